@@ -42,6 +42,7 @@ type ManifestOptions struct {
 	Namespace  string
 	Filename   string
 	K8sContext string
+	Profile    string
 }
 
 func getKubernetesContextList(filterOkteto bool) []string {
@@ -168,12 +169,29 @@ func LoadManifestWithContext(ctx context.Context, opts ManifestOptions) (*model.
 		return nil, err
 	}
 
-	manifest, err := model.GetManifestV1(opts.Filename)
+	manifest, err := loadManifest(opts.Filename)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Profile != "" {
+		manifest, _, err = utils.LoadManifestProfile(manifest, opts.Profile, nil, func() (*model.Manifest, error) {
+			return loadManifest(opts.Filename)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// loadManifest reads the manifest and sets the current context to it and its development containers
+func loadManifest(filename string) (*model.Manifest, error) {
+	manifest, err := model.GetManifestV1(filename)
 	if err != nil {
 		if !errors.Is(err, discovery.ErrOktetoManifestNotFound) {
 			return nil, err
 		}
-		manifest, err = model.GetManifestV2(opts.Filename)
+		manifest, err = model.GetManifestV2(filename)
 		if err != nil {
 			return nil, err
 		}
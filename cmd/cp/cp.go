@@ -0,0 +1,354 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/cheggaaa/pb/v3"
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cp"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Options are the options of the cp command
+type Options struct {
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+}
+
+// location is a path in the local machine or, when remote, in the container of a development container
+type location struct {
+	dev    string
+	path   string
+	remote bool
+}
+
+func (l location) String() string {
+	if l.remote {
+		return fmt.Sprintf("%s:%s", l.dev, l.path)
+	}
+	return l.path
+}
+
+// target is the container files are copied from or to
+type target struct {
+	namespace string
+	pod       string
+	container string
+	c         kubernetes.Interface
+	config    *rest.Config
+}
+
+// Copy copies files and directories between the local machine and a development container
+func Copy(ctx context.Context) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "cp [dev:]src [dev:]dst",
+		Short: "Copy files and directories to and from your development containers",
+		Long: `Copy files and directories to and from your development containers.
+
+Directories are copied recursively. Files are copied over SSH when the development container is running 'okteto up', and with 'tar' in the container of the deployed service otherwise.`,
+		Example: `  okteto cp ./dump.sql api:/tmp/dump.sql
+  okteto cp api:/app/logs ./logs`,
+		Args: utils.ExactArgsAccepted(2, "https://okteto.com/docs/reference/cli/#cp"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			src, dst, err := parseArgs(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			remote := src
+			if dst.remote {
+				remote = dst
+			}
+
+			manifestOpts := contextCMD.ManifestOptions{Filename: options.ManifestPath, Namespace: options.Namespace, K8sContext: options.K8sContext}
+			manifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts)
+			if err != nil {
+				return err
+			}
+
+			dev, err := getDev(manifest, remote.dev)
+			if err != nil {
+				return err
+			}
+
+			c, config, err := okteto.GetK8sClient()
+			if err != nil {
+				return err
+			}
+
+			if err := runCopy(ctx, dev, src, dst, c, config); err != nil {
+				if oktetoErrors.IsNotFound(err) {
+					return oktetoErrors.UserError{
+						E:    fmt.Errorf("development container '%s' not found in namespace '%s'", dev.Name, dev.Namespace),
+						Hint: "Deploy your development environment with 'okteto deploy' or run 'okteto up' and try again",
+					}
+				}
+				return err
+			}
+
+			oktetoLog.Success("Copied '%s' to '%s'", src, dst)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace where the development container is running")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the development container is running")
+	return cmd
+}
+
+// parseLocation parses a '[dev:]path' argument
+func parseLocation(arg string) location {
+	// windows paths like 'C:\src' are local paths
+	if filepath.VolumeName(arg) != "" {
+		return location{path: arg}
+	}
+
+	dev, p, found := strings.Cut(arg, ":")
+	if !found || strings.ContainsAny(dev, `/\`) {
+		return location{path: arg}
+	}
+	if p == "" {
+		p = "."
+	}
+	return location{dev: dev, path: p, remote: true}
+}
+
+func parseArgs(srcArg, dstArg string) (location, location, error) {
+	src := parseLocation(srcArg)
+	dst := parseLocation(dstArg)
+
+	if src.remote == dst.remote {
+		return location{}, location{}, oktetoErrors.UserError{
+			E:    fmt.Errorf("one of the source or the destination must be in a development container"),
+			Hint: "Prefix the path in the development container with its name, for example 'okteto cp ./file api:/tmp/file'",
+		}
+	}
+	return src, dst, nil
+}
+
+func getDev(manifest *model.Manifest, devName string) (*model.Dev, error) {
+	dev, err := utils.GetDevFromManifest(manifest, devName)
+	if err == nil {
+		return dev, nil
+	}
+	if !errors.Is(err, utils.ErrNoDevSelected) {
+		return nil, err
+	}
+	selector := utils.NewOktetoSelector("Select the development container to copy files from or to:", "Development container")
+	return utils.SelectDevFromManifest(manifest, selector, manifest.Dev.GetDevs())
+}
+
+func runCopy(ctx context.Context, dev *model.Dev, src, dst location, c kubernetes.Interface, config *rest.Config) error {
+	devName := dev.Name
+	if dev.Autocreate {
+		dev.Name = model.DevCloneName(dev.Name)
+	}
+	app, err := apps.Get(ctx, dev, dev.Namespace, c)
+	if err != nil {
+		return err
+	}
+
+	if apps.IsDevModeOn(app) {
+		err := copySFTP(ctx, dev, devName, src, dst)
+		if err == nil {
+			return nil
+		}
+		oktetoLog.Infof("failed to copy files over SSH, falling back to tar: %s", err)
+
+		if !dev.Autocreate {
+			app = app.DevClone()
+			if err := app.Refresh(ctx, c); err != nil {
+				return err
+			}
+		}
+	}
+
+	pod, err := app.GetRunningPod(ctx, c)
+	if err != nil {
+		return err
+	}
+	t := &target{
+		namespace: dev.Namespace,
+		pod:       pod.Name,
+		container: dev.Container,
+		c:         c,
+		config:    config,
+	}
+	if t.container == "" {
+		t.container = pod.Spec.Containers[0].Name
+	}
+
+	if dst.remote {
+		return t.upload(ctx, src.path, dst.path)
+	}
+	return t.download(ctx, src.path, dst.path)
+}
+
+// copySFTP copies the files with the SSH server of the development container, available while 'okteto up' is running
+func copySFTP(ctx context.Context, dev *model.Dev, devName string, src, dst location) error {
+	port, err := ssh.GetPort(devName)
+	if err != nil {
+		return err
+	}
+
+	client, err := ssh.NewSFTPClient(ctx, dev.Interface, port)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			oktetoLog.Debugf("Error closing SFTP client: %s", err)
+		}
+	}()
+
+	local := cp.LocalFS{}
+	remote := cp.RemoteFS{Client: client.Client}
+	if dst.remote {
+		total, err := cp.Size(local, src.path)
+		if err != nil {
+			return fmt.Errorf("failed to read '%s': %w", src.path, err)
+		}
+		bar := startProgressBar(total)
+		defer bar.Finish()
+		return cp.Copy(local, src.path, remote, remotePath(dev, dst.path), progress(bar))
+	}
+
+	srcPath := remotePath(dev, src.path)
+	total, err := cp.Size(remote, srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s': %w", src.path, err)
+	}
+	bar := startProgressBar(total)
+	defer bar.Finish()
+	return cp.Copy(remote, srcPath, local, dst.path, progress(bar))
+}
+
+// remotePath resolves relative paths from the working directory of the development container
+func remotePath(dev *model.Dev, p string) string {
+	if path.IsAbs(p) || dev.Workdir == "" {
+		return p
+	}
+	return path.Join(dev.Workdir, p)
+}
+
+// upload copies the local src to dst with 'tar' in the container
+func (t *target) upload(ctx context.Context, src, dst string) error {
+	total, err := cp.Size(cp.LocalFS{}, src)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s': %w", src, err)
+	}
+
+	dir, name := dst, filepath.Base(src)
+	if err := t.exec(ctx, nil, io.Discard, []string{"test", "-d", dst}); err != nil {
+		dir, name = path.Dir(dst), path.Base(dst)
+	}
+
+	bar := startProgressBar(total)
+	defer bar.Finish()
+
+	r, w := io.Pipe()
+	tarErr := make(chan error, 1)
+	go func() {
+		err := cp.Tar(w, src, name, progress(bar))
+		w.CloseWithError(err)
+		tarErr <- err
+	}()
+
+	if err := t.exec(ctx, r, io.Discard, []string{"tar", "xf", "-", "-C", dir}); err != nil {
+		_ = r.CloseWithError(err)
+		return fmt.Errorf("failed to copy '%s' to '%s': %w", src, dst, err)
+	}
+	return <-tarErr
+}
+
+// download copies src in the container to the local dst with 'tar'
+func (t *target) download(ctx context.Context, src, dst string) error {
+	dir, name := dst, path.Base(src)
+	if info, err := os.Stat(dst); err != nil || !info.IsDir() {
+		dir, name = filepath.Dir(dst), filepath.Base(dst)
+	}
+
+	// the size of the files in the container is unknown, the progress bar only shows the copied bytes
+	bar := startProgressBar(0)
+	defer bar.Finish()
+
+	r, w := io.Pipe()
+	execErr := make(chan error, 1)
+	go func() {
+		err := t.exec(ctx, nil, w, []string{"tar", "cf", "-", "-C", path.Dir(src), path.Base(src)})
+		w.CloseWithError(err)
+		execErr <- err
+	}()
+
+	if err := cp.Untar(r, dir, path.Base(src), name, progress(bar)); err != nil {
+		_ = r.CloseWithError(err)
+		if execErr := <-execErr; execErr != nil {
+			return fmt.Errorf("failed to copy '%s' to '%s': %w", src, dst, execErr)
+		}
+		return err
+	}
+	if err := <-execErr; err != nil {
+		return fmt.Errorf("failed to copy '%s' to '%s': %w", src, dst, err)
+	}
+	return nil
+}
+
+// exec runs command in the container, returning its stderr on failure
+func (t *target) exec(ctx context.Context, stdin io.Reader, stdout io.Writer, command []string) error {
+	if stdin == nil {
+		stdin = strings.NewReader("")
+	}
+	stderr := &bytes.Buffer{}
+	if err := exec.Exec(ctx, t.c, t.config, t.namespace, t.pod, t.container, false, stdin, stdout, stderr, command); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func startProgressBar(total int64) *pb.ProgressBar {
+	bar := pb.New64(total)
+	bar.Set(pb.Bytes, true)
+	return bar.Start()
+}
+
+func progress(bar *pb.ProgressBar) cp.ProgressFunc {
+	return func(n int64) {
+		bar.Add64(n)
+	}
+}
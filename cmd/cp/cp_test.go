@@ -0,0 +1,64 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cp
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocation(t *testing.T) {
+	var tests = []struct {
+		name     string
+		arg      string
+		expected location
+	}{
+		{name: "local", arg: "./file.txt", expected: location{path: "./file.txt"}},
+		{name: "remote", arg: "api:/app/file.txt", expected: location{dev: "api", path: "/app/file.txt", remote: true}},
+		{name: "remote-relative", arg: "api:logs", expected: location{dev: "api", path: "logs", remote: true}},
+		{name: "remote-workdir", arg: "api:", expected: location{dev: "api", path: ".", remote: true}},
+		{name: "remote-default-dev", arg: ":/app", expected: location{path: "/app", remote: true}},
+		{name: "local-with-colon", arg: "./backup:1/file.txt", expected: location{path: "./backup:1/file.txt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseLocation(tt.arg))
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	src, dst, err := parseArgs("./dump.sql", "api:/tmp/dump.sql")
+	require.NoError(t, err)
+	assert.False(t, src.remote)
+	assert.True(t, dst.remote)
+
+	_, _, err = parseArgs("./a", "./b")
+	assert.Error(t, err)
+
+	_, _, err = parseArgs("api:/a", "worker:/b")
+	assert.Error(t, err)
+}
+
+func TestRemotePath(t *testing.T) {
+	dev := &model.Dev{Workdir: "/app"}
+	assert.Equal(t, "/tmp/file", remotePath(dev, "/tmp/file"))
+	assert.Equal(t, "/app/logs", remotePath(dev, "logs"))
+	assert.Equal(t, "/app", remotePath(dev, "."))
+	assert.Equal(t, "logs", remotePath(&model.Dev{}, "logs"))
+}
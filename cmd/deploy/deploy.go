@@ -71,6 +71,7 @@ type Options struct {
 	Dependencies     bool
 	RunWithoutBash   bool
 	RunInRemote      bool
+	Profile          string
 	servicesToDeploy []string
 
 	Repository string
@@ -239,6 +240,7 @@ func Deploy(ctx context.Context) *cobra.Command {
 	cmd.Flags().BoolVarP(&options.Dependencies, "dependencies", "", false, "deploy the dependencies from manifest")
 	cmd.Flags().BoolVarP(&options.RunWithoutBash, "no-bash", "", false, "execute commands without bash")
 	cmd.Flags().BoolVarP(&options.RunInRemote, "remote", "", false, "force run deploy commands in remote")
+	cmd.Flags().StringVarP(&options.Profile, "profile", "", "", "deploy the subset of the manifest defined by a profile")
 
 	cmd.Flags().BoolVarP(&options.Wait, "wait", "w", false, "wait until the development environment is deployed (defaults to false)")
 	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", getDefaultTimeout(), "the length of time to wait for completion, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
@@ -253,6 +255,16 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 	if err != nil {
 		return fmt.Errorf("failed to load manifest: %w", err)
 	}
+	if deployOptions.Profile != "" {
+		var profileVariables []string
+		manifest, profileVariables, err = utils.LoadManifestProfile(manifest, deployOptions.Profile, deployOptions.Variables, func() (*model.Manifest, error) {
+			return dc.GetManifest(deployOptions.ManifestPath)
+		})
+		if err != nil {
+			return err
+		}
+		deployOptions.Variables = append(deployOptions.Variables, profileVariables...)
+	}
 	deployOptions.Manifest = manifest
 	oktetoLog.Debug("found okteto manifest")
 	dc.PipelineType = deployOptions.Manifest.Type
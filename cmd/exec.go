@@ -198,6 +198,7 @@ func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
 
 		dev.RemotePort = p
 		oktetoLog.Infof("executing remote command over SSH port %d", dev.RemotePort)
+		up.WarnIfSSHAgentNotFound(dev)
 
 		dev.LoadRemote(ssh.GetPublicKey())
 		oktetoLog.StopSpinner()
@@ -227,7 +228,7 @@ func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
 			return executor.RunCommand(cmd)
 		}
 
-		return ssh.Exec(ctx, dev.Interface, dev.RemotePort, true, os.Stdin, os.Stdout, os.Stderr, wrapped)
+		return ssh.Exec(ctx, dev.Interface, dev.RemotePort, true, dev.SSHAgent, os.Stdin, os.Stdout, os.Stderr, wrapped)
 	}
 	oktetoLog.StopSpinner()
 	return exec.Exec(ctx, c, cfg, dev.Namespace, pod.Name, dev.Container, true, os.Stdin, os.Stdout, os.Stderr, wrapped)
@@ -15,6 +15,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
@@ -33,6 +34,10 @@ func Init() *cobra.Command {
 		Use:   "init",
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#init"),
 		Short: "Automatically generate your okteto manifest",
+		Long: `Automatically generate your okteto manifest.
+
+Use the flag '--from' to create it from the configuration of another tool: 'devcontainer' reads '.devcontainer/devcontainer.json' and 'skaffold' reads 'skaffold.yaml'.
+Tiltfiles are not supported: they are programs, not configuration files that can be translated.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
@@ -62,6 +67,13 @@ func Init() *cobra.Command {
 			mc := &manifest.ManifestCommand{
 				K8sClientProvider: okteto.NewK8sClientProvider(),
 			}
+			if opts.From != "" {
+				if opts.Version1 {
+					return fmt.Errorf("the flags '--from' and '--v1' can't be used together")
+				}
+				_, err := mc.RunInitFrom(opts)
+				return err
+			}
 			if opts.Version1 {
 				if err := mc.RunInitV1(ctx, opts); err != nil {
 					return err
@@ -81,5 +93,7 @@ func Init() *cobra.Command {
 	cmd.Flags().BoolVarP(&opts.Version1, "v1", "", false, "create a v1 okteto manifest: www.okteto.com/docs/0.10/reference/manifest/")
 	cmd.Flags().BoolVarP(&opts.AutoDeploy, "deploy", "", false, "deploy the application after generate the okteto manifest")
 	cmd.Flags().BoolVarP(&opts.AutoConfigureDev, "configure-devs", "", false, "configure devs after deploying the application")
+	cmd.Flags().StringVarP(&opts.From, "from", "", "", "create the okteto manifest from the configuration of another tool. One of: ['devcontainer', 'skaffold']")
+	cmd.Flags().StringVarP(&opts.FromFile, "from-file", "", "", "path to the configuration file of the flag '--from'")
 	return cmd
 }
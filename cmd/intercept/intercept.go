@@ -0,0 +1,302 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intercept
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/divert/istio"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/intercept"
+	forwardk8s "github.com/okteto/okteto/pkg/k8s/forward"
+	"github.com/okteto/okteto/pkg/k8s/virtualservices"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/spf13/cobra"
+	istioV1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	istioclientset "istio.io/client-go/pkg/clientset/versioned"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	agentTimeout           = 2 * time.Minute
+	interceptDocsReference = "https://okteto.com/docs/reference/cli/#intercept"
+)
+
+// Options are the options of the intercept command
+type Options struct {
+	Namespace string
+	Context   string
+	Port      string
+	Headers   []string
+}
+
+// Intercept sends the traffic of a service to a local port
+func Intercept(ctx context.Context) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "intercept <service>",
+		Short: "Send the traffic of a service of your namespace to a local process",
+		Long: `Send the traffic of a service of your namespace to a local process.
+
+The workloads of the service are replaced by a lightweight agent that tunnels the requests to your local port.
+With '--header', only the requests with these headers are sent to your local port, using the Istio virtual services of your namespace, and the rest of the traffic keeps going to the service.
+The original configuration is restored on exit.`,
+		Example: `okteto intercept api --port 8080:80
+okteto intercept api --port 8080:80 --header x-user=alice`,
+		Args: utils.ExactArgsAccepted(1, interceptDocsReference),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			localPort, port, err := intercept.ParsePort(options.Port)
+			if err != nil {
+				return oktetoErrors.UserError{E: err, Hint: "Use '--port <local>:<remote>', like '--port 8080:80'"}
+			}
+			headers, err := intercept.ParseHeaders(options.Headers)
+			if err != nil {
+				return oktetoErrors.UserError{E: err, Hint: "Use '--header <name>=<value>', like '--header x-user=alice'"}
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   options.Context,
+				Namespace: options.Namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			i := &intercept.Intercept{
+				Service:   args[0],
+				Namespace: okteto.Context().Namespace,
+				LocalPort: localPort,
+				Port:      port,
+				Headers:   headers,
+			}
+			return run(ctx, i)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Port, "port", "p", "", "the local port and the port of the service to intercept, as '<local>:<remote>'")
+	cmd.Flags().StringArrayVar(&options.Headers, "header", nil, "only intercept the requests with this header, as '<name>=<value>'")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "the namespace of the service (defaults to the current okteto namespace)")
+	cmd.Flags().StringVarP(&options.Context, "context", "c", "", "the context to use")
+	if err := cmd.MarkFlagRequired("port"); err != nil {
+		oktetoLog.Infof("failed to mark flag 'port' as required: %s", err)
+	}
+	return cmd
+}
+
+func run(ctx context.Context, i *intercept.Intercept) error {
+	c, restConfig, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+	if err != nil {
+		return err
+	}
+
+	var ic istioclientset.Interface
+	if i.IsHeaderBased() {
+		ic, err = virtualservices.GetIstioClient()
+		if err != nil {
+			return fmt.Errorf("error creating istio client: %w", err)
+		}
+	}
+
+	if err := i.Load(ctx, c); err != nil {
+		return err
+	}
+
+	if !ssh.KeyExists() {
+		if err := ssh.GenerateKeys(); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	exit := make(chan error, 1)
+	go func() {
+		sigint := make(chan os.Signal, 1)
+		signal.Notify(sigint, syscall.SIGTERM, syscall.SIGINT)
+		<-sigint
+		exit <- nil
+		cancel()
+	}()
+
+	// restore with a new context, the intercept context is cancelled on exit
+	defer restore(context.Background(), i, c, ic)
+
+	oktetoLog.Spinner(fmt.Sprintf("Deploying intercept agent for service '%s'...", i.Service))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	if err := i.DeployAgent(ctx, c, ssh.GetPublicKey()); err != nil {
+		return err
+	}
+
+	pod, err := waitForAgent(ctx, i, c)
+	if err != nil {
+		return err
+	}
+
+	fm, err := startTunnel(ctx, i, c, restConfig, pod.Name)
+	if err != nil {
+		return err
+	}
+	defer fm.Stop()
+
+	if i.IsHeaderBased() {
+		if err := addInterceptRoutes(ctx, i, ic); err != nil {
+			return err
+		}
+	} else {
+		if err := i.ExposeAgent(ctx, c, pod); err != nil {
+			return err
+		}
+		if err := i.SwapWorkloads(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	oktetoLog.StopSpinner()
+	oktetoLog.Success("Intercepting service '%s'", i.Service)
+	oktetoLog.Println(fmt.Sprintf("    %s %s:%d -> localhost:%d", oktetoLog.BlueString("Traffic:"), i.Service, i.Port, i.LocalPort))
+	if i.IsHeaderBased() {
+		oktetoLog.Println(fmt.Sprintf("    %s %s", oktetoLog.BlueString("Headers:"), formatHeaders(i.Headers)))
+	}
+	oktetoLog.Println("Press Ctrl+C to stop intercepting the service")
+
+	return <-exit
+}
+
+// waitForAgent waits until the pod of the agent is running
+func waitForAgent(ctx context.Context, i *intercept.Intercept, c kubernetes.Interface) (*apiv1.Pod, error) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	to := time.Now().Add(agentTimeout)
+	for {
+		pod, err := i.GetAgentPod(ctx, c)
+		if err == nil {
+			return pod, nil
+		}
+		if !oktetoErrors.IsNotFound(err) {
+			return nil, err
+		}
+		if time.Now().After(to) {
+			return nil, fmt.Errorf("the intercept agent of service '%s' didn't start after %s", i.Service, agentTimeout.String())
+		}
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			return nil, oktetoErrors.ErrIntSig
+		}
+	}
+}
+
+// startTunnel opens a SSH tunnel to the agent that sends the traffic received by the agent to the local port
+func startTunnel(ctx context.Context, i *intercept.Intercept, c kubernetes.Interface, restConfig *rest.Config, pod string) (*ssh.ForwardManager, error) {
+	sshPort, err := model.GetAvailablePort(model.Localhost)
+	if err != nil {
+		return nil, err
+	}
+
+	pf := forwardk8s.NewPortForwardManager(ctx, model.Localhost, restConfig, c, i.Namespace)
+	if err := pf.Add(forward.Forward{Local: sshPort, Remote: intercept.SSHPort}); err != nil {
+		return nil, err
+	}
+
+	fm := ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", sshPort), model.Localhost, "0.0.0.0", pf, i.Namespace)
+	if err := fm.AddReverse(model.Reverse{Local: i.LocalPort, Remote: i.TargetPort}); err != nil {
+		return nil, err
+	}
+	if err := fm.Start(pod, i.Namespace); err != nil {
+		return nil, err
+	}
+	return fm, nil
+}
+
+// addInterceptRoutes routes the requests with the headers of the intercept to the agent in the virtual services of the namespace
+func addInterceptRoutes(ctx context.Context, i *intercept.Intercept, ic istioclientset.Interface) error {
+	route := &istio.InterceptRoute{
+		Service:     i.Service,
+		Namespace:   i.Namespace,
+		Headers:     i.Headers,
+		Destination: fmt.Sprintf("%s.%s.svc.cluster.local", intercept.AgentName(i.Service), i.Namespace),
+		Port:        uint32(i.Port),
+	}
+
+	updated, err := istio.UpdateVirtualServices(ctx, i.Namespace, ic, func(vs *istioV1beta1.VirtualService) *istioV1beta1.VirtualService {
+		return istio.TranslateInterceptVirtualService(vs, route)
+	})
+	if err != nil {
+		return err
+	}
+	if len(updated) == 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("no virtual service of namespace '%s' routes requests to service '%s'", i.Namespace, i.Service),
+			Hint: "Header based intercepts require an Istio virtual service routing the traffic of the service",
+		}
+	}
+	return nil
+}
+
+// restore removes the agent and restores the original routing of the service
+func restore(ctx context.Context, i *intercept.Intercept, c kubernetes.Interface, ic istioclientset.Interface) {
+	oktetoLog.Spinner(fmt.Sprintf("Restoring service '%s'...", i.Service))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	if i.IsHeaderBased() && ic != nil {
+		_, err := istio.UpdateVirtualServices(ctx, i.Namespace, ic, func(vs *istioV1beta1.VirtualService) *istioV1beta1.VirtualService {
+			return istio.RestoreInterceptVirtualService(vs, i.Service)
+		})
+		if err != nil {
+			oktetoLog.Warning("failed to restore the virtual services of service '%s': %s", i.Service, err)
+		}
+	}
+
+	if err := i.RestoreWorkloads(ctx, c); err != nil {
+		oktetoLog.Warning("failed to restore the workloads of service '%s': %s", i.Service, err)
+	}
+
+	if err := i.DestroyAgent(ctx, c); err != nil {
+		oktetoLog.Warning("failed to delete the intercept agent of service '%s': %s", i.Service, err)
+		return
+	}
+
+	oktetoLog.StopSpinner()
+	oktetoLog.Success("Service '%s' restored", i.Service)
+}
+
+func formatHeaders(headers map[string]string) string {
+	result := make([]string, 0, len(headers))
+	for name, value := range headers {
+		result = append(result, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(result)
+	return strings.Join(result, ", ")
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/discovery"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const (
+	devContainerSource = "devcontainer"
+	skaffoldSource     = "skaffold"
+)
+
+// RunInitFrom creates the okteto manifest from the configuration of another tool
+func (*ManifestCommand) RunInitFrom(opts *InitOpts) (*model.Manifest, error) {
+	if !opts.Overwrite && filesystem.FileExists(opts.DevPath) {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("the okteto manifest '%s' already exists", opts.DevPath),
+			Hint: "Use the flag '--replace' to overwrite it",
+		}
+	}
+
+	var getPath func(wd string) (string, error)
+	var translate func(path, workdir string) (*model.Manifest, []string, error)
+	switch opts.From {
+	case devContainerSource:
+		getPath = discovery.GetDevContainerPath
+		translate = model.ManifestFromDevContainer
+	case skaffoldSource:
+		getPath = discovery.GetSkaffoldPath
+		translate = model.ManifestFromSkaffold
+	default:
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' is not a supported value for the flag '--from'", opts.From),
+			Hint: fmt.Sprintf("Supported values are: ['%s', '%s']", devContainerSource, skaffoldSource),
+		}
+	}
+
+	sourcePath := opts.FromFile
+	if sourcePath == "" {
+		var err error
+		sourcePath, err = getPath(opts.Workdir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	manifest, unsupported, err := translate(sourcePath, opts.Workdir)
+	if err != nil {
+		return nil, err
+	}
+	if len(unsupported) > 0 {
+		oktetoLog.Warning("The following fields of '%s' are not supported and were ignored: %s", sourcePath, strings.Join(unsupported, ", "))
+	}
+
+	if err := manifest.WriteToFile(opts.DevPath); err != nil {
+		return nil, err
+	}
+	oktetoLog.Success("Okteto manifest (%s) created from '%s'", opts.DevPath, sourcePath)
+	if opts.ShowCTA {
+		if manifest.Deploy != nil {
+			oktetoLog.Information("Run 'okteto deploy' to deploy your development environment")
+		}
+		oktetoLog.Information("Run 'okteto up' to activate your development container")
+	}
+	return manifest, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunInitFromDevContainer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "api")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".devcontainer"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".devcontainer", "devcontainer.json"), []byte(`{
+  "image": "okteto/golang:1",
+  "forwardPorts": [8080],
+  "runArgs": ["--privileged"]
+}`), 0600))
+	devPath := filepath.Join(dir, "okteto.yml")
+
+	mc := &ManifestCommand{}
+	_, err := mc.RunInitFrom(&InitOpts{DevPath: devPath, Workdir: dir, From: devContainerSource})
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(devPath)
+	require.NoError(t, err)
+	manifest, err := model.Read(b)
+	require.NoError(t, err)
+	require.Contains(t, manifest.Dev, "api")
+	assert.Equal(t, "okteto/golang:1", manifest.Dev["api"].Image.Name)
+	assert.True(t, manifest.Dev["api"].Autocreate)
+
+	_, err = mc.RunInitFrom(&InitOpts{DevPath: devPath, Workdir: dir, From: devContainerSource})
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+
+	_, err = mc.RunInitFrom(&InitOpts{DevPath: devPath, Workdir: dir, From: devContainerSource, Overwrite: true})
+	assert.NoError(t, err)
+}
+
+func TestRunInitFromSkaffold(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "skaffold.yaml"), []byte(`apiVersion: skaffold/v4beta6
+kind: Config
+build:
+  artifacts:
+    - image: api
+manifests:
+  rawYaml:
+    - k8s.yaml
+`), 0600))
+	devPath := filepath.Join(dir, "okteto.yml")
+
+	mc := &ManifestCommand{}
+	_, err := mc.RunInitFrom(&InitOpts{DevPath: devPath, Workdir: dir, From: skaffoldSource})
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(devPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "# The build section defines how to build the images of your development environment")
+	manifest, err := model.Read(b)
+	require.NoError(t, err)
+	assert.Contains(t, manifest.Build, "api")
+	require.Len(t, manifest.Deploy.Commands, 1)
+	assert.Equal(t, "kubectl apply -f k8s.yaml", manifest.Deploy.Commands[0].Command)
+}
+
+func TestRunInitFromUnknownSource(t *testing.T) {
+	mc := &ManifestCommand{}
+	_, err := mc.RunInitFrom(&InitOpts{DevPath: filepath.Join(t.TempDir(), "okteto.yml"), From: "unknown"})
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
@@ -63,6 +63,9 @@ type InitOpts struct {
 
 	AutoDeploy       bool
 	AutoConfigureDev bool
+
+	From     string
+	FromFile string
 }
 
 // Init automatically generates the manifest
@@ -101,6 +104,13 @@ func Init() *cobra.Command {
 			mc := &ManifestCommand{
 				K8sClientProvider: okteto.NewK8sClientProvider(),
 			}
+			if opts.From != "" {
+				if opts.Version1 {
+					return fmt.Errorf("the flags '--from' and '--v1' can't be used together")
+				}
+				_, err := mc.RunInitFrom(opts)
+				return err
+			}
 			if opts.Version1 {
 				if err := mc.RunInitV1(ctx, opts); err != nil {
 					return err
@@ -120,6 +130,8 @@ func Init() *cobra.Command {
 	cmd.Flags().BoolVarP(&opts.Version1, "v1", "", false, "create a v1 okteto manifest: https://www.okteto.com/docs/reference/manifest/")
 	cmd.Flags().BoolVarP(&opts.AutoDeploy, "deploy", "", false, "deploy the application after generate the okteto manifest if it's not running already")
 	cmd.Flags().BoolVarP(&opts.AutoConfigureDev, "configure-devs", "", false, "configure devs after deploying the application")
+	cmd.Flags().StringVarP(&opts.From, "from", "", "", "create the okteto manifest from the configuration of another tool. One of: ['devcontainer', 'skaffold']")
+	cmd.Flags().StringVarP(&opts.FromFile, "from-file", "", "", "path to the configuration file of the flag '--from'")
 	return cmd
 }
 
@@ -0,0 +1,144 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/discovery"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/lint"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	textOutput  = "text"
+	jsonOutput  = "json"
+	sarifOutput = "sarif"
+)
+
+// LintOptions are the options of the manifest lint command
+type LintOptions struct {
+	ManifestPath string
+	ConfigPath   string
+	Output       string
+}
+
+// Lint checks the okteto manifest for common mistakes
+func Lint() *cobra.Command {
+	opts := &LintOptions{}
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check your okteto manifest for common mistakes",
+		Long: `Check your okteto manifest for common mistakes.
+
+Rules can be disabled in the lint configuration file ('.okteto-lint.yml' in the folder of the manifest by default):
+
+  disable: [OKL005]
+  severity:
+    missing-resource-limits: warning
+
+or with inline comments in the manifest:
+
+  # okteto-lint-disable OKL006             disables a rule in the whole file
+  # okteto-lint-disable-next-line OKL005   disables a rule in the next line
+  image: okteto/dev  # okteto-lint-disable-line latest-image`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#manifest-lint"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateLintOutput(opts.Output); err != nil {
+				return err
+			}
+			if opts.ManifestPath == "" {
+				wd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				if opts.ManifestPath, err = discovery.GetOktetoManifestPath(wd); err != nil {
+					return err
+				}
+			}
+			if opts.ConfigPath == "" {
+				opts.ConfigPath = filepath.Join(filepath.Dir(opts.ManifestPath), lint.ConfigFile)
+			}
+			return runLint(cmd.OutOrStdout(), opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&opts.ConfigPath, "config", "", "", "path to the lint configuration file")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", textOutput, "output format. One of: ['text', 'json', 'sarif']")
+	return cmd
+}
+
+func validateLintOutput(output string) error {
+	switch output {
+	case textOutput, jsonOutput, sarifOutput:
+		return nil
+	default:
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['text', 'json', 'sarif']")
+	}
+}
+
+func runLint(w io.Writer, opts *LintOptions) error {
+	c, err := lint.LoadConfig(opts.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(opts.ManifestPath)
+	if err != nil {
+		return err
+	}
+
+	// the deprecation warnings printed while reading the manifest are reported by the linter
+	oktetoLog.SetOutput(io.Discard)
+	m, err := lint.NewManifest(opts.ManifestPath, b)
+	oktetoLog.SetOutput(os.Stdout)
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid manifest '%s': %w", opts.ManifestPath, err),
+			Hint: "Run 'okteto manifest validate' to find the errors of your manifest",
+		}
+	}
+
+	linter := lint.NewLinter(c)
+	issues := linter.Lint(m)
+	switch opts.Output {
+	case jsonOutput:
+		err = lint.WriteJSON(w, opts.ManifestPath, issues)
+	case sarifOutput:
+		err = lint.WriteSARIF(w, opts.ManifestPath, config.VersionString, linter.Rules(), issues)
+	default:
+		err = lint.WriteText(w, opts.ManifestPath, issues)
+		if err == nil && len(issues) == 0 {
+			oktetoLog.Success("No issues found in '%s'", opts.ManifestPath)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if lint.HasErrors(issues) {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' has lint errors", opts.ManifestPath),
+			Hint: "Fix the errors or disable their rules in your lint configuration",
+		}
+	}
+	return nil
+}
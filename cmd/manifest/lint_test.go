@@ -0,0 +1,56 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/lint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLint(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "okteto.yml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`dev:
+  api:
+    image: okteto/golang:1
+    forward:
+      - 8080:80
+      - 8080:81
+`), 0600))
+	configPath := filepath.Join(dir, lint.ConfigFile)
+
+	out := &bytes.Buffer{}
+	err := runLint(out, &LintOptions{ManifestPath: manifestPath, ConfigPath: configPath, Output: textOutput})
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+	assert.Contains(t, out.String(), manifestPath+":6:9: error: local port 8080 is forwarded more than once")
+
+	require.NoError(t, os.WriteFile(configPath, []byte("severity:\n  forward-collision: warning\n"), 0600))
+	out.Reset()
+	err = runLint(out, &LintOptions{ManifestPath: manifestPath, ConfigPath: configPath, Output: jsonOutput})
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), `"severity": "warning"`)
+}
+
+func TestValidateLintOutput(t *testing.T) {
+	assert.NoError(t, validateLintOutput("text"))
+	assert.NoError(t, validateLintOutput("sarif"))
+	assert.Error(t, validateLintOutput("yaml"))
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"context"
+	"os"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/discovery"
+	"github.com/spf13/cobra"
+)
+
+// Manifest has the subcommands to inspect okteto manifests
+func Manifest(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Inspect and validate your okteto manifest",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#manifest"),
+	}
+	cmd.AddCommand(Schema())
+	cmd.AddCommand(Validate())
+	cmd.AddCommand(Lint())
+	cmd.AddCommand(Render())
+	cmd.AddCommand(Migrate())
+	return cmd
+}
+
+// getManifestPath returns the manifest path given by the user, or the okteto manifest or compose file of the current folder
+func getManifestPath(manifestPath string) (string, error) {
+	if manifestPath != "" {
+		return manifestPath, nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	if path, err := discovery.GetOktetoManifestPath(wd); err == nil {
+		return path, nil
+	}
+	if path, err := discovery.GetComposePath(wd); err == nil {
+		return path, nil
+	}
+	return "", discovery.ErrOktetoManifestNotFound
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/discovery"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+// MigrateOptions are the options of the manifest migrate command
+type MigrateOptions struct {
+	ManifestPath string
+	Yes          bool
+	DryRun       bool
+}
+
+// Migrate converts an okteto manifest v1 into an okteto manifest v2
+func Migrate() *cobra.Command {
+	opts := &MigrateOptions{}
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate your okteto manifest to the okteto manifest v2",
+		Long: `Migrate your okteto manifest to the okteto manifest v2.
+
+The development container is moved to the 'dev' section and images built with the 'image' extended syntax are moved to the 'build' section.
+The commands of the okteto pipeline file are moved to the 'deploy' section. Otherwise, the compose file of the folder is referenced from the 'deploy' section.
+Comments are preserved where possible. A diff is shown before writing the manifest.`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#manifest-migrate"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestPath := opts.ManifestPath
+			if manifestPath == "" {
+				wd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				manifestPath, err = discovery.GetOktetoManifestPath(wd)
+				if err != nil {
+					return err
+				}
+			}
+			opts.ManifestPath = manifestPath
+			return runMigrate(cmd.OutOrStdout(), opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "write the manifest without asking for confirmation")
+	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "", false, "show the diff without writing the manifest")
+	return cmd
+}
+
+func runMigrate(w io.Writer, opts *MigrateOptions) error {
+	current, err := os.ReadFile(opts.ManifestPath)
+	if err != nil {
+		return err
+	}
+
+	migration, err := migrateToV2(opts.ManifestPath)
+	if err != nil {
+		if errors.Is(err, model.ErrManifestAlreadyV2) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("'%s': %w", opts.ManifestPath, err),
+				Hint: "Run 'okteto manifest validate' to check it",
+			}
+		}
+		return err
+	}
+
+	if migration.IgnoredComposePath != "" {
+		oktetoLog.Warning("The 'deploy' section uses the commands of '%s', '%s' is not referenced from it", migration.PipelinePath, migration.IgnoredComposePath)
+	}
+
+	name := filepath.Base(opts.ManifestPath)
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(current)),
+		B:        difflib.SplitLines(string(migration.Content)),
+		FromFile: "a/" + name,
+		ToFile:   "b/" + name,
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(w, diff)
+
+	if opts.DryRun {
+		return nil
+	}
+	if !opts.Yes {
+		write, err := utils.AskYesNo(fmt.Sprintf("Do you want to write the okteto manifest v2 to '%s'?", opts.ManifestPath), utils.YesNoDefault_Yes)
+		if err != nil {
+			return err
+		}
+		if !write {
+			oktetoLog.Information("The okteto manifest was not migrated")
+			return nil
+		}
+	}
+
+	if err := os.WriteFile(opts.ManifestPath, migration.Content, 0600); err != nil {
+		return err
+	}
+	oktetoLog.Success("Okteto manifest migrated to '%s'", opts.ManifestPath)
+	if migration.PipelinePath != "" {
+		oktetoLog.Information("The commands of '%s' are in the okteto manifest now, you can remove it", migration.PipelinePath)
+	}
+	return nil
+}
+
+// migrateToV2 migrates the manifest without its logs, the deprecation warnings of the manifest v1 are not relevant while migrating it
+func migrateToV2(manifestPath string) (*model.Migration, error) {
+	previous := oktetoLog.GetOutput()
+	oktetoLog.SetOutput(io.Discard)
+	defer oktetoLog.SetOutput(previous)
+	return model.MigrateToV2(manifestPath)
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const manifestV1 = `name: api
+image: okteto/golang:1
+command: bash # start a shell
+`
+
+func TestRunMigrate(t *testing.T) {
+	var tests = []struct {
+		name          string
+		opts          MigrateOptions
+		expectedWrite bool
+	}{
+		{
+			name:          "write",
+			opts:          MigrateOptions{Yes: true},
+			expectedWrite: true,
+		},
+		{
+			name: "dry-run",
+			opts: MigrateOptions{DryRun: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifestPath := filepath.Join(t.TempDir(), "okteto.yml")
+			require.NoError(t, os.WriteFile(manifestPath, []byte(manifestV1), 0600))
+			tt.opts.ManifestPath = manifestPath
+
+			out := &bytes.Buffer{}
+			require.NoError(t, runMigrate(out, &tt.opts))
+			assert.Contains(t, out.String(), "--- a/okteto.yml")
+			assert.Contains(t, out.String(), "-name: api")
+			assert.Contains(t, out.String(), "+dev:")
+
+			b, err := os.ReadFile(manifestPath)
+			require.NoError(t, err)
+			if tt.expectedWrite {
+				assert.Contains(t, string(b), "dev:\n  api:\n")
+				assert.Contains(t, string(b), "command: bash # start a shell")
+			} else {
+				assert.Equal(t, manifestV1, string(b))
+			}
+		})
+	}
+}
+
+func TestRunMigrateAlreadyV2(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "okteto.yml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte("dev:\n  api:\n    command: bash\n"), 0600))
+
+	err := runMigrate(&bytes.Buffer{}, &MigrateOptions{ManifestPath: manifestPath, Yes: true})
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
+
+func TestRunMigrateRestoresLogOutput(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "okteto.yml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(manifestV1), 0600))
+
+	previous := oktetoLog.GetOutput()
+	defer oktetoLog.SetOutput(previous)
+	logs := &bytes.Buffer{}
+	oktetoLog.SetOutput(logs)
+
+	require.NoError(t, runMigrate(&bytes.Buffer{}, &MigrateOptions{ManifestPath: manifestPath, DryRun: true}))
+	assert.Equal(t, logs, oktetoLog.GetOutput())
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultProvenance = "default"
+
+// variableRegex matches the variables referenced by a value
+var variableRegex = regexp.MustCompile(`\$\{?([A-Za-z_][A-Za-z0-9_]*)`)
+
+// source is a file the rendered manifest is built from
+type source struct {
+	name string
+	root *yaml.Node
+}
+
+// origin is the node of a source that defines a value of the rendered manifest.
+// Origins without node are files that define the value without a known position, like the compose files
+type origin struct {
+	source *source
+	node   *yaml.Node
+}
+
+// provenance adds comments to the rendered manifest with the file or variable each value comes from
+type provenance struct {
+	manifest  *source
+	devRc     *source
+	compose   []string
+	isV2      bool
+	variables map[string]string
+}
+
+func newProvenance(m *model.Manifest, manifestPath, devRcPath string, variables map[string]string) (*provenance, error) {
+	p := &provenance{isV2: m.IsV2, variables: variables}
+
+	var err error
+	if p.manifest, err = readSource(manifestPath); err != nil {
+		return nil, err
+	}
+	if devRcPath != "" {
+		if p.devRc, err = readSource(devRcPath); err != nil {
+			return nil, err
+		}
+	}
+	if m.Deploy != nil && m.Deploy.ComposeSection != nil {
+		for _, composeInfo := range m.Deploy.ComposeSection.ComposesInfo {
+			p.compose = append(p.compose, displayPath(composeInfo.File))
+		}
+	}
+	return p, nil
+}
+
+func readSource(path string) (*source, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(b, doc); err != nil {
+		return nil, err
+	}
+	s := &source{name: displayPath(path)}
+	if len(doc.Content) > 0 {
+		s.root = doc.Content[0]
+	}
+	return s, nil
+}
+
+// annotate adds the provenance comments to the scalars of the rendered manifest
+func (p *provenance) annotate(root *yaml.Node) {
+	var origins []origin
+	if p.isV2 {
+		origins = append(origins, origin{source: p.manifest, node: p.manifest.root})
+	}
+	p.walk(root, nil, origins)
+}
+
+func (p *provenance) walk(n *yaml.Node, path []string, origins []origin) {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		n.LineComment = p.comment(n, origins)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			childPath := append(append([]string{}, path...), key.Value)
+			p.walk(value, childPath, p.childOrigins(childPath, key.Value, -1, value, origins))
+		}
+	case yaml.SequenceNode:
+		for i, item := range n.Content {
+			childPath := append(append([]string{}, path...), fmt.Sprintf("[%d]", i))
+			p.walk(item, childPath, p.childOrigins(childPath, "", i, item, origins))
+		}
+	}
+}
+
+// childOrigins returns the origins of a value of the rendered manifest, found by key in maps and by value or index in lists
+func (p *provenance) childOrigins(path []string, key string, index int, value *yaml.Node, origins []origin) []origin {
+	var result []origin
+	for _, o := range origins {
+		if o.node == nil {
+			result = append(result, o)
+			continue
+		}
+		if child := findChild(o.node, key, index, value); child != nil {
+			result = append(result, origin{source: o.source, node: child})
+		}
+	}
+
+	// the development containers start from their definition in the manifest, the developer level manifest and the compose files
+	if len(path) == 2 && path[0] == "dev" {
+		if !p.isV2 {
+			result = append(result, origin{source: p.manifest, node: p.manifest.root})
+		}
+		if p.devRc != nil && p.devRc.root != nil {
+			result = append(result, origin{source: p.devRc, node: p.devRc.root})
+		}
+	}
+	if len(path) == 2 && (path[0] == "dev" || path[0] == "build") && len(result) == 0 {
+		for _, compose := range p.compose {
+			result = append(result, origin{source: &source{name: compose}})
+		}
+	}
+	return result
+}
+
+// findChild returns the node of a source that defines value, the child of n at key or index
+func findChild(n *yaml.Node, key string, index int, value *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	switch {
+	case n.Kind == yaml.ScalarNode:
+		// values written with the short syntax, like 'image: okteto/golang', define one field of the rendered map
+		if value.Kind == yaml.ScalarNode && sameValue(n.Value, value.Value) {
+			return n
+		}
+	case index < 0:
+		return mappingValue(n, key)
+	case n.Kind == yaml.SequenceNode && value.Kind == yaml.ScalarNode:
+		// lists are merged and sorted, their items are found by value or by the name of 'NAME=VALUE' items
+		name, _, isEnv := strings.Cut(value.Value, "=")
+		for _, item := range n.Content {
+			if item.Kind != yaml.ScalarNode {
+				continue
+			}
+			if sameValue(item.Value, value.Value) || (isEnv && strings.HasPrefix(item.Value, name+"=")) {
+				return item
+			}
+		}
+	case n.Kind == yaml.SequenceNode:
+		if index < len(n.Content) {
+			return n.Content[index]
+		}
+	case n.Kind == yaml.MappingNode && value.Kind == yaml.ScalarNode:
+		// maps of variables are rendered as lists of 'NAME=VALUE' items
+		if name, _, found := strings.Cut(value.Value, "="); found {
+			return mappingValue(n, name)
+		}
+	}
+	return nil
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sameValue returns if the value of a source is the rendered value, before or after expanding its variables
+func sameValue(sourceValue, rendered string) bool {
+	return sourceValue == rendered || os.ExpandEnv(sourceValue) == rendered
+}
+
+// comment returns the provenance of a scalar: the last source that defines it, or the files without position that define it
+func (p *provenance) comment(n *yaml.Node, origins []origin) string {
+	for i := len(origins) - 1; i >= 0; i-- {
+		o := origins[i]
+		if o.node == nil {
+			continue
+		}
+		if o.node.Kind == yaml.ScalarNode || n.Value == "" {
+			return p.describe(o)
+		}
+	}
+
+	var result []string
+	for _, o := range origins {
+		if o.node == nil && !contains(result, o.source.name) {
+			result = append(result, o.source.name)
+		}
+	}
+	if len(result) == 0 {
+		return defaultProvenance
+	}
+	return strings.Join(result, ", ")
+}
+
+func (p *provenance) describe(o origin) string {
+	result := fmt.Sprintf("%s:%d", o.source.name, o.node.Line)
+	if o.node.Kind != yaml.ScalarNode {
+		return result
+	}
+	if variables := p.describeVariables(o.node.Value); variables != "" {
+		result = fmt.Sprintf("%s (%s)", result, variables)
+	}
+	return result
+}
+
+// describeVariables returns where the variables referenced by a value are defined
+func (p *provenance) describeVariables(value string) string {
+	names := map[string]bool{}
+	for _, match := range variableRegex.FindAllStringSubmatch(value, -1) {
+		names[match[1]] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var result []string
+	for _, name := range sorted {
+		switch _, isVar := p.variables[name]; {
+		case isVar:
+			result = append(result, fmt.Sprintf("$%s from --var", name))
+		case os.Getenv(name) != "":
+			result = append(result, fmt.Sprintf("$%s from the environment", name))
+		default:
+			result = append(result, fmt.Sprintf("$%s is not set", name))
+		}
+	}
+	return strings.Join(result, ", ")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/cobra"
+	yaml2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	yamlOutput = "yaml"
+
+	maskedValue = "***"
+)
+
+// secretNameRegex matches the names of the variables whose values are masked
+var secretNameRegex = regexp.MustCompile(`(?i)(secret|token|passw(or)?d|api_?key|private_?key|credential)`)
+
+// RenderOptions are the options of the manifest render command
+type RenderOptions struct {
+	ManifestPath string
+	Output       string
+	Variables    []string
+	Provenance   bool
+}
+
+// Render prints the okteto manifest after merging the developer level manifest, inferring the compose files and expanding the variables
+func Render() *cobra.Command {
+	opts := &RenderOptions{}
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Print your okteto manifest fully resolved",
+		Long: `Print your okteto manifest fully resolved.
+
+The manifest is printed after merging the developer level manifest ('~/.okteto/okteto.yml'), inferring the services of the compose files and expanding the variables.
+The values of the variables and fields with names like 'TOKEN', 'SECRET' or 'PASSWORD' are masked.`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#manifest-render"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateRenderOutput(opts.Output); err != nil {
+				return err
+			}
+			if opts.Provenance && opts.Output != yamlOutput {
+				return fmt.Errorf("the flag '--provenance' is only supported with the 'yaml' output")
+			}
+			manifestPath, err := getManifestPath(opts.ManifestPath)
+			if err != nil {
+				return err
+			}
+			opts.ManifestPath = manifestPath
+			return runRender(cmd.OutOrStdout(), opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", yamlOutput, "output format. One of: ['yaml', 'json']")
+	cmd.Flags().StringArrayVarP(&opts.Variables, "var", "v", []string{}, "set a variable (can be set more than once)")
+	cmd.Flags().BoolVarP(&opts.Provenance, "provenance", "", false, "add comments with the file or variable each value comes from")
+	return cmd
+}
+
+func validateRenderOutput(output string) error {
+	switch output {
+	case yamlOutput, jsonOutput:
+		return nil
+	default:
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['yaml', 'json']")
+	}
+}
+
+func runRender(w io.Writer, opts *RenderOptions) error {
+	variables, err := setVariables(opts.Variables)
+	if err != nil {
+		return err
+	}
+
+	// warnings are not part of the rendered manifest
+	oktetoLog.SetOutput(io.Discard)
+	defer oktetoLog.SetOutput(os.Stdout)
+
+	manifest, err := model.GetManifestV2(opts.ManifestPath)
+	if err != nil {
+		return err
+	}
+	for _, dev := range manifest.Dev {
+		if err := utils.LoadManifestRc(dev); err != nil {
+			return err
+		}
+	}
+	if err := manifest.ExpandEnvVars(); err != nil {
+		return err
+	}
+
+	b, err := yaml2.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(b, doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		return fmt.Errorf("the manifest '%s' is empty", opts.ManifestPath)
+	}
+	root := doc.Content[0]
+
+	if opts.Provenance {
+		p, err := newProvenance(manifest, opts.ManifestPath, utils.GetDevRcPath(), variables)
+		if err != nil {
+			return err
+		}
+		p.annotate(root)
+	}
+	maskSecrets(root)
+
+	if opts.Output == jsonOutput {
+		var value interface{}
+		if err := root.Decode(&value); err != nil {
+			return err
+		}
+		out, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	}
+
+	buffer := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	_, err = w.Write(buffer.Bytes())
+	return err
+}
+
+// setVariables sets the variables given as 'KEY=VALUE' as environment variables, and returns them
+func setVariables(variables []string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, v := range variables {
+		key, value, found := strings.Cut(v, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid variable value '%s': must follow KEY=VALUE format", v)
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// maskSecrets replaces the values of the variables and of the fields with secret names in the rendered manifest
+func maskSecrets(n *yaml.Node) {
+	var secrets []string
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		if secretNameRegex.MatchString(name) && strings.TrimSpace(value) != "" {
+			secrets = append(secrets, value)
+		}
+	}
+	// longer values first, in case a secret contains another one
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	var words []string
+	for _, secret := range secrets {
+		words = append(words, secret, maskedValue)
+	}
+	replacer := strings.NewReplacer(words...)
+
+	var mask func(n *yaml.Node)
+	mask = func(n *yaml.Node) {
+		switch n.Kind {
+		case yaml.ScalarNode:
+			// environment variables are rendered as 'NAME=VALUE'
+			if name, _, found := strings.Cut(n.Value, "="); found && secretNameRegex.MatchString(name) {
+				n.Value = fmt.Sprintf("%s=%s", name, maskedValue)
+				return
+			}
+			if len(words) > 0 {
+				n.Value = replacer.Replace(n.Value)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				key, value := n.Content[i], n.Content[i+1]
+				if value.Kind == yaml.ScalarNode && value.Value != "" && secretNameRegex.MatchString(key.Value) {
+					value.Value = maskedValue
+					value.Tag = "!!str"
+					continue
+				}
+				mask(value)
+			}
+		case yaml.SequenceNode, yaml.DocumentNode:
+			for _, child := range n.Content {
+				mask(child)
+			}
+		}
+	}
+	mask(n)
+}
+
+// displayPath returns the path relative to the working directory when possible
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(wd, abs); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
@@ -0,0 +1,231 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const renderManifest = `build:
+  api:
+    context: api
+deploy:
+  - helm upgrade --install api chart --set token=${API_TOKEN}
+dev:
+  api:
+    image: okteto/golang:${GO_VERSION}
+    command: bash
+    environment:
+      FOO: bar
+      DB_PASSWORD: s3cr3t
+    forward:
+      - 8080:80
+    sync:
+      - .:/app
+`
+
+const renderDevRc = `environment:
+  EDITOR: vim
+forward:
+  - 9000:9000
+`
+
+func writeRenderFiles(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("API_TOKEN", "my-token")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".okteto"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".okteto", "okteto.yml"), []byte(renderDevRc), 0600))
+	path := filepath.Join(dir, "okteto.yml")
+	require.NoError(t, os.WriteFile(path, []byte(renderManifest), 0600))
+	return path
+}
+
+func TestRunRender(t *testing.T) {
+	path := writeRenderFiles(t)
+	t.Setenv("GO_VERSION", "")
+
+	out := &bytes.Buffer{}
+	err := runRender(out, &RenderOptions{ManifestPath: path, Output: yamlOutput, Variables: []string{"GO_VERSION=1.20"}})
+	require.NoError(t, err)
+
+	rendered := out.String()
+	assert.Contains(t, rendered, "name: okteto/golang:1.20")
+	assert.Contains(t, rendered, "- EDITOR=vim")
+	assert.Contains(t, rendered, "- DB_PASSWORD=***")
+	assert.Contains(t, rendered, "--set token=***")
+	assert.Contains(t, rendered, "- 9000:9000")
+	assert.NotContains(t, rendered, "s3cr3t")
+	assert.NotContains(t, rendered, "my-token")
+}
+
+func TestRunRenderJSON(t *testing.T) {
+	path := writeRenderFiles(t)
+	t.Setenv("GO_VERSION", "1.20")
+
+	out := &bytes.Buffer{}
+	require.NoError(t, runRender(out, &RenderOptions{ManifestPath: path, Output: jsonOutput}))
+
+	rendered := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &rendered))
+	assert.Contains(t, rendered, "dev")
+	assert.Contains(t, rendered, "build")
+}
+
+const renderSchedulingManifest = `dev:
+  api:
+    image: okteto/golang:1
+    command: bash
+    sync:
+      - .:/app
+    affinity:
+      podAffinity:
+        requiredDuringSchedulingIgnoredDuringExecution:
+          - labelSelector:
+              matchLabels:
+                app: db
+            topologyKey: kubernetes.io/hostname
+    tolerations:
+      - key: dedicated
+        operator: Equal
+        value: dev
+        effect: NoExecute
+        tolerationSeconds: 30
+      - key: gpu
+        operator: Exists
+`
+
+func TestRunRenderAffinityAndTolerations(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	path := filepath.Join(dir, "okteto.yml")
+	require.NoError(t, os.WriteFile(path, []byte(renderSchedulingManifest), 0600))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, runRender(out, &RenderOptions{ManifestPath: path, Output: yamlOutput}))
+
+	rendered := out.String()
+	assert.Contains(t, rendered, "podAffinity:")
+	assert.Contains(t, rendered, "requiredDuringSchedulingIgnoredDuringExecution:")
+	assert.Contains(t, rendered, "topologyKey: kubernetes.io/hostname")
+	assert.Contains(t, rendered, "tolerationSeconds: 30")
+	assert.NotContains(t, rendered, "podaffinity")
+	assert.NotContains(t, rendered, "tolerationseconds")
+	assert.NotContains(t, rendered, "null")
+
+	errs, err := validateManifest(out.Bytes(), model.ManifestSchema())
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+
+	original, err := model.GetManifestV2(path)
+	require.NoError(t, err)
+	renderedPath := filepath.Join(dir, "rendered.yml")
+	require.NoError(t, os.WriteFile(renderedPath, out.Bytes(), 0600))
+	loaded, err := model.GetManifestV2(renderedPath)
+	require.NoError(t, err)
+	assert.Equal(t, original.Dev["api"].Affinity, loaded.Dev["api"].Affinity)
+	assert.Equal(t, original.Dev["api"].Tolerations, loaded.Dev["api"].Tolerations)
+}
+
+func TestRunRenderProvenance(t *testing.T) {
+	path := writeRenderFiles(t)
+	t.Setenv("GO_VERSION", "")
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Dir(path)))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	out := &bytes.Buffer{}
+	err = runRender(out, &RenderOptions{ManifestPath: path, Output: yamlOutput, Variables: []string{"GO_VERSION=1.20"}, Provenance: true})
+	require.NoError(t, err)
+
+	doc := &yaml.Node{}
+	require.NoError(t, yaml.Unmarshal(out.Bytes(), doc))
+	comments := map[string]string{}
+	var collect func(n *yaml.Node)
+	collect = func(n *yaml.Node) {
+		if n.Kind == yaml.ScalarNode && n.LineComment != "" {
+			comments[n.Value] = n.LineComment
+		}
+		for _, child := range n.Content {
+			collect(child)
+		}
+	}
+	collect(doc)
+
+	assert.Equal(t, "# okteto.yml:8 ($GO_VERSION from --var)", comments["okteto/golang:1.20"])
+	assert.Equal(t, "# okteto.yml:9", comments["bash"])
+	assert.Equal(t, "# okteto.yml:14", comments["8080:80"])
+	assert.Equal(t, "# .okteto/okteto.yml:4", comments["9000:9000"])
+	assert.Equal(t, "# .okteto/okteto.yml:2", comments["EDITOR=vim"])
+	assert.Equal(t, "# okteto.yml:11", comments["FOO=bar"])
+	assert.Equal(t, "# default", comments["sync"])
+}
+
+func TestRenderProvenanceOnlyYAML(t *testing.T) {
+	cmd := Render()
+	cmd.SetArgs([]string{"--output", "json", "--provenance"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestValidateRenderOutput(t *testing.T) {
+	assert.NoError(t, validateRenderOutput("yaml"))
+	assert.NoError(t, validateRenderOutput("json"))
+	assert.Error(t, validateRenderOutput("sarif"))
+}
+
+func TestSetVariables(t *testing.T) {
+	t.Setenv("RENDER_VAR", "")
+	variables, err := setVariables([]string{"RENDER_VAR=value=with=equals"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"RENDER_VAR": "value=with=equals"}, variables)
+	assert.Equal(t, "value=with=equals", os.Getenv("RENDER_VAR"))
+
+	_, err = setVariables([]string{"RENDER_VAR"})
+	assert.Error(t, err)
+}
+
+func TestMaskSecrets(t *testing.T) {
+	t.Setenv("MY_SECRET", "hidden-value")
+	doc := &yaml.Node{}
+	require.NoError(t, yaml.Unmarshal([]byte(`environment:
+  - API_KEY=abc
+  - NAME=api
+command: run --key hidden-value
+password: p4ss
+`), doc))
+
+	maskSecrets(doc)
+
+	b, err := yaml.Marshal(doc)
+	require.NoError(t, err)
+	assert.Equal(t, `environment:
+    - API_KEY=***
+    - NAME=api
+command: run --key ***
+password: '***'
+`, string(b))
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/schema"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateOptions are the options of the manifest validate command
+type ValidateOptions struct {
+	ManifestPath string
+}
+
+// Validate checks the okteto manifest against its schema, without connecting to the cluster
+func Validate() *cobra.Command {
+	opts := &ValidateOptions{}
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate your okteto manifest",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#manifest-validate"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestPath, err := getManifestPath(opts.ManifestPath)
+			if err != nil {
+				return err
+			}
+			return runValidate(cmd.OutOrStdout(), manifestPath)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	return cmd
+}
+
+func runValidate(w io.Writer, manifestPath string) error {
+	b, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	errs, err := validateManifest(b, model.SchemaForFile(manifestPath))
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("%s: %w", manifestPath, err),
+			Hint: "Check the indentation and the syntax of your manifest",
+		}
+	}
+	if len(errs) == 0 {
+		oktetoLog.Success("'%s' is valid", manifestPath)
+		return nil
+	}
+
+	for _, e := range errs {
+		fmt.Fprintf(w, "%s:%s\n", manifestPath, e.Error())
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("'%s' has %d error(s)", manifestPath, len(errs)),
+		Hint: "See https://okteto.com/docs/reference/manifest/ for details",
+	}
+}
+
+// validateManifest returns the schema errors of the manifest, or an error if it isn't valid YAML
+func validateManifest(b []byte, s *schema.Schema) ([]schema.Error, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return schema.Validate(s, &doc), nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunValidate(t *testing.T) {
+	dir := t.TempDir()
+	var tests = []struct {
+		name      string
+		file      string
+		content   string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name: "valid",
+			file: "okteto.yml",
+			content: `dev:
+  api:
+    command: bash`,
+		},
+		{
+			name: "invalid",
+			file: "okteto.yml",
+			content: `dev:
+  api:
+    comand: bash`,
+			expected:  filepath.Join(dir, "invalid", "okteto.yml") + ":3:5: dev.api: field 'comand' is not allowed, did you mean 'command'?\n",
+			expectErr: true,
+		},
+		{
+			name: "compose",
+			file: "docker-compose.yml",
+			content: `services:
+  api:
+    image: api
+x-okteto: true`,
+		},
+		{
+			name:      "syntax-error",
+			file:      "okteto.yml",
+			content:   "dev:\n  api:\n command: bash\n  sync: [",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name, tt.file)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			out := &bytes.Buffer{}
+			err := runValidate(out, path)
+			if tt.expectErr {
+				assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package portforward
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/pkg/discovery"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	forwardk8s "github.com/okteto/okteto/pkg/k8s/forward"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/ports"
+	"github.com/spf13/cobra"
+)
+
+// refreshInterval is how often the state of the port forwards is refreshed
+const refreshInterval = time.Second
+
+// Options are the options of the port-forward command
+type Options struct {
+	ManifestPath string
+	Namespace    string
+	Context      string
+	Interface    string
+}
+
+// PortForward forwards local ports to the services of a deployed environment
+func PortForward(ctx context.Context) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "port-forward [[localPort:]service:port...]",
+		Short: "Forward local ports to the services of your namespace",
+		Long: `Forward local ports to the services of your namespace.
+
+Starts the global forwards defined in the 'forward' section of your okteto manifest, and the forwards passed as arguments.
+Forwards reconnect automatically when the pods of the service are restarted.`,
+		Example: `okteto port-forward
+okteto port-forward api:8080 5433:postgres:5432 auto:redis:6379`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			adHoc := []forward.GlobalForward{}
+			for _, arg := range args {
+				gf, err := forward.ParseGlobalForward(arg)
+				if err != nil {
+					return oktetoErrors.UserError{E: err, Hint: "Use '<service>:<port>', '<localPort>:<service>:<port>' or 'auto:<service>:<port>'"}
+				}
+				adHoc = append(adHoc, gf)
+			}
+
+			globalForwards, err := loadGlobalForwards(ctx, options, len(adHoc) > 0)
+			if err != nil {
+				return err
+			}
+			globalForwards = append(globalForwards, adHoc...)
+			if len(globalForwards) == 0 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("there are no port forwards to start"),
+					Hint: "Define a 'forward' section in your okteto manifest or pass '<service>:<port>' as arguments",
+				}
+			}
+
+			return run(ctx, options, globalForwards)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "the namespace of the services (defaults to the current okteto namespace)")
+	cmd.Flags().StringVarP(&options.Context, "context", "c", "", "the context to use")
+	cmd.Flags().StringVar(&options.Interface, "address", model.Localhost, "the local address of the port forwards")
+	return cmd
+}
+
+// loadGlobalForwards sets the context of the command and returns the global forwards of the manifest.
+// The manifest is optional when there are forwards passed as arguments
+func loadGlobalForwards(ctx context.Context, options *Options, optional bool) ([]forward.GlobalForward, error) {
+	manifest, err := contextCMD.LoadManifestWithContext(ctx, contextCMD.ManifestOptions{Filename: options.ManifestPath, Namespace: options.Namespace, K8sContext: options.Context})
+	if err == nil {
+		return manifest.GlobalForward, nil
+	}
+	if !optional || options.ManifestPath != "" || !errors.Is(err, discovery.ErrOktetoManifestNotFound) {
+		return nil, err
+	}
+
+	ctxOptions := &contextCMD.ContextOptions{
+		Context:   options.Context,
+		Namespace: options.Namespace,
+		Show:      true,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func run(ctx context.Context, options *Options, globalForwards []forward.GlobalForward) error {
+	c, restConfig, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	namespace := okteto.Context().Namespace
+	if err := allocateAutoPorts(namespace, options.Interface, globalForwards); err != nil {
+		return err
+	}
+
+	pf := forwardk8s.NewPortForwardManager(ctx, options.Interface, restConfig, c, namespace)
+	for _, gf := range globalForwards {
+		f := forward.Forward{
+			Local:       gf.Local,
+			Remote:      gf.Remote,
+			Service:     true,
+			IsGlobal:    true,
+			ServiceName: gf.ServiceName,
+			Labels:      gf.Labels,
+		}
+		if gf.Labels != nil {
+			f, err = pf.TransformLabelsToServiceName(f)
+			if err != nil {
+				return err
+			}
+		}
+		if err := pf.Add(f); err != nil {
+			return err
+		}
+	}
+
+	go func() {
+		sigint := make(chan os.Signal, 1)
+		signal.Notify(sigint, syscall.SIGTERM, syscall.SIGINT)
+		<-sigint
+		cancel()
+	}()
+
+	pf.StartServices(namespace)
+	defer pf.Stop()
+
+	oktetoLog.Success("Forwarding ports to namespace '%s'", namespace)
+	oktetoLog.Println("Press Ctrl+C to stop the port forwards")
+	watchStatus(ctx, os.Stdout, pf.Status, oktetoLog.IsInteractive())
+	return nil
+}
+
+// allocateAutoPorts sets the local port of the forwards defined with 'localPort: auto'.
+// Ports are allocated deterministically for the namespace, so they are stable between executions
+func allocateAutoPorts(namespace, iface string, globalForwards []forward.GlobalForward) error {
+	reserved := []int{}
+	for _, gf := range globalForwards {
+		if !gf.IsAuto() {
+			reserved = append(reserved, gf.Local)
+		}
+	}
+
+	allocator := ports.NewAllocator(namespace, "port-forward", iface, nil, reserved)
+	for i := range globalForwards {
+		gf := &globalForwards[i]
+		if !gf.IsAuto() {
+			continue
+		}
+		local, err := allocator.Allocate(gf.ServiceName, gf.Remote, true)
+		if err != nil {
+			return err
+		}
+		gf.Local = local
+	}
+	return nil
+}
+
+// watchStatus prints the state of the port forwards until the context is cancelled.
+// Interactive terminals redraw the table in place, otherwise the table is printed when it changes
+func watchStatus(ctx context.Context, w io.Writer, status func() []forwardk8s.ForwardStatus, interactive bool) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	previous := ""
+	for {
+		current := renderStatus(status())
+		if current != previous {
+			if interactive && previous != "" {
+				// move the cursor to the beginning of the previous table and clear it
+				fmt.Fprintf(w, "\033[%dA\033[J", strings.Count(previous, "\n"))
+			}
+			fmt.Fprint(w, current)
+			previous = current
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// renderStatus returns a table with the state of the port forwards
+func renderStatus(statuses []forwardk8s.ForwardStatus) string {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "LOCAL\tSERVICE\tPOD\tSTATUS\tRECONNECTIONS")
+	for _, s := range statuses {
+		state := "connecting"
+		switch {
+		case s.Connected:
+			state = "connected"
+		case s.Error != "":
+			state = fmt.Sprintf("reconnecting: %s", s.Error)
+		}
+		pod := s.Pod
+		if pod == "" {
+			pod = "-"
+		}
+		fmt.Fprintf(tw, "%d\t%s:%d\t%s\t%s\t%d\n", s.Forward.Local, s.Forward.ServiceName, s.Forward.Remote, pod, state, s.Reconnections)
+	}
+	if err := tw.Flush(); err != nil {
+		oktetoLog.Infof("failed to render port forwards: %s", err)
+	}
+	return buf.String()
+}
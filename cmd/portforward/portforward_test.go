@@ -0,0 +1,83 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package portforward
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	forwardk8s "github.com/okteto/okteto/pkg/k8s/forward"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderStatus(t *testing.T) {
+	statuses := []forwardk8s.ForwardStatus{
+		{
+			Forward:   forward.Forward{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+			Pod:       "db-0",
+			Connected: true,
+		},
+		{
+			Forward:       forward.Forward{Local: 8080, Remote: 80, Service: true, ServiceName: "api"},
+			Reconnections: 2,
+			Error:         "pod not found",
+		},
+		{
+			Forward: forward.Forward{Local: 9090, Remote: 9090, Service: true, ServiceName: "metrics"},
+		},
+	}
+
+	expected := `LOCAL  SERVICE       POD   STATUS                       RECONNECTIONS
+5432   db:5432       db-0  connected                    0
+8080   api:80        -     reconnecting: pod not found  2
+9090   metrics:9090  -     connecting                   0
+`
+	assert.Equal(t, expected, renderStatus(statuses))
+}
+
+func TestWatchStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	status := func() []forwardk8s.ForwardStatus {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		return []forwardk8s.ForwardStatus{
+			{Forward: forward.Forward{Local: 8080, Remote: 80, Service: true, ServiceName: "api"}, Connected: calls > 1},
+		}
+	}
+
+	var buf bytes.Buffer
+	watchStatus(ctx, &buf, status, true)
+
+	output := buf.String()
+	assert.Equal(t, 2, strings.Count(output, "LOCAL"))
+	assert.Contains(t, output, "connecting")
+	assert.Contains(t, output, "connected")
+	assert.Contains(t, output, "\033[2A\033[J")
+}
+
+func TestAllocateAutoPorts(t *testing.T) {
+	globalForwards := []forward.GlobalForward{
+		{Local: 5433, Remote: 5432, ServiceName: "postgres"},
+		{Local: 0, Remote: 6379, ServiceName: "redis"},
+	}
+	assert.NoError(t, allocateAutoPorts("test", "localhost", globalForwards))
+	assert.Equal(t, 5433, globalForwards[0].Local)
+	assert.NotZero(t, globalForwards[1].Local)
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/forward"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/proxy"
+	"github.com/spf13/cobra"
+)
+
+// proxyOptions are the options of the proxy command
+type proxyOptions struct {
+	Namespace string
+	Context   string
+	Address   string
+	Port      int
+}
+
+// Proxy starts a local SOCKS5 and HTTP proxy to the services of a namespace
+func Proxy(ctx context.Context) *cobra.Command {
+	options := &proxyOptions{}
+	cmd := &cobra.Command{
+		Use:   "proxy",
+		Short: "Start a local SOCKS5 and HTTP proxy to the services of your namespace",
+		Long: `Start a local SOCKS5 and HTTP proxy to the services of your namespace.
+
+Connections are opened with a port-forward to the pods of the service, so services are reachable by their cluster name ('<service>', '<service>.<namespace>' or '<service>.<namespace>.svc.cluster.local') without a development container.`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#proxy"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.Port < 1 || options.Port > 65535 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("invalid port %d", options.Port),
+					Hint: "Use a port between 1 and 65535",
+				}
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   options.Context,
+				Namespace: options.Namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			c, restConfig, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go func() {
+				sigint := make(chan os.Signal, 1)
+				signal.Notify(sigint, syscall.SIGTERM, syscall.SIGINT)
+				<-sigint
+				cancel()
+			}()
+
+			address := net.JoinHostPort(options.Address, strconv.Itoa(options.Port))
+			dialer := forward.NewServiceDialer(okteto.Context().Namespace, restConfig, c)
+			p := proxy.New(address, dialer)
+
+			oktetoLog.Success("Proxy to namespace '%s' listening on %s", okteto.Context().Namespace, address)
+			oktetoLog.Println(fmt.Sprintf("    %s  curl --proxy socks5h://%s http://<service>:<port>", oktetoLog.BlueString("SOCKS5:"), address))
+			oktetoLog.Println(fmt.Sprintf("    %s    curl --proxy http://%s http://<service>:<port>", oktetoLog.BlueString("HTTP:"), address))
+			oktetoLog.Println("Press Ctrl+C to stop the proxy")
+
+			if err := p.ListenAndServe(ctx); err != nil {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("failed to start the proxy on %s: %w", address, err),
+					Hint: "Use '--port' to select a different port",
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "the namespace of the services (defaults to the current okteto namespace)")
+	cmd.Flags().StringVarP(&options.Context, "context", "c", "", "the context to use")
+	cmd.Flags().StringVar(&options.Address, "address", model.Localhost, "the local address of the proxy")
+	cmd.Flags().IntVarP(&options.Port, "port", "p", 1080, "the local port of the proxy")
+	return cmd
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/secrets"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// RotateKeysOptions are the options of the rotate-keys command
+type RotateKeysOptions struct {
+	Namespace string
+	Context   string
+	KeyType   string
+}
+
+// RotateKeys replaces the SSH keys of okteto by a new key pair
+func RotateKeys(ctx context.Context) *cobra.Command {
+	options := &RotateKeysOptions{}
+	cmd := &cobra.Command{
+		Use:   "rotate-keys",
+		Short: "Replace the SSH keys used to connect to your development containers",
+		Long: `Replace the SSH keys used to connect to your development containers.
+
+Generates a new key pair, authorizes it in the development containers of all your namespaces and removes the previous key pair.
+The running development containers authorize the new key pair when they restart, and the previous key pair is kept until then.
+If some namespace can't be updated, the previous key pair is kept until you run 'okteto up' in it.`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#ssh"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.KeyType == "" {
+				keyType, err := ssh.GetKeyType()
+				if err != nil {
+					return err
+				}
+				options.KeyType = keyType
+			}
+			if err := ssh.ValidateKeyType(options.KeyType); err != nil {
+				return oktetoErrors.UserError{
+					E:    err,
+					Hint: "Use '--type ed25519' or '--type rsa'",
+				}
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   options.Context,
+				Namespace: options.Namespace,
+				Show:      true,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			c, _, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+			if err != nil {
+				return err
+			}
+			namespaces, err := getNamespaces(ctx, okteto.Context().Namespace, c)
+			if err != nil {
+				return err
+			}
+			return rotateKeys(ctx, options.KeyType, namespaces, c)
+		},
+	}
+	cmd.Flags().StringVar(&options.KeyType, "type", "", "the type of the new keys, 'ed25519' or 'rsa' (defaults to the value of OKTETO_SSH_KEY_TYPE or 'ed25519')")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "the namespace to use (defaults to the current okteto namespace)")
+	cmd.Flags().StringVarP(&options.Context, "context", "c", "", "the context to use")
+	return cmd
+}
+
+// getNamespaces returns the namespaces where the user can have development containers, starting by the current namespace
+func getNamespaces(ctx context.Context, current string, c kubernetes.Interface) ([]string, error) {
+	namespaces := []string{current}
+	if okteto.IsOkteto() {
+		oc, err := okteto.NewOktetoClient()
+		if err != nil {
+			return nil, err
+		}
+		spaces, err := oc.Namespaces().List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list your namespaces: %w", err)
+		}
+		for i := range spaces {
+			if spaces[i].ID != current {
+				namespaces = append(namespaces, spaces[i].ID)
+			}
+		}
+		return namespaces, nil
+	}
+
+	nList, err := c.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		oktetoLog.Infof("failed to list namespaces: %s", err)
+		oktetoLog.Warning("Only the development containers in the namespace '%s' are updated, you don't have permission to list the namespaces of the cluster", current)
+		return namespaces, nil
+	}
+	for i := range nList.Items {
+		if nList.Items[i].Name != current {
+			namespaces = append(namespaces, nList.Items[i].Name)
+		}
+	}
+	return namespaces, nil
+}
+
+// rotateKeys authorizes a new key pair in the development containers of the namespaces using the current key, before replacing the current key pair.
+// The current key pair is kept while it's still authorized in some namespace
+func rotateKeys(ctx context.Context, keyType string, namespaces []string, c kubernetes.Interface) error {
+	if err := replacePreviousKey(ctx, c); err != nil {
+		return err
+	}
+
+	previous, err := ssh.ReadPublicKey()
+	if err != nil {
+		return err
+	}
+
+	kp, err := ssh.NewKeyPair(keyType)
+	if err != nil {
+		return err
+	}
+
+	rotation := time.Now()
+	pending := []string{}
+	running := []string{}
+	for _, namespace := range namespaces {
+		updated, err := secrets.UpdateAuthorizedKey(ctx, namespace, previous, kp.Public, c)
+		for _, name := range updated {
+			oktetoLog.Information("Updated the authorized key of '%s' in namespace '%s'", name, namespace)
+		}
+		if err != nil {
+			oktetoLog.Infof("failed to update the authorized key in namespace '%s': %s", namespace, err)
+			pending = append(pending, namespace)
+			continue
+		}
+		if len(updated) == 0 {
+			continue
+		}
+		// the running development containers copied the previous key when they started
+		ok, err := ssh.HasDevPodsStartedBefore(ctx, namespace, rotation, c)
+		if err != nil {
+			oktetoLog.Infof("failed to check the development containers in namespace '%s': %s", namespace, err)
+		}
+		if ok || err != nil {
+			running = append(running, namespace)
+		}
+	}
+
+	if len(pending) == 0 && len(running) == 0 {
+		if err := kp.Save(); err != nil {
+			return err
+		}
+		oktetoLog.Success("SSH keys rotated to a new '%s' key pair", keyType)
+		return nil
+	}
+
+	if err := kp.SaveKeepingPrevious(append(pending, running...)); err != nil {
+		return err
+	}
+	oktetoLog.Success("SSH keys rotated to a new '%s' key pair", keyType)
+	if len(pending) > 0 {
+		oktetoLog.Warning("The development containers in the namespaces %s still use the previous key pair, it's kept until you run 'okteto up' in them", quoteNamespaces(pending))
+	}
+	if len(running) > 0 {
+		oktetoLog.Information("The development containers running in the namespaces %s authorize the new key pair when they restart, the previous key pair is kept until then", quoteNamespaces(running))
+	}
+	return nil
+}
+
+// replacePreviousKey authorizes the current key pair in the namespaces still using the key pair replaced by a previous rotation
+func replacePreviousKey(ctx context.Context, c kubernetes.Interface) error {
+	namespaces, err := ssh.PreviousKeyNamespaces()
+	if err != nil {
+		return err
+	}
+	for _, namespace := range namespaces {
+		if err := ssh.ReplacePreviousKey(ctx, namespace, c); err != nil {
+			oktetoLog.Infof("failed to replace the previous key in namespace '%s': %s", namespace, err)
+		}
+	}
+
+	pending, err := ssh.PreviousKeyNamespaces()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the development containers in the namespaces %s still use the key pair replaced by your last rotation", quoteNamespaces(pending)),
+			Hint: "Run 'okteto up' in those namespaces, or restart their development containers, before rotating your SSH keys again",
+		}
+	}
+	return nil
+}
+
+func quoteNamespaces(namespaces []string) string {
+	quoted := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		quoted = append(quoted, fmt.Sprintf("'%s'", namespace))
+	}
+	return strings.Join(quoted, ", ")
+}
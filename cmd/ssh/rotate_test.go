@@ -0,0 +1,191 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func newDevSecret(name, namespace string, authorizedKey []byte) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{constants.DevLabel: "true"},
+		},
+		Data: map[string][]byte{
+			"config.xml":                 []byte("<configuration/>"),
+			"dev-secret-authorized_keys": authorizedKey,
+		},
+	}
+}
+
+func TestRotateKeys(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(constants.OktetoFolderEnvVar, dir)
+	t.Setenv(constants.OktetoHomeEnvVar, dir)
+	t.Setenv(model.OktetoSSHKeyTypeEnvVar, ssh.KeyTypeRSA)
+
+	require.NoError(t, ssh.GenerateKeys())
+	previousPath := ssh.GetPublicKey()
+	previous, err := os.ReadFile(previousPath)
+	require.NoError(t, err)
+
+	c := fake.NewSimpleClientset(
+		newDevSecret("okteto-api", "test", previous),
+		newDevSecret("okteto-other", "test", []byte("ssh-ed25519 AAAA other")),
+		newDevSecret("okteto-web", "staging", previous),
+	)
+	ctx := context.Background()
+	require.NoError(t, rotateKeys(ctx, ssh.KeyTypeED25519, []string{"test", "staging"}, c))
+
+	assert.NoFileExists(t, previousPath)
+	current, err := os.ReadFile(ssh.GetPublicKey())
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(current), "ssh-ed25519 "))
+
+	api, err := c.CoreV1().Secrets("test").Get(ctx, "okteto-api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, current, api.Data["dev-secret-authorized_keys"])
+	assert.Equal(t, []byte("<configuration/>"), api.Data["config.xml"])
+
+	other, err := c.CoreV1().Secrets("test").Get(ctx, "okteto-other", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ssh-ed25519 AAAA other"), other.Data["dev-secret-authorized_keys"])
+
+	web, err := c.CoreV1().Secrets("staging").Get(ctx, "okteto-web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, current, web.Data["dev-secret-authorized_keys"])
+}
+
+func TestRotateKeysKeepsPreviousKey(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(constants.OktetoFolderEnvVar, dir)
+	t.Setenv(constants.OktetoHomeEnvVar, dir)
+	t.Setenv(model.OktetoSSHKeyTypeEnvVar, ssh.KeyTypeED25519)
+
+	require.NoError(t, ssh.GenerateKeys())
+	previousPath := ssh.GetPublicKey()
+	previous, err := os.ReadFile(previousPath)
+	require.NoError(t, err)
+
+	c := fake.NewSimpleClientset(
+		newDevSecret("okteto-api", "test", previous),
+		newDevSecret("okteto-web", "staging", previous),
+	)
+	forbidden := true
+	c.PrependReactor("list", "secrets", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		if forbidden && action.GetNamespace() == "staging" {
+			return true, nil, errors.New("forbidden")
+		}
+		return false, nil, nil
+	})
+
+	ctx := context.Background()
+	require.NoError(t, rotateKeys(ctx, ssh.KeyTypeED25519, []string{"test", "staging"}, c))
+
+	current, err := os.ReadFile(ssh.GetPublicKey())
+	require.NoError(t, err)
+	assert.NotEqual(t, previous, current)
+	kept, err := os.ReadFile(previousPath + ".previous")
+	require.NoError(t, err)
+	assert.Equal(t, previous, kept)
+
+	namespaces, err := ssh.PreviousKeyNamespaces()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"staging"}, namespaces)
+
+	api, err := c.CoreV1().Secrets("test").Get(ctx, "okteto-api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, current, api.Data["dev-secret-authorized_keys"])
+
+	err = rotateKeys(ctx, ssh.KeyTypeED25519, []string{"test", "staging"}, c)
+	assert.ErrorContains(t, err, "'staging'")
+
+	forbidden = false
+	require.NoError(t, ssh.ReplacePreviousKey(ctx, "staging", c))
+
+	web, err := c.CoreV1().Secrets("staging").Get(ctx, "okteto-web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, current, web.Data["dev-secret-authorized_keys"])
+	assert.NoFileExists(t, previousPath+".previous")
+
+	namespaces, err = ssh.PreviousKeyNamespaces()
+	require.NoError(t, err)
+	assert.Empty(t, namespaces)
+}
+
+func TestRotateKeysKeepsPreviousKeyForRunningContainers(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(constants.OktetoFolderEnvVar, dir)
+	t.Setenv(constants.OktetoHomeEnvVar, dir)
+	t.Setenv(model.OktetoSSHKeyTypeEnvVar, ssh.KeyTypeED25519)
+
+	require.NoError(t, ssh.GenerateKeys())
+	previousPath := ssh.GetPublicKey()
+	previous, err := os.ReadFile(previousPath)
+	require.NoError(t, err)
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "api-123",
+			Namespace:         "test",
+			Labels:            map[string]string{model.InteractiveDevLabel: "api"},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+	}
+	c := fake.NewSimpleClientset(newDevSecret("okteto-api", "test", previous), pod)
+
+	ctx := context.Background()
+	require.NoError(t, rotateKeys(ctx, ssh.KeyTypeED25519, []string{"test"}, c))
+
+	kept, err := os.ReadFile(previousPath + ".previous")
+	require.NoError(t, err)
+	assert.Equal(t, previous, kept)
+	namespaces, err := ssh.PreviousKeyNamespaces()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test"}, namespaces)
+
+	// the development container still runs with the previous authorized key
+	require.NoError(t, ssh.ReplacePreviousKey(ctx, "test", c))
+	assert.FileExists(t, previousPath+".previous")
+
+	require.NoError(t, c.CoreV1().Pods("test").Delete(ctx, pod.Name, metav1.DeleteOptions{}))
+	pod.Name = "api-456"
+	pod.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Minute))
+	_, err = c.CoreV1().Pods("test").Create(ctx, pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, ssh.ReplacePreviousKey(ctx, "test", c))
+	assert.NoFileExists(t, previousPath+".previous")
+	namespaces, err = ssh.PreviousKeyNamespaces()
+	require.NoError(t, err)
+	assert.Empty(t, namespaces)
+}
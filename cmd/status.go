@@ -16,6 +16,7 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"time"
@@ -27,7 +28,9 @@ import (
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/ports"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/spf13/cobra"
 )
@@ -90,6 +93,8 @@ func Status() *cobra.Command {
 				oktetoLog.Information("Syncthing password: %s", sy.GUIPassword)
 			}
 
+			printAllocatedPorts(dev)
+
 			if watch {
 				err = runWithWatch(ctx, sy)
 			} else {
@@ -108,6 +113,23 @@ func Status() *cobra.Command {
 	return cmd
 }
 
+// printAllocatedPorts shows the local ports allocated to the forwards defined with 'localPort: auto'
+func printAllocatedPorts(dev *model.Dev) {
+	allocations, err := ports.Load(dev.Namespace, dev.Name)
+	if err != nil {
+		oktetoLog.Infof("error accessing the allocated ports: %s", err)
+		return
+	}
+	if len(allocations) == 0 {
+		return
+	}
+
+	oktetoLog.Information("Allocated ports:")
+	for _, a := range allocations {
+		oktetoLog.Println(fmt.Sprintf("    %s", a.String()))
+	}
+}
+
 func runWithWatch(ctx context.Context, sy *syncthing.Syncthing) error {
 	textSpinner := "Synchronizing your files..."
 	oktetoLog.Spinner(textSpinner)
@@ -162,5 +184,14 @@ func runWithoutWatch(ctx context.Context, sy *syncthing.Syncthing) error {
 	} else {
 		oktetoLog.Yellow("Synchronization status: %.2f%%", progress)
 	}
+
+	folders, err := status.GetFolderStates(ctx, sy)
+	if err != nil {
+		oktetoLog.Infof("error accessing the status of the sync folders: %s", err)
+		return nil
+	}
+	for _, f := range folders {
+		oktetoLog.Println(fmt.Sprintf("    %s", f.String()))
+	}
 	return nil
 }
@@ -0,0 +1,203 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+const (
+	keepLocalOption  = "Keep local"
+	keepRemoteOption = "Keep remote"
+	skipOption       = "Skip"
+
+	keepLocal  = "local"
+	keepRemote = "remote"
+)
+
+// ConflictsOptions represents the options of the sync conflicts command
+type ConflictsOptions struct {
+	DevPath    string
+	Namespace  string
+	K8sContext string
+	Keep       string
+	List       bool
+}
+
+// askFunc asks the user to choose one of the options
+type askFunc func(options []string, label string) (string, error)
+
+// Conflicts lists and resolves the synchronization conflicts of a development container
+func Conflicts(ctx context.Context) *cobra.Command {
+	options := &ConflictsOptions{}
+	cmd := &cobra.Command{
+		Use:   "conflicts [devContainer]",
+		Short: "List and resolve the synchronization conflicts of a development container",
+		Args:  utils.MaximumNArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if okteto.InDevContainer() {
+				return oktetoErrors.ErrNotInDevContainer
+			}
+			if options.Keep != "" && options.Keep != keepLocal && options.Keep != keepRemote {
+				return fmt.Errorf("invalid value for '--keep': must be '%s' or '%s'", keepLocal, keepRemote)
+			}
+
+			manifestOpts := contextCMD.ManifestOptions{Filename: options.DevPath, Namespace: options.Namespace, K8sContext: options.K8sContext}
+			manifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts)
+			if err != nil {
+				return err
+			}
+
+			devName := ""
+			if len(args) == 1 {
+				devName = args[0]
+			}
+			dev, err := utils.GetDevFromManifest(manifest, devName)
+			if err != nil {
+				if !errors.Is(err, utils.ErrNoDevSelected) {
+					return err
+				}
+				selector := utils.NewOktetoSelector("Select the development container to check for synchronization conflicts:", "Development container")
+				dev, err = utils.SelectDevFromManifest(manifest, selector, manifest.Dev.GetDevs())
+				if err != nil {
+					return err
+				}
+			}
+
+			sy, err := syncthing.Load(dev)
+			if err != nil {
+				oktetoLog.Infof("error accessing the syncthing info file: %s", err)
+				return oktetoErrors.ErrNotInDevMode
+			}
+			if !sy.Ping(ctx, true) {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the synchronization service of '%s' is not running", dev.Name),
+					Hint: "Run 'okteto up' and try again",
+				}
+			}
+
+			conflicts, err := sy.GetConflicts(ctx)
+			if err != nil {
+				return err
+			}
+			if len(conflicts) == 0 {
+				oktetoLog.Success("No synchronization conflicts found")
+				return nil
+			}
+			return resolveConflicts(conflicts, options, utils.AskForOptions)
+		},
+	}
+	cmd.Flags().StringVarP(&options.DevPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace where the up command is executing")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the up command is executing")
+	cmd.Flags().StringVarP(&options.Keep, "keep", "", "", "resolve all the conflicts without asking, keeping the 'local' or the 'remote' version")
+	cmd.Flags().BoolVarP(&options.List, "list", "l", false, "list the conflicts without resolving them")
+	return cmd
+}
+
+func resolveConflicts(conflicts []syncthing.Conflict, options *ConflictsOptions, ask askFunc) error {
+	oktetoLog.Information("%d synchronization conflicts found", len(conflicts))
+	for i := range conflicts {
+		c := &conflicts[i]
+		oktetoLog.Println()
+		oktetoLog.Println(oktetoLog.BlueString("%s (%s)", c.Original, c.Folder.LocalPath))
+		diff, err := getConflictDiff(c)
+		if err != nil {
+			return err
+		}
+		oktetoLog.Println(diff)
+
+		if options.List {
+			continue
+		}
+
+		keep := options.Keep
+		if keep == "" {
+			option, err := ask([]string{keepLocalOption, keepRemoteOption, skipOption}, fmt.Sprintf("How do you want to resolve '%s'?", c.Original))
+			if err != nil {
+				return err
+			}
+			switch option {
+			case keepLocalOption:
+				keep = keepLocal
+			case keepRemoteOption:
+				keep = keepRemote
+			default:
+				continue
+			}
+		}
+
+		if err := c.Resolve(keep == keepLocal); err != nil {
+			return fmt.Errorf("failed to resolve the conflict of '%s': %w", c.Original, err)
+		}
+		oktetoLog.Success("Conflict of '%s' resolved keeping the %s version", c.Original, keep)
+	}
+	return nil
+}
+
+// getConflictDiff returns the unified diff between the local and the remote versions of the conflict
+func getConflictDiff(c *syncthing.Conflict) (string, error) {
+	local, err := os.ReadFile(c.GetLocalVersionPath())
+	if err != nil {
+		return "", err
+	}
+	remote, err := os.ReadFile(c.GetRemoteVersionPath())
+	if err != nil {
+		return "", err
+	}
+	if bytes.IndexByte(local, 0) != -1 || bytes.IndexByte(remote, 0) != -1 {
+		return "Binary files differ", nil
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(string(local)),
+		B:        splitLines(string(remote)),
+		FromFile: "local/" + c.Original,
+		ToFile:   "remote/" + c.Original,
+		Context:  3,
+	})
+	if err != nil {
+		return "", err
+	}
+	if diff == "" {
+		return "Files are identical", nil
+	}
+	return diff, nil
+}
+
+// splitLines splits the text in lines ending with a newline, without adding an empty line for the trailing newline
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const remoteCopyName = "main.sync-conflict-20230102-150405-ATOPHFJ.go"
+
+func newTestConflict(t *testing.T) (syncthing.Conflict, string) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, remoteCopyName), []byte("package main\n\nfunc main() {\n}\n"), 0600))
+	return syncthing.Conflict{
+		Folder:   &syncthing.Folder{LocalPath: dir},
+		Path:     remoteCopyName,
+		Original: "main.go",
+		DeviceID: syncthing.DefaultRemoteDeviceID[:7],
+	}, dir
+}
+
+func Test_getConflictDiff(t *testing.T) {
+	c, _ := newTestConflict(t)
+	diff, err := getConflictDiff(&c)
+	require.NoError(t, err)
+	expected := `--- local/main.go
++++ remote/main.go
+@@ -1,3 +1,4 @@
+ package main
+ 
+-func main() {}
++func main() {
++}
+`
+	assert.Equal(t, expected, diff)
+}
+
+func Test_resolveConflicts(t *testing.T) {
+	tests := []struct {
+		name            string
+		options         *ConflictsOptions
+		answer          string
+		expectedContent string
+		expectedCopy    bool
+	}{
+		{
+			name:            "ask keep remote",
+			options:         &ConflictsOptions{},
+			answer:          keepRemoteOption,
+			expectedContent: "package main\n\nfunc main() {\n}\n",
+		},
+		{
+			name:            "ask skip",
+			options:         &ConflictsOptions{},
+			answer:          skipOption,
+			expectedContent: "package main\n\nfunc main() {}\n",
+			expectedCopy:    true,
+		},
+		{
+			name:            "keep local flag",
+			options:         &ConflictsOptions{Keep: keepLocal},
+			expectedContent: "package main\n\nfunc main() {}\n",
+		},
+		{
+			name:            "list",
+			options:         &ConflictsOptions{List: true},
+			expectedContent: "package main\n\nfunc main() {}\n",
+			expectedCopy:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, dir := newTestConflict(t)
+			asked := false
+			ask := func(options []string, label string) (string, error) {
+				asked = true
+				return tt.answer, nil
+			}
+			require.NoError(t, resolveConflicts([]syncthing.Conflict{c}, tt.options, ask))
+			assert.Equal(t, tt.answer != "", asked)
+
+			content, err := os.ReadFile(filepath.Join(dir, "main.go"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedContent, string(content))
+			_, err = os.Stat(filepath.Join(dir, remoteCopyName))
+			assert.Equal(t, tt.expectedCopy, err == nil)
+		})
+	}
+}
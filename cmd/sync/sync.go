@@ -0,0 +1,31 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+// Sync has all the subcommands to manage the file synchronization of the development containers
+func Sync(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Manage the file synchronization of your development containers",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(Conflicts(ctx))
+	return cmd
+}
@@ -32,6 +32,7 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/ssh"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -50,6 +51,7 @@ func (up *upContext) activate() error {
 	up.Cancel = cancel
 	up.ShutdownCompleted = make(chan bool, 1)
 	up.Sy = nil
+	up.syncEngine = nil
 	up.Forwarder = nil
 	defer func() {
 		if up.Dev.IsHybridModeEnabled() {
@@ -118,11 +120,13 @@ func (up *upContext) activate() error {
 		}
 	}
 
-	go func() {
-		if err := up.initializeSyncthing(); err != nil {
-			oktetoLog.Infof("could not initialize syncthing: %s", err)
-		}
-	}()
+	if !up.Dev.Sync.IsSSHSyncEngine() {
+		go func() {
+			if err := up.initializeSyncthing(); err != nil {
+				oktetoLog.Infof("could not initialize syncthing: %s", err)
+			}
+		}()
+	}
 	if err := up.setDevContainer(app); err != nil {
 		return err
 	}
@@ -241,7 +245,10 @@ func (up *upContext) shouldRetry(ctx context.Context, err error) bool {
 	case oktetoErrors.ErrLostSyncthing:
 		return true
 	case oktetoErrors.ErrCommandFailed:
-		return !up.Sy.Ping(ctx, false)
+		if up.syncEngine == nil {
+			return false
+		}
+		return !up.syncEngine.Ping(ctx)
 	case oktetoErrors.ErrApplyToApp:
 		return true
 	}
@@ -293,9 +300,11 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 		return err
 	}
 
-	initSyncErr := <-up.hardTerminate
-	if initSyncErr != nil {
-		return initSyncErr
+	if !up.Dev.Sync.IsSSHSyncEngine() {
+		initSyncErr := <-up.hardTerminate
+		if initSyncErr != nil {
+			return initSyncErr
+		}
 	}
 
 	oktetoLog.Info("create deployment secrets")
@@ -323,6 +332,12 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 		}
 	}
 
+	if up.Dev.IsSideBySide() {
+		if err := up.createSideBySideServices(ctx, app, k8sClient); err != nil {
+			return err
+		}
+	}
+
 	pod, err := apps.GetRunningPodInLoop(ctx, up.Dev, devApp, k8sClient)
 	if err != nil {
 		return err
@@ -330,6 +345,11 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 
 	up.Pod = pod
 
+	// checked once the development container is running, it no longer uses the previous key if it was recreated
+	if err := ssh.ReplacePreviousKey(ctx, up.Dev.Namespace, k8sClient); err != nil {
+		oktetoLog.Infof("failed to replace the previous ssh key: %s", err)
+	}
+
 	return nil
 }
 
@@ -106,13 +106,21 @@ func expandExecutableInCurrentDirectory(args0, dir string) (string, error) {
 	return args0, nil
 }
 
+// WarnIfSSHAgentNotFound warns when 'sshAgent' is enabled but there is no local SSH agent to forward
+func WarnIfSSHAgentNotFound(dev *model.Dev) {
+	if dev.SSHAgent && !ssh.IsAgentAvailable() {
+		oktetoLog.Warning("SSH agent not found: start your SSH agent and set SSH_AUTH_SOCK to use it in your development container")
+	}
+}
+
 type syncExecutor struct {
-	iface      string
-	remotePort int
+	iface        string
+	remotePort   int
+	forwardAgent bool
 }
 
 func (se *syncExecutor) RunCommand(ctx context.Context, cmd []string) error {
-	return ssh.Exec(ctx, se.iface, se.remotePort, true, os.Stdin, os.Stdout, os.Stderr, cmd)
+	return ssh.Exec(ctx, se.iface, se.remotePort, true, se.forwardAgent, os.Stdin, os.Stdout, os.Stderr, cmd)
 }
 
 func NewHybridExecutor(ctx context.Context, hybridCtx *HybridExecCtx) (*hybridExecutor, error) {
@@ -134,8 +142,9 @@ func NewHybridExecutor(ctx context.Context, hybridCtx *HybridExecCtx) (*hybridEx
 
 func newSyncExecutor(up *upContext) *syncExecutor {
 	return &syncExecutor{
-		iface:      up.Dev.Interface,
-		remotePort: up.Dev.RemotePort,
+		iface:        up.Dev.Interface,
+		remotePort:   up.Dev.RemotePort,
+		forwardAgent: up.Dev.SSHAgent,
 	}
 }
 
@@ -181,6 +190,7 @@ type envsGetter struct {
 	secretsEnvsGetter     secretsEnvsGetterInterface
 	imageEnvsGetter       imageEnvsGetterInterface
 	getDefaultLocalEnvs   func() []string
+	getPortEnvs           func(*model.Dev) []string
 }
 
 func newEnvsGetter(hybridCtx *HybridExecCtx) (*envsGetter, error) {
@@ -208,6 +218,7 @@ func newEnvsGetter(hybridCtx *HybridExecCtx) (*envsGetter, error) {
 			imageGetter: registry.NewOktetoRegistry(okteto.Config{}),
 		},
 		getDefaultLocalEnvs: getDefaultLocalEnvs,
+		getPortEnvs:         getPortEnvs,
 	}, nil
 }
 
@@ -245,6 +256,8 @@ func (eg *envsGetter) getEnvs(ctx context.Context) ([]string, error) {
 
 	envs = append(envs, eg.getDefaultLocalEnvs()...)
 
+	envs = append(envs, eg.getPortEnvs(eg.dev)...)
+
 	for _, env := range eg.dev.Environment {
 		envs = append(envs, fmt.Sprintf("%s=%s", env.Name, env.Value))
 	}
@@ -420,6 +433,9 @@ func (up *upContext) RunCommand(ctx context.Context, cmd []string) error {
 			if err != nil {
 				return err
 			}
+			if len(up.hybridHosts) > 0 {
+				executor.envs = replaceEnvHosts(executor.envs, up.hybridHosts)
+			}
 
 			cmd, err := executor.GetCommandToExec(cmd)
 			if err != nil {
@@ -224,6 +224,7 @@ func TestGetEnvs(t *testing.T) {
 				secretsEnvsGetter:     &tt.fakeSecretEnvsGetter,
 				imageEnvsGetter:       &tt.fakeImageEnvsGetter,
 				getDefaultLocalEnvs:   func() []string { return []string{} },
+				getPortEnvs:           func(*model.Dev) []string { return nil },
 			}
 
 			envs, err := eg.getEnvs(ctx)
@@ -397,6 +398,7 @@ func TestGetEnvForHybridModeWithProperPriority(t *testing.T) {
 		"ENVFROMSECRET=FROMSECRETVALUE",
 		"ENVFROMCONFIGMAP=FROMCONFIGMAPVALUE",
 		"ENVFROMPOD=FROMPODVALUE",
+		"OKTETO_PORT_8080=21000",
 		"ENVFROMMANIFEST=FROMMANIFESTVALUE",
 	}
 
@@ -410,6 +412,7 @@ func TestGetEnvForHybridModeWithProperPriority(t *testing.T) {
 		secretsEnvsGetter:     &fakeSecretEnvsGetter,
 		imageEnvsGetter:       &fakeImageEnvsGetter,
 		getDefaultLocalEnvs:   func() []string { return []string{} },
+		getPortEnvs:           func(*model.Dev) []string { return []string{"OKTETO_PORT_8080=21000"} },
 	}
 	envs, err := eg.getEnvs(ctx)
 	require.NoError(t, err)
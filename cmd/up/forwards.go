@@ -63,11 +63,7 @@ func (up *upContext) forwards(ctx context.Context) error {
 		}
 	}
 
-	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemotePort, Remote: syncthing.ClusterPort}); err != nil {
-		return err
-	}
-
-	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemoteGUIPort, Remote: syncthing.GUIPort}); err != nil {
+	if err := up.addSyncthingForwards(); err != nil {
 		return err
 	}
 
@@ -97,16 +93,22 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 	}
 
 	up.Forwarder = ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", up.Dev.RemotePort), up.Dev.Interface, "0.0.0.0", f, up.Dev.Namespace)
-	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemotePort, Remote: syncthing.ClusterPort}); err != nil {
+	if err := up.addSyncthingForwards(); err != nil {
 		return err
 	}
 
-	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemoteGUIPort, Remote: syncthing.GUIPort}); err != nil {
+	if err := addToForwarder(up); err != nil {
 		return err
 	}
 
-	if err := addToForwarder(up); err != nil {
-		return err
+	if up.Dev.Proxy > 0 {
+		if err := up.Forwarder.AddProxy(up.Dev.Proxy); err != nil {
+			return err
+		}
+	}
+
+	if up.Dev.IsHybridModeEnabled() {
+		up.addHybridServiceForwards(ctx)
 	}
 
 	if err := ssh.AddEntry(up.Dev.Name, up.Dev.Interface, up.Dev.RemotePort); err != nil {
@@ -131,14 +133,14 @@ func addToForwarder(up *upContext) error {
 	ticker := time.NewTicker(1 * time.Second)
 	to := time.NewTicker(10 * time.Second)
 	var forwardErr error
-	alreadyAdded := map[int]bool{}
+	alreadyAdded := map[string]bool{}
 	for {
 		select {
 		case <-ticker.C:
 			forwardErr = nil
 
 			for idx, f := range up.Dev.Forward {
-				if _, ok := alreadyAdded[f.Local]; ok {
+				if _, ok := alreadyAdded[forwardKey(f.Local, f.Protocol, f.LocalSocket)]; ok {
 					continue
 				}
 				if f.Labels != nil {
@@ -150,21 +152,21 @@ func addToForwarder(up *upContext) error {
 					}
 					up.Dev.Forward[idx] = forwardWithServiceName
 					f = forwardWithServiceName
-					alreadyAdded[f.Local] = true
+					alreadyAdded[forwardKey(f.Local, f.Protocol, f.LocalSocket)] = true
 				}
 				if err := up.Forwarder.Add(f); err != nil {
 					oktetoLog.Infof("could not create forward port: %s", err)
 					forwardErr = err
 					continue
 				}
-				alreadyAdded[f.Local] = true
+				alreadyAdded[forwardKey(f.Local, f.Protocol, f.LocalSocket)] = true
 			}
 			if forwardErr != nil {
 				continue
 			}
 
 			for _, r := range up.Dev.Reverse {
-				if _, ok := alreadyAdded[r.Local]; ok {
+				if _, ok := alreadyAdded[forwardKey(r.Local, r.Protocol, r.LocalSocket)]; ok {
 					continue
 				}
 				if err := up.Forwarder.AddReverse(r); err != nil {
@@ -172,7 +174,7 @@ func addToForwarder(up *upContext) error {
 					forwardErr = err
 					continue
 				}
-				alreadyAdded[r.Local] = true
+				alreadyAdded[forwardKey(r.Local, r.Protocol, r.LocalSocket)] = true
 			}
 
 			if forwardErr != nil {
@@ -188,6 +190,14 @@ func addToForwarder(up *upContext) error {
 	}
 }
 
+// forwardKey identifies the local side of a forward, since a TCP and an UDP forward can use the same port
+func forwardKey(local int, protocol, socket string) string {
+	if socket != "" {
+		return socket
+	}
+	return fmt.Sprintf("%d%s", local, forward.ProtocolSuffix(protocol))
+}
+
 func (up *upContext) setGlobalForwardsIfRequiredLoop(ctx context.Context) {
 	ticker := time.NewTicker(time.Second)
 
@@ -261,3 +271,14 @@ func addGlobalForwards(up *upContext) error {
 
 	return nil
 }
+
+// addSyncthingForwards forwards the ports of the remote syncthing, not running with the ssh sync engine
+func (up *upContext) addSyncthingForwards() error {
+	if up.Dev.Sync.IsSSHSyncEngine() {
+		return nil
+	}
+	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemotePort, Remote: syncthing.ClusterPort}); err != nil {
+		return err
+	}
+	return up.Forwarder.Add(forward.Forward{Local: up.Sy.RemoteGUIPort, Remote: syncthing.GUIPort})
+}
@@ -0,0 +1,282 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+const (
+	// defaultSyncHookDebounce is the time to wait for more changes before running a sync hook
+	defaultSyncHookDebounce = 1 * time.Second
+
+	syncHookPIDFileTemplate = "/tmp/okteto-hook-%s.pid"
+)
+
+type hookExecutor interface {
+	Exec(ctx context.Context, command []string, out io.Writer) error
+}
+
+type sshHookExecutor struct {
+	iface      string
+	remotePort int
+}
+
+// Exec runs the command in the development container without attaching the stdin of the terminal
+func (e *sshHookExecutor) Exec(ctx context.Context, command []string, out io.Writer) error {
+	return ssh.Exec(ctx, e.iface, e.remotePort, false, false, nil, out, out, command)
+}
+
+// syncHooksRunner runs the sync hooks of a development container when the files matching its paths are synchronized
+type syncHooksRunner struct {
+	hooks    []model.SyncHook
+	executor hookExecutor
+
+	mu      sync.Mutex
+	timers  map[int]*time.Timer
+	running map[int]context.CancelFunc
+	locks   map[int]*sync.Mutex
+}
+
+func newSyncHooksRunner(hooks []model.SyncHook, executor hookExecutor) *syncHooksRunner {
+	r := &syncHooksRunner{
+		hooks:    hooks,
+		executor: executor,
+		timers:   map[int]*time.Timer{},
+		running:  map[int]context.CancelFunc{},
+		locks:    map[int]*sync.Mutex{},
+	}
+	for i := range hooks {
+		r.locks[i] = &sync.Mutex{}
+	}
+	return r
+}
+
+func (up *upContext) runSyncHooks(ctx context.Context) {
+	executor := &sshHookExecutor{iface: up.Dev.Interface, remotePort: up.Dev.RemotePort}
+	runner := newSyncHooksRunner(up.Dev.Sync.Hooks, executor)
+
+	synced := make(chan syncthing.SyncedItems)
+	go up.syncEngine.WatchSyncedItems(ctx, synced)
+	runner.run(ctx, synced)
+}
+
+func (r *syncHooksRunner) run(ctx context.Context, synced <-chan syncthing.SyncedItems) {
+	for {
+		select {
+		case items := <-synced:
+			for i := range r.hooks {
+				if r.matches(&r.hooks[i], items.Items) {
+					r.schedule(ctx, i)
+				}
+			}
+		case <-ctx.Done():
+			r.stop()
+			return
+		}
+	}
+}
+
+// schedule runs the hook once no more changes are received during its debounce time
+func (r *syncHooksRunner) schedule(ctx context.Context, index int) {
+	debounce := r.hooks[index].Debounce
+	if debounce == 0 {
+		debounce = defaultSyncHookDebounce
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.timers[index]; ok {
+		t.Stop()
+	}
+	r.timers[index] = time.AfterFunc(debounce, func() {
+		r.execute(ctx, index)
+	})
+}
+
+func (r *syncHooksRunner) execute(ctx context.Context, index int) {
+	if ctx.Err() != nil {
+		return
+	}
+	hook := &r.hooks[index]
+	name := hook.GetName(index)
+
+	if hook.Restart {
+		r.restart(ctx, index)
+		return
+	}
+
+	// hooks not running in restart mode never overlap with themselves
+	r.locks[index].Lock()
+	defer r.locks[index].Unlock()
+
+	oktetoLog.Information("Running sync hook '%s'...", name)
+	out := newHookWriter(name)
+	defer out.Flush()
+	if err := r.executor.Exec(ctx, hook.Command.Values, out); err != nil {
+		if ctx.Err() == nil {
+			oktetoLog.Warning("Sync hook '%s' failed: %s", name, err)
+		}
+		return
+	}
+	oktetoLog.Success("Sync hook '%s' completed", name)
+}
+
+// restart stops the previous execution of a hook and starts it again in the background
+func (r *syncHooksRunner) restart(ctx context.Context, index int) {
+	hook := &r.hooks[index]
+	name := hook.GetName(index)
+	// the name is part of the path of the pid file and of the shell commands using it
+	pidFile := shellquote.Join(fmt.Sprintf(syncHookPIDFileTemplate, strings.ReplaceAll(name, "/", "-")))
+
+	r.locks[index].Lock()
+	defer r.locks[index].Unlock()
+
+	r.mu.Lock()
+	cancel, ok := r.running[index]
+	r.mu.Unlock()
+	if ok {
+		cancel()
+		stop := []string{"sh", "-c", fmt.Sprintf("kill $(cat %s) 2>/dev/null; rm -f %s; true", pidFile, pidFile)}
+		if err := r.executor.Exec(ctx, stop, io.Discard); err != nil {
+			oktetoLog.Infof("failed to stop sync hook '%s': %s", name, err)
+		}
+	}
+
+	hookCtx, hookCancel := context.WithCancel(ctx)
+	r.mu.Lock()
+	r.running[index] = hookCancel
+	r.mu.Unlock()
+
+	command := append([]string{"sh", "-c", fmt.Sprintf(`echo $$ > %s && exec "$@"`, pidFile), "sh"}, hook.Command.Values...)
+	oktetoLog.Information("Restarting sync hook '%s'...", name)
+	go func() {
+		out := newHookWriter(name)
+		defer out.Flush()
+		err := r.executor.Exec(hookCtx, command, out)
+		if err != nil && hookCtx.Err() == nil {
+			oktetoLog.Warning("Sync hook '%s' exited: %s", name, err)
+		}
+	}()
+}
+
+func (r *syncHooksRunner) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range r.timers {
+		t.Stop()
+	}
+	for _, cancel := range r.running {
+		cancel()
+	}
+}
+
+// matches returns true if any of the synchronized items matches the paths of the hook
+func (*syncHooksRunner) matches(hook *model.SyncHook, items []string) bool {
+	if len(hook.Paths) == 0 {
+		return len(items) > 0
+	}
+	for _, item := range items {
+		for _, pattern := range hook.Paths {
+			if matchSyncHookPath(pattern, item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchSyncHookPath matches a path relative to the sync folder with a glob pattern.
+// "**" matches any number of directories and patterns without "/" are matched against the base name
+func matchSyncHookPath(pattern, item string) bool {
+	item = strings.TrimPrefix(path.Clean(strings.ReplaceAll(item, "\\", "/")), "/")
+	pattern = strings.TrimPrefix(pattern, "./")
+	if !strings.Contains(pattern, "/") {
+		ok, err := path.Match(pattern, path.Base(item))
+		return err == nil && ok
+	}
+	return matchPathSegments(strings.Split(pattern, "/"), strings.Split(item, "/"))
+}
+
+func matchPathSegments(pattern, item []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(item); i++ {
+				if matchPathSegments(pattern[1:], item[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(item) == 0 {
+			return false
+		}
+		ok, err := path.Match(pattern[0], item[0])
+		if err != nil || !ok {
+			return false
+		}
+		pattern, item = pattern[1:], item[1:]
+	}
+	return len(item) == 0
+}
+
+// hookWriter prints the output of a sync hook line by line prefixed with the name of the hook
+type hookWriter struct {
+	prefix string
+	mu     sync.Mutex
+	buf    bytes.Buffer
+}
+
+func newHookWriter(name string) *hookWriter {
+	return &hookWriter{prefix: oktetoLog.BlueString(fmt.Sprintf("[%s]", name))}
+}
+
+func (w *hookWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// keep the incomplete line until the rest of it is written
+			w.buf.Reset()
+			w.buf.WriteString(line)
+			return len(p), nil
+		}
+		oktetoLog.Println(fmt.Sprintf("%s %s", w.prefix, strings.TrimRight(line, "\r\n")))
+	}
+}
+
+// Flush prints the remaining output of the hook
+func (w *hookWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.buf.Len() > 0 {
+		oktetoLog.Println(fmt.Sprintf("%s %s", w.prefix, strings.TrimRight(w.buf.String(), "\r\n")))
+		w.buf.Reset()
+	}
+}
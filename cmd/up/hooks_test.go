@@ -0,0 +1,149 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeHookExecutor struct {
+	mu       sync.Mutex
+	commands [][]string
+}
+
+func (f *fakeHookExecutor) Exec(_ context.Context, command []string, _ io.Writer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commands = append(f.commands, command)
+	return nil
+}
+
+func (f *fakeHookExecutor) getCommands() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.commands
+}
+
+func Test_matchSyncHookPath(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		item     string
+		expected bool
+	}{
+		{pattern: "*.go", item: "main.go", expected: true},
+		{pattern: "*.go", item: "pkg/api/main.go", expected: true},
+		{pattern: "*.go", item: "README.md", expected: false},
+		{pattern: "pkg/*.go", item: "pkg/main.go", expected: true},
+		{pattern: "pkg/*.go", item: "pkg/api/main.go", expected: false},
+		{pattern: "pkg/**/*.go", item: "pkg/api/v1/main.go", expected: true},
+		{pattern: "pkg/**/*.go", item: "pkg/main.go", expected: true},
+		{pattern: "./cmd/**", item: "cmd/up/up.go", expected: true},
+		{pattern: "cmd/**", item: "pkg/up.go", expected: false},
+		{pattern: "**/package.json", item: "frontend/package.json", expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"-"+tt.item, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchSyncHookPath(tt.pattern, tt.item))
+		})
+	}
+}
+
+func Test_syncHooksRunner(t *testing.T) {
+	executor := &fakeHookExecutor{}
+	hooks := []model.SyncHook{
+		{
+			Name:     "build",
+			Command:  model.Command{Values: []string{"go", "build"}},
+			Paths:    []string{"*.go"},
+			Debounce: 50 * time.Millisecond,
+		},
+		{
+			Name:     "npm",
+			Command:  model.Command{Values: []string{"npm", "install"}},
+			Paths:    []string{"package.json"},
+			Debounce: 50 * time.Millisecond,
+		},
+	}
+	runner := newSyncHooksRunner(hooks, executor)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	synced := make(chan syncthing.SyncedItems)
+	go runner.run(ctx, synced)
+
+	synced <- syncthing.SyncedItems{Items: []string{"main.go"}}
+	synced <- syncthing.SyncedItems{Items: []string{"pkg/api.go", "README.md"}}
+
+	assert.Eventually(t, func() bool {
+		return len(executor.getCommands()) == 1
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, [][]string{{"go", "build"}}, executor.getCommands())
+}
+
+func Test_syncHooksRunnerRestart(t *testing.T) {
+	executor := &fakeHookExecutor{}
+	hooks := []model.SyncHook{
+		{
+			Name:     "server",
+			Command:  model.Command{Values: []string{"./server"}},
+			Debounce: 10 * time.Millisecond,
+			Restart:  true,
+		},
+	}
+	runner := newSyncHooksRunner(hooks, executor)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runner.execute(ctx, 0)
+	assert.Eventually(t, func() bool {
+		return len(executor.getCommands()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"sh", "-c", `echo $$ > /tmp/okteto-hook-server.pid && exec "$@"`, "sh", "./server"}, executor.getCommands()[0])
+
+	runner.execute(ctx, 0)
+	assert.Eventually(t, func() bool {
+		return len(executor.getCommands()) == 3
+	}, time.Second, 10*time.Millisecond)
+	commands := executor.getCommands()
+	assert.Contains(t, commands, []string{"sh", "-c", "kill $(cat /tmp/okteto-hook-server.pid) 2>/dev/null; rm -f /tmp/okteto-hook-server.pid; true"})
+}
+
+func Test_syncHooksRunnerRestartQuotesName(t *testing.T) {
+	executor := &fakeHookExecutor{}
+	hooks := []model.SyncHook{
+		{
+			Name:    "api server; rm -rf /",
+			Command: model.Command{Values: []string{"./server"}},
+			Restart: true,
+		},
+	}
+	runner := newSyncHooksRunner(hooks, executor)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runner.execute(ctx, 0)
+	assert.Eventually(t, func() bool {
+		return len(executor.getCommands()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"sh", "-c", `echo $$ > '/tmp/okteto-hook-api server; rm -rf -.pid' && exec "$@"`, "sh", "./server"}, executor.getCommands()[0])
+}
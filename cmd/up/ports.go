@@ -0,0 +1,124 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"path/filepath"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/ports"
+)
+
+// allocateAutoPorts sets the local port of the forwards and global forwards defined with 'localPort: auto'.
+// The allocated ports are recorded in the state folder of the development container and in '.okteto/ports.env'
+func allocateAutoPorts(dev *model.Dev, globalForwards []forward.GlobalForward, manifestDir string) error {
+	previous, err := ports.Load(dev.Namespace, dev.Name)
+	if err != nil {
+		oktetoLog.Infof("failed to load the allocated ports: %s", err)
+	}
+
+	if !hasAutoPorts(dev, globalForwards) {
+		if len(previous) > 0 {
+			// the ports allocated by a previous manifest are not exported anymore
+			return ports.Save(dev.Namespace, dev.Name, nil)
+		}
+		return nil
+	}
+
+	allocator := ports.NewAllocator(dev.Namespace, dev.Name, dev.Interface, previous, getReservedPorts(dev, globalForwards))
+	for i := range dev.Forward {
+		f := &dev.Forward[i]
+		if !f.IsAuto() {
+			continue
+		}
+		f.Local, err = allocator.Allocate(f.ServiceName, f.Remote, false)
+		if err != nil {
+			return err
+		}
+	}
+	for i := range globalForwards {
+		gf := &globalForwards[i]
+		if !gf.IsAuto() {
+			continue
+		}
+		gf.Local, err = allocator.Allocate(gf.ServiceName, gf.Remote, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	allocations := allocator.Allocations()
+	if err := ports.Save(dev.Namespace, dev.Name, allocations); err != nil {
+		return err
+	}
+
+	envFile := filepath.Join(manifestDir, ports.EnvFile)
+	if err := ports.WriteEnvFile(envFile, allocations); err != nil {
+		oktetoLog.Warning("failed to write the allocated ports to '%s': %s", envFile, err)
+	}
+	return nil
+}
+
+func hasAutoPorts(dev *model.Dev, globalForwards []forward.GlobalForward) bool {
+	for i := range dev.Forward {
+		if dev.Forward[i].IsAuto() {
+			return true
+		}
+	}
+	for i := range globalForwards {
+		if globalForwards[i].IsAuto() {
+			return true
+		}
+	}
+	return false
+}
+
+// getReservedPorts returns the local ports explicitly defined in the manifest, which are never allocated
+func getReservedPorts(dev *model.Dev, globalForwards []forward.GlobalForward) []int {
+	reserved := []int{}
+	for _, f := range dev.Forward {
+		if f.Local != 0 {
+			reserved = append(reserved, f.Local)
+		}
+	}
+	for _, r := range dev.Reverse {
+		if r.Local != 0 {
+			reserved = append(reserved, r.Local)
+		}
+	}
+	for _, gf := range globalForwards {
+		if gf.Local != 0 {
+			reserved = append(reserved, gf.Local)
+		}
+	}
+	if dev.Proxy > 0 {
+		reserved = append(reserved, dev.Proxy)
+	}
+	if dev.RemotePort > 0 {
+		reserved = append(reserved, dev.RemotePort)
+	}
+	return reserved
+}
+
+// getPortEnvs returns the environment variables with the ports allocated to the forwards of the development container
+func getPortEnvs(dev *model.Dev) []string {
+	allocations, err := ports.Load(dev.Namespace, dev.Name)
+	if err != nil {
+		oktetoLog.Infof("failed to load the allocated ports: %s", err)
+		return nil
+	}
+	return ports.EnvVars(allocations)
+}
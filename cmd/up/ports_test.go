@@ -0,0 +1,103 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAutoPortsDev() (*model.Dev, []forward.GlobalForward) {
+	dev := &model.Dev{
+		Name:      "api",
+		Namespace: "test",
+		Interface: model.Localhost,
+		Forward: []forward.Forward{
+			{Local: 8080, Remote: 8080},
+			{Local: 0, Remote: 9229},
+			{Local: 0, Remote: 5432, Service: true, ServiceName: "db"},
+		},
+	}
+	globalForwards := []forward.GlobalForward{
+		{Local: 0, Remote: 6379, ServiceName: "redis"},
+	}
+	return dev, globalForwards
+}
+
+func TestAllocateAutoPorts(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	manifestDir := t.TempDir()
+
+	dev, globalForwards := newAutoPortsDev()
+	require.NoError(t, allocateAutoPorts(dev, globalForwards, manifestDir))
+
+	assert.Equal(t, 8080, dev.Forward[0].Local)
+	assert.NotZero(t, dev.Forward[1].Local)
+	assert.NotZero(t, dev.Forward[2].Local)
+	assert.NotZero(t, globalForwards[0].Local)
+
+	allocations, err := ports.Load("test", "api")
+	require.NoError(t, err)
+	assert.Len(t, allocations, 3)
+
+	b, err := os.ReadFile(filepath.Join(manifestDir, ports.EnvFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), fmt.Sprintf("OKTETO_PORT_9229=%d", dev.Forward[1].Local))
+	assert.Contains(t, string(b), fmt.Sprintf("OKTETO_PORT_DB_5432=%d", dev.Forward[2].Local))
+	assert.Contains(t, string(b), fmt.Sprintf("OKTETO_PORT_REDIS_6379=%d", globalForwards[0].Local))
+
+	assert.ElementsMatch(t, ports.EnvVars(allocations), getPortEnvs(dev))
+
+	again, againGlobalForwards := newAutoPortsDev()
+	require.NoError(t, allocateAutoPorts(again, againGlobalForwards, manifestDir))
+	assert.Equal(t, dev.Forward, again.Forward)
+	assert.Equal(t, globalForwards, againGlobalForwards)
+}
+
+func TestAllocateAutoPortsWithoutAutoPorts(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	manifestDir := t.TempDir()
+
+	require.NoError(t, ports.Save("test", "api", []ports.Allocation{{Remote: 9229, Local: 21000}}))
+
+	dev := &model.Dev{
+		Name:      "api",
+		Namespace: "test",
+		Forward:   []forward.Forward{{Local: 8080, Remote: 8080}},
+	}
+	require.NoError(t, allocateAutoPorts(dev, nil, manifestDir))
+
+	assert.Empty(t, getPortEnvs(dev))
+	_, err := os.Stat(filepath.Join(manifestDir, ports.EnvFile))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestGetReservedPorts(t *testing.T) {
+	dev, globalForwards := newAutoPortsDev()
+	dev.Reverse = []model.Reverse{{Local: 3000, Remote: 3000}}
+	dev.Proxy = 1080
+	dev.RemotePort = 2222
+	globalForwards = append(globalForwards, forward.GlobalForward{Local: 27017, Remote: 27017, ServiceName: "mongo"})
+
+	assert.ElementsMatch(t, []int{8080, 3000, 27017, 1080, 2222}, getReservedPorts(dev, globalForwards))
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/divert/istio"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/services"
+	"github.com/okteto/okteto/pkg/k8s/virtualservices"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+// createSideBySideServices creates the services of a side-by-side dev container and the header based routes to reach them
+func (up *upContext) createSideBySideServices(ctx context.Context, app apps.App, c kubernetes.Interface) error {
+	svcs, err := services.CreateSideBySide(ctx, up.Dev, app.TemplateObjectMeta().Labels, c)
+	if err != nil {
+		return err
+	}
+	if len(svcs) == 0 {
+		return nil
+	}
+
+	if !isIstioDivertEnabled(up.Manifest) {
+		oktetoLog.Information("The original '%s' keeps serving traffic. Use your forwards to reach the development container", up.Dev.Name)
+		return nil
+	}
+
+	ic, err := virtualservices.GetIstioClient()
+	if err != nil {
+		return fmt.Errorf("error creating istio client: %w", err)
+	}
+	if err := istio.DivertDevClone(ctx, up.Dev.Name, up.Dev.Namespace, svcs, ic); err != nil {
+		return fmt.Errorf("error diverting traffic to your development container: %w", err)
+	}
+	oktetoLog.Information("Requests with the baggage '%s=%s' are routed to your development container", constants.OktetoDivertHeaderName, istio.DevCloneBaggageValue(up.Dev.Name, up.Dev.Namespace))
+	return nil
+}
+
+func isIstioDivertEnabled(m *model.Manifest) bool {
+	if m == nil || m.Deploy == nil || m.Deploy.Divert == nil {
+		return false
+	}
+	return m.Deploy.Divert.Driver == constants.OktetoDivertIstioDriver
+}
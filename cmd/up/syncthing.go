@@ -16,12 +16,15 @@ package up
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/sshsync"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/spf13/afero"
 )
@@ -46,8 +49,27 @@ func (up *upContext) initializeSyncthing() error {
 	return nil
 }
 
+// syncConflictsInterval is the interval to check for new synchronization conflicts
+const syncConflictsInterval = 30 * time.Second
+
+// syncthingEngine synchronizes the files with the local and remote syncthing instances
+type syncthingEngine struct {
+	up *upContext
+}
+
+func (up *upContext) getSyncEngine() syncEngine {
+	if up.Dev.Sync.IsSSHSyncEngine() {
+		return sshsync.New(up.Dev, up.Fs)
+	}
+	return &syncthingEngine{up: up}
+}
+
 func (up *upContext) sync(ctx context.Context) error {
-	if err := up.startSyncthing(ctx); err != nil {
+	up.syncEngine = up.getSyncEngine()
+	if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.StartingSync); err != nil {
+		return err
+	}
+	if err := up.syncEngine.Start(ctx); err != nil {
 		return err
 	}
 
@@ -80,16 +102,93 @@ func (up *upContext) sync(ctx context.Context) error {
     More information is available here: https://okteto.com/docs/reference/file-synchronization/`, minutes, seconds)
 	}
 
-	up.Sy.Type = "sendreceive"
-	up.Sy.IgnoreDelete = false
-	if err := up.Sy.UpdateConfig(); err != nil {
+	if len(up.Dev.Sync.Hooks) > 0 {
+		if up.Dev.IsHybridModeEnabled() {
+			oktetoLog.Warning("The field 'sync.hooks' is ignored in hybrid mode: your files aren't synchronized to the development container")
+		} else {
+			go up.runSyncHooks(ctx)
+		}
+	}
+	return up.syncEngine.Watch(ctx, up.Disconnect)
+}
+
+// Start runs the local syncthing and waits until both syncthing instances are connected
+func (e *syncthingEngine) Start(ctx context.Context) error {
+	return e.up.startSyncthing(ctx)
+}
+
+// GetInSynchronizationFile returns the file being synchronized by syncthing
+func (e *syncthingEngine) GetInSynchronizationFile(ctx context.Context) string {
+	return e.up.Sy.GetInSynchronizationFile(ctx)
+}
+
+// WaitForCompletion waits until syncthing completes the initial synchronization
+func (e *syncthingEngine) WaitForCompletion(ctx context.Context, reporter chan float64) error {
+	return e.up.Sy.WaitForCompletion(ctx, reporter)
+}
+
+// Watch switches syncthing to sendreceive mode and monitors its connection
+func (e *syncthingEngine) Watch(ctx context.Context, disconnect chan error) error {
+	sy := e.up.Sy
+	sy.Type = "sendreceive"
+	sy.IgnoreDelete = false
+	if err := sy.UpdateConfig(); err != nil {
 		return err
 	}
 
-	go up.Sy.Monitor(ctx, up.Disconnect)
-	go up.Sy.MonitorStatus(ctx, up.Disconnect)
+	go sy.Monitor(ctx, disconnect)
+	go sy.MonitorStatus(ctx, disconnect)
+	go e.up.monitorSyncConflicts(ctx)
 	oktetoLog.Infof("restarting syncthing to update sync mode to sendreceive")
-	return up.Sy.Restart(ctx)
+	return sy.Restart(ctx)
+}
+
+// WatchSyncedItems sends to synced the files applied by the remote syncthing
+func (e *syncthingEngine) WatchSyncedItems(ctx context.Context, synced chan<- syncthing.SyncedItems) {
+	e.up.Sy.WatchSyncedItems(ctx, synced)
+}
+
+// Ping returns if the remote syncthing is reachable
+func (e *syncthingEngine) Ping(ctx context.Context) bool {
+	return e.up.Sy.Ping(ctx, false)
+}
+
+// Stop terminates the local syncthing
+func (e *syncthingEngine) Stop() error {
+	oktetoLog.Infof("stopping syncthing")
+	return e.up.Sy.SoftTerminate()
+}
+
+// monitorSyncConflicts warns about the conflict copies created by syncthing when a file is modified in both sides
+func (up *upContext) monitorSyncConflicts(ctx context.Context) {
+	ticker := time.NewTicker(syncConflictsInterval)
+	defer ticker.Stop()
+	warned := map[string]bool{}
+	for {
+		select {
+		case <-ticker.C:
+			conflicts, err := up.Sy.GetConflicts(ctx)
+			if err != nil {
+				oktetoLog.Infof("failed to get sync conflicts: %s", err)
+				continue
+			}
+			current := map[string]bool{}
+			newConflicts := []string{}
+			for _, c := range conflicts {
+				key := filepath.Join(c.Folder.LocalPath, c.Path)
+				current[key] = true
+				if !warned[key] {
+					newConflicts = append(newConflicts, c.Original)
+				}
+			}
+			warned = current
+			if len(newConflicts) > 0 {
+				oktetoLog.Warning("Synchronization conflicts detected in: %s\n    Run 'okteto sync conflicts' to resolve them", strings.Join(newConflicts, ", "))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
 func (up *upContext) startSyncthing(ctx context.Context) error {
@@ -99,10 +198,6 @@ func (up *upContext) startSyncthing(ctx context.Context) error {
 		defer oktetoLog.StopSpinner()
 	}
 
-	if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.StartingSync); err != nil {
-		return err
-	}
-
 	if err := up.Sy.Run(); err != nil {
 		return err
 	}
@@ -153,7 +248,7 @@ func (up *upContext) synchronizeFiles(ctx context.Context) error {
 			case <-quit:
 				return
 			case <-time.NewTicker(1 * time.Second).C:
-				inSynchronizationFile := up.Sy.GetInSynchronizationFile(ctx)
+				inSynchronizationFile := up.syncEngine.GetInSynchronizationFile(ctx)
 				if inSynchronizationFile != "" && oktetoLog.GetOutputFormat() != oktetoLog.PlainFormat {
 					oktetoLog.StopSpinner()
 					progressBar.UpdateItemInSync(inSynchronizationFile)
@@ -178,7 +273,7 @@ func (up *upContext) synchronizeFiles(ctx context.Context) error {
 		quit <- true
 	}()
 
-	if err := up.Sy.WaitForCompletion(ctx, reporter); err != nil {
+	if err := up.syncEngine.WaitForCompletion(ctx, reporter); err != nil {
 		up.analyticsMeta.ErrSync()
 		switch err {
 		case oktetoErrors.ErrLostSyncthing:
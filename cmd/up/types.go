@@ -20,7 +20,9 @@ import (
 
 	"github.com/moby/term"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/hosts"
 	"github.com/okteto/okteto/pkg/k8s/apps"
+	forwardk8s "github.com/okteto/okteto/pkg/k8s/forward"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -50,6 +52,7 @@ type upContext struct {
 	CommandResult         chan error
 	Exit                  chan error
 	Sy                    *syncthing.Syncthing
+	syncEngine            syncEngine
 	cleaned               chan string
 	hardTerminate         chan error
 	success               bool
@@ -64,15 +67,37 @@ type upContext struct {
 	K8sClientProvider     okteto.K8sClientProvider
 	Fs                    afero.Fs
 	hybridCommand         *exec.Cmd
+	hostsFile             *hosts.File
+	hybridHosts           map[string]string
+	hybridForwarders      []*forwardk8s.PortForwardManager
 	interruptReceived     bool
 	analyticsTracker      *analytics.AnalyticsTracker
 	analyticsMeta         *analytics.UpMetricsMetadata
 }
 
+// syncEngine is an interface for the file synchronization between the local folders and the development container
+type syncEngine interface {
+	// Start starts the engine and waits until it is ready to synchronize files
+	Start(ctx context.Context) error
+	// GetInSynchronizationFile returns the file being synchronized, if any
+	GetInSynchronizationFile(ctx context.Context) string
+	// WaitForCompletion waits for the initial synchronization, reporting its progress from 0 to 100
+	WaitForCompletion(ctx context.Context, reporter chan float64) error
+	// Watch keeps the files synchronized in background, reporting the errors that require a restart on disconnect
+	Watch(ctx context.Context, disconnect chan error) error
+	// WatchSyncedItems sends to synced the files applied in the development container
+	WatchSyncedItems(ctx context.Context, synced chan<- syncthing.SyncedItems)
+	// Ping returns if the development container is still reachable by the engine
+	Ping(ctx context.Context) bool
+	// Stop stops the engine
+	Stop() error
+}
+
 // Forwarder is an interface for the port-forwarding features
 type forwarder interface {
 	Add(forward.Forward) error
 	AddReverse(model.Reverse) error
+	AddProxy(int) error
 	Start(string, string) error
 	StartGlobalForwarding() error
 	Stop()
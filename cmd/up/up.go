@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -48,6 +49,7 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/okteto/okteto/pkg/okteto"
 	oktetoPath "github.com/okteto/okteto/pkg/path"
 	"github.com/okteto/okteto/pkg/registry"
@@ -86,6 +88,7 @@ type UpOptions struct {
 	Deploy           bool
 	ForcePull        bool
 	Reset            bool
+	Profile          string
 	commandToExecute []string
 }
 
@@ -149,7 +152,7 @@ func Up() *cobra.Command {
 				}
 				upOptions.ManifestPath = uptManifestPath
 			}
-			manifestOpts := contextCMD.ManifestOptions{Filename: upOptions.ManifestPath, Namespace: upOptions.Namespace, K8sContext: upOptions.K8sContext}
+			manifestOpts := contextCMD.ManifestOptions{Filename: upOptions.ManifestPath, Namespace: upOptions.Namespace, K8sContext: upOptions.K8sContext, Profile: upOptions.Profile}
 			oktetoManifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts)
 			if err != nil {
 				if err.Error() == fmt.Errorf(oktetoErrors.ErrNotLogged, okteto.CloudURL).Error() {
@@ -346,7 +349,7 @@ func Up() *cobra.Command {
 				return err
 			}
 
-			if syncthing.ShouldUpgrade() {
+			if !dev.Sync.IsSSHSyncEngine() && syncthing.ShouldUpgrade() {
 				oktetoLog.Println("Installing dependencies...")
 				if err := downloadSyncthing(); err != nil {
 					oktetoLog.Infof("failed to upgrade syncthing: %s", err)
@@ -380,6 +383,10 @@ func Up() *cobra.Command {
 				return err
 			}
 
+			if err := allocateAutoPorts(dev, up.Manifest.GlobalForward, wd); err != nil {
+				return err
+			}
+
 			if _, ok := os.LookupEnv(model.OktetoAutoDeployEnvVar); ok {
 				upOptions.Deploy = true
 			}
@@ -418,6 +425,7 @@ func Up() *cobra.Command {
 		oktetoLog.Infof("failed to mark 'pull' flag as hidden: %s", err)
 	}
 	cmd.Flags().BoolVarP(&upOptions.Reset, "reset", "", false, "reset the file synchronization database")
+	cmd.Flags().StringVarP(&upOptions.Profile, "profile", "", "", "use the subset of the manifest defined by a profile")
 	cmd.Flags().StringArrayVarP(&upOptions.commandToExecute, "command", "", []string{}, "external commands to be supplied to 'okteto up'")
 	return cmd
 }
@@ -495,6 +503,7 @@ func loadManifestOverrides(dev *model.Dev, upOptions *UpOptions) error {
 		}
 
 		dev.LoadRemote(ssh.GetPublicKey())
+		WarnIfSSHAgentNotFound(dev)
 	}
 
 	if upOptions.ForcePull {
@@ -929,10 +938,10 @@ func (up *upContext) shutdown() {
 		oktetoLog.Info("sent cancellation signal")
 	}
 
-	if up.Sy != nil {
-		oktetoLog.Infof("stopping syncthing")
-		if err := up.Sy.SoftTerminate(); err != nil {
-			oktetoLog.Infof("failed to stop syncthing during shutdown: %s", err.Error())
+	if up.syncEngine != nil {
+		oktetoLog.Infof("stopping the file synchronization")
+		if err := up.syncEngine.Stop(); err != nil {
+			oktetoLog.Infof("failed to stop the file synchronization during shutdown: %s", err.Error())
 		}
 	}
 
@@ -940,6 +949,7 @@ func (up *upContext) shutdown() {
 	if up.Forwarder != nil {
 		up.Forwarder.Stop()
 	}
+	up.stopHybridServiceForwards()
 
 	if up.Dev.IsHybridModeEnabled() {
 		oktetoLog.Infof("stopping local process...")
@@ -1032,29 +1042,25 @@ func printDisplayContext(up *upContext) {
 		fromIdxToShowWithoutForwardLabel := 0
 		if !anyGlobalForward {
 			fromIdxToShowWithoutForwardLabel = 1
-			if up.Dev.Forward[0].Service {
-				oktetoLog.Println(fmt.Sprintf("    %s   %d -> %s:%d", oktetoLog.BlueString("Forward:"), up.Dev.Forward[0].Local, up.Dev.Forward[0].ServiceName, up.Dev.Forward[0].Remote))
-			} else {
-				oktetoLog.Println(fmt.Sprintf("    %s   %d -> %d", oktetoLog.BlueString("Forward:"), up.Dev.Forward[0].Local, up.Dev.Forward[0].Remote))
-			}
+			oktetoLog.Println(fmt.Sprintf("    %s   %s", oktetoLog.BlueString("Forward:"), formatForward(up.Dev.Forward[0])))
 		}
 
 		for i := fromIdxToShowWithoutForwardLabel; i < len(up.Dev.Forward); i++ {
-			if up.Dev.Forward[i].Service {
-				oktetoLog.Println(fmt.Sprintf("               %d -> %s:%d", up.Dev.Forward[i].Local, up.Dev.Forward[i].ServiceName, up.Dev.Forward[i].Remote))
-				continue
-			}
-			oktetoLog.Println(fmt.Sprintf("               %d -> %d", up.Dev.Forward[i].Local, up.Dev.Forward[i].Remote))
+			oktetoLog.Println(fmt.Sprintf("               %s", formatForward(up.Dev.Forward[i])))
 		}
 	}
 
 	if len(up.Dev.Reverse) > 0 {
-		oktetoLog.Println(fmt.Sprintf("    %s   %d <- %d", oktetoLog.BlueString("Reverse:"), up.Dev.Reverse[0].Local, up.Dev.Reverse[0].Remote))
+		oktetoLog.Println(fmt.Sprintf("    %s   %s", oktetoLog.BlueString("Reverse:"), formatReverse(up.Dev.Reverse[0])))
 		for i := 1; i < len(up.Dev.Reverse); i++ {
-			oktetoLog.Println(fmt.Sprintf("               %d <- %d", up.Dev.Reverse[i].Local, up.Dev.Reverse[i].Remote))
+			oktetoLog.Println(fmt.Sprintf("               %s", formatReverse(up.Dev.Reverse[i])))
 		}
 	}
 
+	if up.Dev.Proxy > 0 {
+		oktetoLog.Println(fmt.Sprintf("    %s     %s (SOCKS5 and HTTP)", oktetoLog.BlueString("Proxy:"), net.JoinHostPort(up.Dev.Interface, strconv.Itoa(up.Dev.Proxy))))
+	}
+
 	oktetoLog.Println()
 }
 
@@ -1133,3 +1139,15 @@ func (tuc *tokenUpdaterController) UpdateKubeConfigToken() error {
 	okCtx.Cfg.AuthInfos[ctxUserID].Token = token.Status.Token
 	return nil
 }
+
+func formatForward(f forward.Forward) string {
+	remote := forward.Endpoint(f.Remote, f.RemoteSocket)
+	if f.Service {
+		remote = fmt.Sprintf("%s:%d", f.ServiceName, f.Remote)
+	}
+	return fmt.Sprintf("%s -> %s%s", forward.Endpoint(f.Local, f.LocalSocket), remote, forward.ProtocolSuffix(f.Protocol))
+}
+
+func formatReverse(r model.Reverse) string {
+	return fmt.Sprintf("%s <- %s%s", forward.Endpoint(r.Local, r.LocalSocket), forward.Endpoint(r.Remote, r.RemoteSocket), forward.ProtocolSuffix(r.Protocol))
+}
@@ -107,28 +107,29 @@ func DeprecatedLoadManifest(devPath string) (*model.Manifest, error) {
 }
 
 func LoadManifestRc(dev *model.Dev) error {
-	defaultDevRcPath := filepath.Join(config.GetOktetoHome(), "okteto.yml")
-	secondaryDevRcPath := filepath.Join(config.GetOktetoHome(), "okteto.yaml")
-	var devRc *model.DevRC
-	var err error
-	if filesystem.FileExists(defaultDevRcPath) {
-		devRc, err = model.GetRc(defaultDevRcPath)
-		if err != nil {
-			return fmt.Errorf("error while reading %s file: %s", defaultDevRcPath, err.Error())
-		}
-	} else if filesystem.FileExists(secondaryDevRcPath) {
-		devRc, err = model.GetRc(secondaryDevRcPath)
-		if err != nil {
-			return fmt.Errorf("error while reading %s file: %s", defaultDevRcPath, err.Error())
-		}
+	devRcPath := GetDevRcPath()
+	if devRcPath == "" {
+		return nil
 	}
-
-	if devRc != nil {
-		model.MergeDevWithDevRc(dev, devRc)
+	devRc, err := model.GetRc(devRcPath)
+	if err != nil {
+		return fmt.Errorf("error while reading %s file: %s", devRcPath, err.Error())
 	}
+	model.MergeDevWithDevRc(dev, devRc)
 	return nil
 }
 
+// GetDevRcPath returns the path of the developer level manifest merged into every dev container, or an empty string if it doesn't exist
+func GetDevRcPath() string {
+	for _, name := range []string{"okteto.yml", "okteto.yaml"} {
+		devRcPath := filepath.Join(config.GetOktetoHome(), name)
+		if filesystem.FileExists(devRcPath) {
+			return devRcPath
+		}
+	}
+	return ""
+}
+
 // DeprecatedLoadManifestOrDefault loads an okteto manifest or a default one if does not exist
 // Deprecatd. It should only be used by `push` command that will be deleted on next major version. No new usages should be added
 func DeprecatedLoadManifestOrDefault(devPath, name string) (*model.Manifest, error) {
@@ -0,0 +1,49 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// LoadManifestProfile returns the subset of the manifest defined by the profile, and the variables set by the profile as 'NAME=VALUE'.
+// The variables of the profile not set by the user are set before reading the manifest again with reload, so they are expanded
+func LoadManifestProfile(manifest *model.Manifest, profile string, userVariables []string, reload func() (*model.Manifest, error)) (*model.Manifest, []string, error) {
+	p, err := manifest.GetProfile(profile)
+	if err != nil {
+		return nil, nil, oktetoErrors.UserError{
+			E:    err,
+			Hint: "Define the profile in the 'profiles' section of your okteto manifest",
+		}
+	}
+
+	variables, err := p.SetVariables(userVariables)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(variables) > 0 {
+		manifest, err = reload()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := manifest.ApplyProfile(profile); err != nil {
+		return nil, nil, err
+	}
+	oktetoLog.Information("Using profile '%s'", profile)
+	return manifest, variables, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newProfileManifest() *model.Manifest {
+	return &model.Manifest{
+		Dev: model.ManifestDevs{
+			"web": &model.Dev{Name: "web"},
+			"api": &model.Dev{Name: "api"},
+		},
+		Profiles: model.ManifestProfiles{
+			"frontend": &model.Profile{
+				Dev:       []string{"web"},
+				Variables: model.Environment{{Name: "PROFILE_TEST_VAR", Value: "frontend"}},
+			},
+			"backend": &model.Profile{
+				Dev: []string{"api"},
+			},
+		},
+	}
+}
+
+func TestLoadManifestProfile(t *testing.T) {
+	t.Setenv("PROFILE_TEST_VAR", "")
+
+	reloads := 0
+	reload := func() (*model.Manifest, error) {
+		reloads++
+		return newProfileManifest(), nil
+	}
+
+	m, variables, err := LoadManifestProfile(newProfileManifest(), "frontend", nil, reload)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reloads)
+	assert.Equal(t, []string{"PROFILE_TEST_VAR=frontend"}, variables)
+	assert.Equal(t, []string{"web"}, m.Dev.GetDevs())
+
+	m, variables, err = LoadManifestProfile(newProfileManifest(), "backend", nil, reload)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reloads)
+	assert.Empty(t, variables)
+	assert.Equal(t, []string{"api"}, m.Dev.GetDevs())
+
+	_, _, err = LoadManifestProfile(newProfileManifest(), "mobile", nil, reload)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
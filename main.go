@@ -27,15 +27,21 @@ import (
 	"github.com/okteto/okteto/cmd"
 	"github.com/okteto/okteto/cmd/build"
 	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/cp"
 	"github.com/okteto/okteto/cmd/deploy"
 	"github.com/okteto/okteto/cmd/destroy"
+	"github.com/okteto/okteto/cmd/intercept"
 	"github.com/okteto/okteto/cmd/kubetoken"
 	"github.com/okteto/okteto/cmd/logs"
+	"github.com/okteto/okteto/cmd/manifest"
 	"github.com/okteto/okteto/cmd/namespace"
 	"github.com/okteto/okteto/cmd/pipeline"
+	"github.com/okteto/okteto/cmd/portforward"
 	"github.com/okteto/okteto/cmd/preview"
 	"github.com/okteto/okteto/cmd/registrytoken"
+	sshCMD "github.com/okteto/okteto/cmd/ssh"
 	"github.com/okteto/okteto/cmd/stack"
+	syncCMD "github.com/okteto/okteto/cmd/sync"
 	"github.com/okteto/okteto/cmd/up"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/config"
@@ -145,11 +151,18 @@ func main() {
 
 	root.AddCommand(namespace.Namespace(ctx))
 	root.AddCommand(cmd.Init())
+	root.AddCommand(manifest.Manifest(ctx))
 	root.AddCommand(up.Up())
 	root.AddCommand(cmd.Down())
 	root.AddCommand(cmd.Status())
+	root.AddCommand(syncCMD.Sync(ctx))
 	root.AddCommand(cmd.Doctor())
 	root.AddCommand(cmd.Exec())
+	root.AddCommand(cp.Copy(ctx))
+	root.AddCommand(cmd.Proxy(ctx))
+	root.AddCommand(intercept.Intercept(ctx))
+	root.AddCommand(portforward.PortForward(ctx))
+	root.AddCommand(sshCMD.SSH(ctx))
 	root.AddCommand(preview.Preview(ctx))
 	root.AddCommand(cmd.Restart())
 	root.AddCommand(cmd.UpdateDeprecated())
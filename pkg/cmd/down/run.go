@@ -16,9 +16,11 @@ package down
 import (
 	"context"
 
+	"github.com/okteto/okteto/pkg/divert/istio"
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/k8s/secrets"
 	"github.com/okteto/okteto/pkg/k8s/services"
+	"github.com/okteto/okteto/pkg/k8s/virtualservices"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/ssh"
@@ -66,6 +68,13 @@ func Run(dev *model.Dev, app apps.App, trMap map[string]*apps.Translation, wait
 		}
 	}
 
+	if dev.IsSideBySide() {
+		restoreSideBySideRoutes(ctx, dev)
+		if err := services.DestroySideBySide(ctx, dev, c); err != nil {
+			return err
+		}
+	}
+
 	if err := secrets.Destroy(ctx, dev, c); err != nil {
 		return err
 	}
@@ -84,6 +93,17 @@ func Run(dev *model.Dev, app apps.App, trMap map[string]*apps.Translation, wait
 	return nil
 }
 
+func restoreSideBySideRoutes(ctx context.Context, dev *model.Dev) {
+	ic, err := virtualservices.GetIstioClient()
+	if err != nil {
+		oktetoLog.Infof("failed to create istio client: %s", err)
+		return
+	}
+	if err := istio.RestoreDevClone(ctx, dev.Name, dev.Namespace, ic); err != nil {
+		oktetoLog.Infof("failed to restore virtual services: %s", err)
+	}
+}
+
 func stopSyncthing(dev *model.Dev) {
 	sy, err := syncthing.New(dev)
 	if err != nil {
@@ -0,0 +1,87 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+const idleState = "idle"
+
+// FolderState represents the synchronization state of a sync folder
+type FolderState struct {
+	Folder   *syncthing.Folder
+	State    string
+	Progress float64
+
+	// IgnoredChanges is the number of files changed in the side that only receives changes
+	IgnoredChanges int64
+}
+
+// GetFolderStates returns the synchronization state of each sync folder
+func GetFolderStates(ctx context.Context, sy *syncthing.Syncthing) ([]FolderState, error) {
+	result := []FolderState{}
+	for _, folder := range sy.Folders {
+		local, err := sy.GetFolderStatus(ctx, folder, true)
+		if err != nil {
+			return nil, err
+		}
+		remote, err := sy.GetFolderStatus(ctx, folder, false)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, getFolderState(folder, local, remote))
+	}
+	return result, nil
+}
+
+func getFolderState(folder *syncthing.Folder, local, remote *syncthing.FolderStatus) FolderState {
+	state := local.State
+	if state == idleState {
+		state = remote.State
+	}
+	return FolderState{
+		Folder:         folder,
+		State:          state,
+		Progress:       computeProgress(getFolderProgress(local), getFolderProgress(remote)),
+		IgnoredChanges: local.ReceiveOnlyChangedFiles + remote.ReceiveOnlyChangedFiles,
+	}
+}
+
+func getFolderProgress(status *syncthing.FolderStatus) float64 {
+	if status.GlobalBytes == 0 {
+		return 100
+	}
+	return (float64(status.GlobalBytes-status.NeedBytes) / float64(status.GlobalBytes)) * 100
+}
+
+// String returns the state of the folder in a single line
+func (f FolderState) String() string {
+	arrow := "<->"
+	switch f.Folder.Direction {
+	case model.SyncDirectionUp:
+		arrow = "->"
+	case model.SyncDirectionDown:
+		arrow = "<-"
+	}
+	result := fmt.Sprintf("%s %s %s: %s (%.2f%%)", f.Folder.LocalPath, arrow, f.Folder.RemotePath, f.State, f.Progress)
+	if f.IgnoredChanges > 0 {
+		result = fmt.Sprintf("%s, %d changes not synchronized due to the folder direction", result, f.IgnoredChanges)
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getFolderState(t *testing.T) {
+	tests := []struct {
+		name     string
+		folder   *syncthing.Folder
+		local    *syncthing.FolderStatus
+		remote   *syncthing.FolderStatus
+		expected string
+	}{
+		{
+			name:     "synchronized",
+			folder:   &syncthing.Folder{LocalPath: "/src", RemotePath: "/app"},
+			local:    &syncthing.FolderStatus{State: "idle", GlobalBytes: 100},
+			remote:   &syncthing.FolderStatus{State: "idle", GlobalBytes: 100},
+			expected: "/src <-> /app: idle (100.00%)",
+		},
+		{
+			name:     "remote syncing",
+			folder:   &syncthing.Folder{LocalPath: "/src", RemotePath: "/app", Direction: model.SyncDirectionUp},
+			local:    &syncthing.FolderStatus{State: "idle", GlobalBytes: 100},
+			remote:   &syncthing.FolderStatus{State: "syncing", GlobalBytes: 100, NeedBytes: 50},
+			expected: "/src -> /app: syncing (50.00%)",
+		},
+		{
+			name:     "local changes ignored",
+			folder:   &syncthing.Folder{LocalPath: "/src/coverage", RemotePath: "/app/coverage", Direction: model.SyncDirectionDown},
+			local:    &syncthing.FolderStatus{State: "idle", GlobalBytes: 100, ReceiveOnlyChangedFiles: 2},
+			remote:   &syncthing.FolderStatus{State: "idle", GlobalBytes: 100},
+			expected: "/src/coverage <- /app/coverage: idle (100.00%), 2 changes not synchronized due to the folder direction",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getFolderState(tt.folder, tt.local, tt.remote).String())
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cp
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// ProgressFunc is called with the number of bytes copied since its previous call
+type ProgressFunc func(n int64)
+
+// Copy copies srcPath from src to dstPath in dst, recursively if srcPath is a directory.
+// As with 'cp -r', srcPath is copied inside dstPath when dstPath is an existing directory
+func Copy(src FS, srcPath string, dst FS, dstPath string, progress ProgressFunc) error {
+	info, err := src.Stat(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s': %w", srcPath, err)
+	}
+
+	target := dstPath
+	if dstInfo, err := dst.Stat(dstPath); err == nil && dstInfo.IsDir() {
+		target = dst.Join(dstPath, src.Base(srcPath))
+	}
+
+	return copyPath(src, srcPath, info, dst, target, progress)
+}
+
+func copyPath(src FS, srcPath string, info os.FileInfo, dst FS, dstPath string, progress ProgressFunc) error {
+	switch {
+	case info.IsDir():
+		return copyDir(src, srcPath, info, dst, dstPath, progress)
+	case info.Mode().IsRegular():
+		return copyFile(src, srcPath, info, dst, dstPath, progress)
+	default:
+		oktetoLog.Infof("skipping '%s': not a regular file", srcPath)
+		return nil
+	}
+}
+
+func copyDir(src FS, srcPath string, info os.FileInfo, dst FS, dstPath string, progress ProgressFunc) error {
+	if err := dst.MkdirAll(dstPath, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to create directory '%s': %w", dstPath, err)
+	}
+
+	entries, err := src.ReadDir(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to read directory '%s': %w", srcPath, err)
+	}
+
+	for _, e := range entries {
+		if err := copyPath(src, src.Join(srcPath, e.Name()), e, dst, dst.Join(dstPath, e.Name()), progress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src FS, srcPath string, info os.FileInfo, dst FS, dstPath string, progress ProgressFunc) error {
+	r, err := src.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to open '%s': %w", srcPath, err)
+	}
+	defer func() {
+		if err := r.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", srcPath, err)
+		}
+	}()
+
+	w, err := dst.Create(dstPath, info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("failed to create '%s': %w", dstPath, err)
+	}
+
+	if _, err := io.Copy(w, &progressReader{Reader: r, progress: progress}); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("failed to copy '%s' to '%s': %w", srcPath, dstPath, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to write '%s': %w", dstPath, err)
+	}
+	return nil
+}
+
+// Size returns the number of bytes of the regular files in name
+func Size(fs FS, name string) (int64, error) {
+	info, err := fs.Stat(name)
+	if err != nil {
+		return 0, err
+	}
+	return size(fs, name, info)
+}
+
+func size(fs FS, name string, info os.FileInfo) (int64, error) {
+	if !info.IsDir() {
+		if info.Mode().IsRegular() {
+			return info.Size(), nil
+		}
+		return 0, nil
+	}
+
+	entries, err := fs.ReadDir(name)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, e := range entries {
+		n, err := size(fs, fs.Join(name, e.Name()), e)
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// progressReader reports the bytes read to a progress function
+type progressReader struct {
+	io.Reader
+	progress ProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 && r.progress != nil {
+		r.progress(int64(n))
+	}
+	return n, err
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cp
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTree creates a directory with nested files and returns its path
+func createTree(t *testing.T) string {
+	dir := filepath.Join(t.TempDir(), "src")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "run.sh"), []byte("echo world"), 0755))
+	return dir
+}
+
+func assertTree(t *testing.T, dir string) {
+	b, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+
+	b, err = os.ReadFile(filepath.Join(dir, "nested", "run.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, "echo world", string(b))
+
+	info, err := os.Stat(filepath.Join(dir, "nested", "run.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+}
+
+// newRemoteFS serves the local filesystem with an in-process SFTP server
+func newRemoteFS(t *testing.T) RemoteFS {
+	serverR, clientW := io.Pipe()
+	clientR, serverW := io.Pipe()
+
+	server, err := sftp.NewServer(struct {
+		io.Reader
+		io.WriteCloser
+	}{serverR, serverW})
+	require.NoError(t, err)
+	go func() {
+		_ = server.Serve()
+	}()
+
+	client, err := sftp.NewClientPipe(clientR, clientW)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = server.Close()
+		_ = client.Close()
+	})
+	return RemoteFS{Client: client}
+}
+
+func TestCopy(t *testing.T) {
+	var tests = []struct {
+		name string
+		src  FS
+		dst  FS
+	}{
+		{name: "local", src: LocalFS{}, dst: LocalFS{}},
+		{name: "upload", src: LocalFS{}, dst: newRemoteFS(t)},
+		{name: "download", src: newRemoteFS(t), dst: LocalFS{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := createTree(t)
+
+			var copied int64
+			progress := func(n int64) { copied += n }
+
+			// copied inside existing directories
+			dst := t.TempDir()
+			require.NoError(t, Copy(tt.src, src, tt.dst, dst, progress))
+			assertTree(t, filepath.Join(dst, "src"))
+			assert.Equal(t, int64(15), copied)
+
+			// copied with a different name otherwise
+			renamed := filepath.Join(t.TempDir(), "renamed")
+			require.NoError(t, Copy(tt.src, src, tt.dst, renamed, nil))
+			assertTree(t, renamed)
+
+			file := filepath.Join(t.TempDir(), "file.txt")
+			require.NoError(t, Copy(tt.src, filepath.Join(src, "a.txt"), tt.dst, file, nil))
+			b, err := os.ReadFile(file)
+			require.NoError(t, err)
+			assert.Equal(t, "hello", string(b))
+		})
+	}
+}
+
+func TestCopyNotFound(t *testing.T) {
+	err := Copy(LocalFS{}, filepath.Join(t.TempDir(), "missing"), LocalFS{}, t.TempDir(), nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSize(t *testing.T) {
+	src := createTree(t)
+
+	n, err := Size(LocalFS{}, src)
+	require.NoError(t, err)
+	assert.Equal(t, int64(15), n)
+
+	n, err = Size(newRemoteFS(t), filepath.Join(src, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cp
+
+import (
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/sftp"
+)
+
+// FS is a filesystem files are copied from or to
+type FS interface {
+	Stat(name string) (os.FileInfo, error)
+	ReadDir(name string) ([]os.FileInfo, error)
+	Open(name string) (io.ReadCloser, error)
+	Create(name string, perm os.FileMode) (io.WriteCloser, error)
+	MkdirAll(name string, perm os.FileMode) error
+	Join(elem ...string) string
+	Base(name string) string
+}
+
+// LocalFS is the filesystem of the local machine
+type LocalFS struct{}
+
+// Stat returns the file info of name
+func (LocalFS) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// ReadDir returns the file info of the entries of the directory name
+func (LocalFS) ReadDir(name string) ([]os.FileInfo, error) {
+	entries, err := os.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]os.FileInfo, 0, len(entries))
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// Open opens name for reading
+func (LocalFS) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
+// Create creates or truncates name
+func (LocalFS) Create(name string, perm os.FileMode) (io.WriteCloser, error) {
+	return os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+}
+
+// MkdirAll creates the directory name and its parents
+func (LocalFS) MkdirAll(name string, perm os.FileMode) error {
+	return os.MkdirAll(name, perm)
+}
+
+// Join joins the elements with the local path separator
+func (LocalFS) Join(elem ...string) string {
+	return filepath.Join(elem...)
+}
+
+// Base returns the last element of name
+func (LocalFS) Base(name string) string {
+	return filepath.Base(name)
+}
+
+// RemoteFS is the filesystem of a development container, accessed with SFTP
+type RemoteFS struct {
+	Client *sftp.Client
+}
+
+// Stat returns the file info of name
+func (r RemoteFS) Stat(name string) (os.FileInfo, error) {
+	return r.Client.Stat(name)
+}
+
+// ReadDir returns the file info of the entries of the directory name
+func (r RemoteFS) ReadDir(name string) ([]os.FileInfo, error) {
+	return r.Client.ReadDir(name)
+}
+
+// Open opens name for reading
+func (r RemoteFS) Open(name string) (io.ReadCloser, error) {
+	return r.Client.Open(name)
+}
+
+// Create creates or truncates name
+func (r RemoteFS) Create(name string, perm os.FileMode) (io.WriteCloser, error) {
+	f, err := r.Client.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Chmod(perm); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// MkdirAll creates the directory name and its parents
+func (r RemoteFS) MkdirAll(name string, perm os.FileMode) error {
+	if err := r.Client.MkdirAll(name); err != nil {
+		return err
+	}
+	return r.Client.Chmod(name, perm)
+}
+
+// Join joins the elements with slashes, development containers are always linux containers
+func (RemoteFS) Join(elem ...string) string {
+	return path.Join(elem...)
+}
+
+// Base returns the last element of name
+func (RemoteFS) Base(name string) string {
+	return path.Base(name)
+}
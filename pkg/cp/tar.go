@@ -0,0 +1,139 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cp
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// Tar writes a tar stream of the local srcPath to w, with its entries rooted at name
+func Tar(w io.Writer, srcPath, name string, progress ProgressFunc) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(srcPath, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			oktetoLog.Infof("skipping '%s': not a regular file", p)
+			return nil
+		}
+
+		rel, err := filepath.Rel(srcPath, p)
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(name, filepath.ToSlash(rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				oktetoLog.Debugf("Error closing file %s: %s", p, err)
+			}
+		}()
+		_, err = io.Copy(tw, &progressReader{Reader: f, progress: progress})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive '%s': %w", srcPath, err)
+	}
+	return tw.Close()
+}
+
+// Untar extracts the tar stream r into the local directory dir.
+// The first element of the entries is renamed from 'from' to 'to', to copy a file or a directory with a different name
+func Untar(r io.Reader, dir, from, to string, progress ProgressFunc) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		name, err := renameEntry(hdr.Name, from, to)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, hdr.FileInfo().Mode().Perm()); err != nil {
+				return fmt.Errorf("failed to create directory '%s': %w", target, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return fmt.Errorf("failed to create directory '%s': %w", filepath.Dir(target), err)
+			}
+			if err := untarFile(tr, target, hdr.FileInfo().Mode().Perm(), progress); err != nil {
+				return err
+			}
+		default:
+			oktetoLog.Infof("skipping '%s': not a regular file", hdr.Name)
+		}
+	}
+}
+
+func untarFile(r io.Reader, target string, perm os.FileMode, progress ProgressFunc) error {
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create '%s': %w", target, err)
+	}
+	if _, err := io.Copy(f, &progressReader{Reader: r, progress: progress}); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write '%s': %w", target, err)
+	}
+	return f.Close()
+}
+
+// renameEntry replaces the first element of name and rejects the names that escape the destination directory
+func renameEntry(name, from, to string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(name, "./"))
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid archive entry '%s'", name)
+	}
+
+	first, rest, _ := strings.Cut(cleaned, "/")
+	if first != from {
+		return "", fmt.Errorf("unexpected archive entry '%s'", name)
+	}
+	return path.Join(to, rest), nil
+}
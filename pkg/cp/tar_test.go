@@ -0,0 +1,84 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cp
+
+import (
+	"archive/tar"
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTarUntar(t *testing.T) {
+	src := createTree(t)
+
+	buf := &bytes.Buffer{}
+	var archived int64
+	require.NoError(t, Tar(buf, src, "src", func(n int64) { archived += n }))
+	assert.Equal(t, int64(15), archived)
+
+	dst := t.TempDir()
+	var extracted int64
+	require.NoError(t, Untar(bytes.NewReader(buf.Bytes()), dst, "src", "renamed", func(n int64) { extracted += n }))
+	assert.Equal(t, int64(15), extracted)
+	assertTree(t, filepath.Join(dst, "renamed"))
+}
+
+func TestUntarInvalidEntries(t *testing.T) {
+	var tests = []struct {
+		name  string
+		entry string
+	}{
+		{name: "parent", entry: "../evil"},
+		{name: "nested-parent", entry: "src/../../evil"},
+		{name: "absolute", entry: "/etc/evil"},
+		{name: "unexpected", entry: "other/file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			tw := tar.NewWriter(buf)
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: tt.entry, Typeflag: tar.TypeReg, Mode: 0644, Size: 4}))
+			_, err := tw.Write([]byte("evil"))
+			require.NoError(t, err)
+			require.NoError(t, tw.Close())
+
+			assert.Error(t, Untar(buf, t.TempDir(), "src", "src", nil))
+		})
+	}
+}
+
+func TestRenameEntry(t *testing.T) {
+	var tests = []struct {
+		name     string
+		entry    string
+		expected string
+	}{
+		{name: "root", entry: "src/", expected: "dst"},
+		{name: "nested", entry: "src/nested/a.txt", expected: "dst/nested/a.txt"},
+		{name: "dot-prefix", entry: "./src/a.txt", expected: "dst/a.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := renameEntry(tt.entry, "src", "dst")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDevContainerPath(t *testing.T) {
+	var tests = []struct {
+		name     string
+		files    []string
+		expected string
+	}{
+		{
+			name:     "devcontainer folder",
+			files:    []string{".devcontainer.json", filepath.Join(".devcontainer", "devcontainer.json")},
+			expected: filepath.Join(".devcontainer", "devcontainer.json"),
+		},
+		{
+			name:     "devcontainer file",
+			files:    []string{".devcontainer.json"},
+			expected: ".devcontainer.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wd := t.TempDir()
+			for _, file := range tt.files {
+				path := filepath.Join(wd, file)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+				require.NoError(t, os.WriteFile(path, []byte("{}"), 0600))
+			}
+			result, err := GetDevContainerPath(wd)
+			assert.NoError(t, err)
+			assert.Equal(t, filepath.Join(wd, tt.expected), result)
+		})
+	}
+}
+
+func TestGetDevContainerPathWhenNotExists(t *testing.T) {
+	result, err := GetDevContainerPath(t.TempDir())
+	assert.Empty(t, result)
+	assert.ErrorIs(t, err, ErrDevContainerNotFound)
+}
@@ -36,4 +36,14 @@ var (
 	ErrHelmChartNotFound = errors.New("could not detect any helm chart")
 	// ErrK8sManifestNotFound is raised when discovery package could not found any k8s manifest
 	ErrK8sManifestNotFound = errors.New("could not detect any k8s manifest")
+	// ErrDevContainerNotFound is raised when discovery package could not found any devcontainer.json file
+	ErrDevContainerNotFound = oktetoErrors.UserError{
+		E:    errors.New("could not detect any devcontainer.json file"),
+		Hint: "If you have a devcontainer.json file, use the flag '--from-file' to point to it",
+	}
+	// ErrSkaffoldNotFound is raised when discovery package could not found any skaffold file
+	ErrSkaffoldNotFound = oktetoErrors.UserError{
+		E:    errors.New("could not detect any skaffold file"),
+		Hint: "If you have a skaffold file, use the flag '--from-file' to point to it",
+	}
 )
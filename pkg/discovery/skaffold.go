@@ -0,0 +1,39 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/filesystem"
+)
+
+var (
+	// possibleSkaffoldFiles represents the possible names of a skaffold file
+	possibleSkaffoldFiles = []string{
+		"skaffold.yaml",
+		"skaffold.yml",
+	}
+)
+
+// GetSkaffoldPath returns the skaffold file of the folder if exists, error otherwise
+func GetSkaffoldPath(wd string) (string, error) {
+	for _, possibleSkaffoldFile := range possibleSkaffoldFiles {
+		path := filepath.Join(wd, possibleSkaffoldFile)
+		if filesystem.FileExists(path) {
+			return path, nil
+		}
+	}
+	return "", ErrSkaffoldNotFound
+}
@@ -0,0 +1,38 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSkaffoldPath(t *testing.T) {
+	wd := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(wd, "skaffold.yml"), []byte("apiVersion: skaffold/v4beta6"), 0600))
+
+	result, err := GetSkaffoldPath(wd)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(wd, "skaffold.yml"), result)
+}
+
+func TestGetSkaffoldPathWhenNotExists(t *testing.T) {
+	result, err := GetSkaffoldPath(t.TempDir())
+	assert.Empty(t, result)
+	assert.ErrorIs(t, err, ErrSkaffoldNotFound)
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/okteto/okteto/pkg/constants"
+	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
+	istioV1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	istioclientset "istio.io/client-go/pkg/clientset/versioned"
+)
+
+const devCloneRouteTemplate = "okteto-dev-%s"
+
+// DevCloneBaggageValue returns the value of the baggage member "okteto-divert" that routes the requests to a side-by-side dev container.
+// It is specific to the dev container, since the divert driver adds "okteto-divert=<namespace>" to every request entering the namespace
+func DevCloneBaggageValue(name, namespace string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// DivertDevClone adds header based routes to the virtual services of a namespace to send the requests
+// including the baggage "okteto-divert=<namespace>/<name>" to the services of a side-by-side dev container
+func DivertDevClone(ctx context.Context, name, namespace string, services map[string]string, ic istioclientset.Interface) error {
+	_, err := UpdateVirtualServices(ctx, namespace, ic, func(vs *istioV1beta1.VirtualService) *istioV1beta1.VirtualService {
+		return translateDevCloneRoutes(vs, name, services)
+	})
+	return err
+}
+
+// RestoreDevClone removes the header based routes added by DivertDevClone
+func RestoreDevClone(ctx context.Context, name, namespace string, ic istioclientset.Interface) error {
+	_, err := UpdateVirtualServices(ctx, namespace, ic, func(vs *istioV1beta1.VirtualService) *istioV1beta1.VirtualService {
+		return restoreDevCloneRoutes(vs, name)
+	})
+	return err
+}
+
+// translateDevCloneRoutes returns nil if the virtual service doesn't route traffic to any of the services
+func translateDevCloneRoutes(vs *istioV1beta1.VirtualService, name string, services map[string]string) *istioV1beta1.VirtualService {
+	result := restoreDevCloneRoutes(vs, name)
+	if result == nil {
+		result = vs.DeepCopy()
+	}
+	routeName := fmt.Sprintf(devCloneRouteTemplate, name)
+	devRoutes := []*istioNetworkingV1beta1.HTTPRoute{}
+	for _, httpRoute := range result.Spec.Http {
+		devRoute := httpRoute.DeepCopy()
+		diverted := false
+		for _, destination := range devRoute.Route {
+			if destination.Destination == nil {
+				continue
+			}
+			service, namespace := getServiceHost(destination.Destination.Host, vs.Namespace)
+			if namespace != vs.Namespace {
+				continue
+			}
+			clone, ok := services[service]
+			if !ok {
+				continue
+			}
+			destination.Destination.Host = clone
+			diverted = true
+		}
+		if !diverted {
+			continue
+		}
+		devRoute.Name = routeName
+		devRoute.Match = addDivertBaggageMatch(devRoute.Match, DevCloneBaggageValue(name, vs.Namespace))
+		devRoutes = append(devRoutes, devRoute)
+	}
+	if len(devRoutes) == 0 {
+		return nil
+	}
+	result.Spec.Http = append(devRoutes, result.Spec.Http...)
+	return result
+}
+
+// restoreDevCloneRoutes returns nil if the virtual service doesn't include routes to the dev container
+func restoreDevCloneRoutes(vs *istioV1beta1.VirtualService, name string) *istioV1beta1.VirtualService {
+	routeName := fmt.Sprintf(devCloneRouteTemplate, name)
+	result := vs.DeepCopy()
+	result.Spec.Http = []*istioNetworkingV1beta1.HTTPRoute{}
+	for _, httpRoute := range vs.Spec.Http {
+		if httpRoute.Name == routeName {
+			continue
+		}
+		result.Spec.Http = append(result.Spec.Http, httpRoute.DeepCopy())
+	}
+	if len(result.Spec.Http) == len(vs.Spec.Http) {
+		return nil
+	}
+	return result
+}
+
+// addDivertBaggageMatch makes matches require the baggage member "okteto-divert" with value
+func addDivertBaggageMatch(matches []*istioNetworkingV1beta1.HTTPMatchRequest, value string) []*istioNetworkingV1beta1.HTTPMatchRequest {
+	if len(matches) == 0 {
+		matches = []*istioNetworkingV1beta1.HTTPMatchRequest{{}}
+	}
+	member := regexp.QuoteMeta(fmt.Sprintf("%s=%s", constants.OktetoDivertHeaderName, value))
+	for _, match := range matches {
+		if match.Headers == nil {
+			match.Headers = map[string]*istioNetworkingV1beta1.StringMatch{}
+		}
+		match.Headers[constants.OktetoDivertBaggageHeader] = &istioNetworkingV1beta1.StringMatch{
+			MatchType: &istioNetworkingV1beta1.StringMatch_Regex{Regex: fmt.Sprintf(`^(.*,)?\s*%s\s*([;,].*)?$`, member)},
+		}
+	}
+	return matches
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
+	istioV1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_translateDevCloneRoutes(t *testing.T) {
+	vs := &istioV1beta1.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "cindy"},
+		Spec: istioNetworkingV1beta1.VirtualService{
+			Http: []*istioNetworkingV1beta1.HTTPRoute{
+				{
+					Name: "api",
+					Match: []*istioNetworkingV1beta1.HTTPMatchRequest{
+						{Uri: &istioNetworkingV1beta1.StringMatch{MatchType: &istioNetworkingV1beta1.StringMatch_Prefix{Prefix: "/api"}}},
+					},
+					Route: []*istioNetworkingV1beta1.HTTPRouteDestination{
+						{Destination: &istioNetworkingV1beta1.Destination{Host: "api.cindy.svc.cluster.local"}},
+					},
+				},
+				{
+					Name: "frontend",
+					Route: []*istioNetworkingV1beta1.HTTPRouteDestination{
+						{Destination: &istioNetworkingV1beta1.Destination{Host: "frontend"}},
+					},
+				},
+			},
+		},
+	}
+
+	result := translateDevCloneRoutes(vs, "api", map[string]string{"api": "api-okteto"})
+	require.NotNil(t, result)
+	require.Len(t, result.Spec.Http, 3)
+
+	devRoute := result.Spec.Http[0]
+	assert.Equal(t, "okteto-dev-api", devRoute.Name)
+	assert.Equal(t, "api-okteto", devRoute.Route[0].Destination.Host)
+	require.Len(t, devRoute.Match, 1)
+	assert.Equal(t, "/api", devRoute.Match[0].Uri.GetPrefix())
+	baggage := regexp.MustCompile(devRoute.Match[0].Headers[constants.OktetoDivertBaggageHeader].GetRegex())
+	assert.True(t, baggage.MatchString("okteto-divert=cindy/api"))
+	assert.True(t, baggage.MatchString("okteto-divert=cindy, okteto-divert=cindy/api"))
+	assert.True(t, baggage.MatchString("userId=alice, okteto-divert=cindy/api;ttl=60, isProduction=false"))
+	// the baggage the divert driver adds to every request of the namespace doesn't reach the dev container
+	assert.False(t, baggage.MatchString("okteto-divert=cindy"))
+	assert.False(t, baggage.MatchString("okteto-divert=cindy/api-2"))
+	assert.False(t, baggage.MatchString("x-okteto-divert=cindy/api"))
+
+	assert.Equal(t, "api", result.Spec.Http[1].Name)
+	assert.Equal(t, "api.cindy.svc.cluster.local", result.Spec.Http[1].Route[0].Destination.Host)
+	assert.Nil(t, result.Spec.Http[1].Match[0].Headers)
+	assert.Equal(t, "frontend", result.Spec.Http[2].Name)
+
+	// translating twice doesn't duplicate routes
+	again := translateDevCloneRoutes(result, "api", map[string]string{"api": "api-okteto"})
+	require.NotNil(t, again)
+	assert.Len(t, again.Spec.Http, 3)
+
+	restored := restoreDevCloneRoutes(result, "api")
+	require.NotNil(t, restored)
+	require.Len(t, restored.Spec.Http, 2)
+	assert.Equal(t, "api", restored.Spec.Http[0].Name)
+	assert.Equal(t, "frontend", restored.Spec.Http[1].Name)
+
+	assert.Nil(t, restoreDevCloneRoutes(restored, "api"))
+	assert.Nil(t, translateDevCloneRoutes(vs, "api", map[string]string{"db": "db-okteto"}))
+}
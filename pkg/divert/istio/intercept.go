@@ -0,0 +1,131 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
+	istioV1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+)
+
+const interceptRouteTemplate = "okteto-intercept-%s"
+
+// InterceptRoute defines the requests of a service sent to the destination of an intercept
+type InterceptRoute struct {
+	Service     string
+	Namespace   string
+	Headers     map[string]string
+	Destination string
+	Port        uint32
+}
+
+func (r *InterceptRoute) name() string {
+	return fmt.Sprintf(interceptRouteTemplate, r.Service)
+}
+
+// isServiceHost returns true if host is a hostname of the service of the route in the namespace of vs
+func (r *InterceptRoute) isServiceHost(host, vsNamespace string) bool {
+	service, namespace := getServiceHost(host, vsNamespace)
+	return service == r.Service && namespace == r.Namespace
+}
+
+// TranslateInterceptVirtualService returns a copy of vs that sends the requests to the service of the route with
+// its headers to the destination of the route, or nil if vs doesn't route requests to the service
+func TranslateInterceptVirtualService(vs *istioV1beta1.VirtualService, route *InterceptRoute) *istioV1beta1.VirtualService {
+	result := vs.DeepCopy()
+	removeInterceptRoute(&result.Spec, route.name())
+
+	matches := []*istioNetworkingV1beta1.HTTPMatchRequest{}
+	found := false
+	for _, httpRoute := range result.Spec.Http {
+		routesService := false
+		for _, dst := range httpRoute.Route {
+			if dst.Destination != nil && route.isServiceHost(dst.Destination.Host, vs.Namespace) {
+				routesService = true
+				break
+			}
+		}
+		if !routesService {
+			continue
+		}
+		found = true
+		if len(httpRoute.Match) == 0 {
+			matches = append(matches, &istioNetworkingV1beta1.HTTPMatchRequest{})
+			continue
+		}
+		for _, m := range httpRoute.Match {
+			matches = append(matches, m.DeepCopy())
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	headerNames := make([]string, 0, len(route.Headers))
+	for name := range route.Headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	for _, m := range matches {
+		if m.Headers == nil {
+			m.Headers = map[string]*istioNetworkingV1beta1.StringMatch{}
+		}
+		for _, name := range headerNames {
+			m.Headers[name] = &istioNetworkingV1beta1.StringMatch{
+				MatchType: &istioNetworkingV1beta1.StringMatch_Exact{Exact: route.Headers[name]},
+			}
+		}
+	}
+
+	interceptRoute := &istioNetworkingV1beta1.HTTPRoute{
+		Name:  route.name(),
+		Match: matches,
+		Route: []*istioNetworkingV1beta1.HTTPRouteDestination{
+			{
+				Destination: &istioNetworkingV1beta1.Destination{
+					Host: route.Destination,
+					Port: &istioNetworkingV1beta1.PortSelector{Number: route.Port},
+				},
+			},
+		},
+	}
+	result.Spec.Http = append([]*istioNetworkingV1beta1.HTTPRoute{interceptRoute}, result.Spec.Http...)
+	return result
+}
+
+// RestoreInterceptVirtualService returns a copy of vs without the intercept route of service, or nil if vs doesn't have it
+func RestoreInterceptVirtualService(vs *istioV1beta1.VirtualService, service string) *istioV1beta1.VirtualService {
+	result := vs.DeepCopy()
+	if !removeInterceptRoute(&result.Spec, fmt.Sprintf(interceptRouteTemplate, service)) {
+		return nil
+	}
+	return result
+}
+
+func removeInterceptRoute(spec *istioNetworkingV1beta1.VirtualService, name string) bool {
+	removed := false
+	routes := make([]*istioNetworkingV1beta1.HTTPRoute, 0, len(spec.Http))
+	for _, httpRoute := range spec.Http {
+		if strings.EqualFold(httpRoute.Name, name) {
+			removed = true
+			continue
+		}
+		routes = append(routes, httpRoute)
+	}
+	spec.Http = routes
+	return removed
+}
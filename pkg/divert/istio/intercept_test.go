@@ -0,0 +1,105 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
+	istioV1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newInterceptTestVirtualService() *istioV1beta1.VirtualService {
+	return &istioV1beta1.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "staging"},
+		Spec: istioNetworkingV1beta1.VirtualService{
+			Http: []*istioNetworkingV1beta1.HTTPRoute{
+				{
+					Name: "api",
+					Match: []*istioNetworkingV1beta1.HTTPMatchRequest{
+						{Uri: &istioNetworkingV1beta1.StringMatch{MatchType: &istioNetworkingV1beta1.StringMatch_Prefix{Prefix: "/api"}}},
+					},
+					Route: []*istioNetworkingV1beta1.HTTPRouteDestination{
+						{Destination: &istioNetworkingV1beta1.Destination{Host: "api"}},
+					},
+				},
+				{
+					Name: "web",
+					Route: []*istioNetworkingV1beta1.HTTPRouteDestination{
+						{Destination: &istioNetworkingV1beta1.Destination{Host: "web.staging.svc.cluster.local"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTranslateInterceptVirtualService(t *testing.T) {
+	route := &InterceptRoute{
+		Service:     "api",
+		Namespace:   "staging",
+		Headers:     map[string]string{"x-user": "alice"},
+		Destination: "api-okteto-intercept.staging.svc.cluster.local",
+		Port:        80,
+	}
+
+	vs := newInterceptTestVirtualService()
+	result := TranslateInterceptVirtualService(vs, route)
+	require.NotNil(t, result)
+	require.Len(t, result.Spec.Http, 3)
+	assert.Len(t, vs.Spec.Http, 2)
+
+	intercept := result.Spec.Http[0]
+	assert.Equal(t, "okteto-intercept-api", intercept.Name)
+	require.Len(t, intercept.Match, 1)
+	assert.Equal(t, "/api", intercept.Match[0].Uri.GetPrefix())
+	assert.Equal(t, "alice", intercept.Match[0].Headers["x-user"].GetExact())
+	assert.Nil(t, vs.Spec.Http[0].Match[0].Headers)
+	assert.Equal(t, route.Destination, intercept.Route[0].Destination.Host)
+	assert.Equal(t, uint32(80), intercept.Route[0].Destination.Port.Number)
+
+	again := TranslateInterceptVirtualService(result, route)
+	require.NotNil(t, again)
+	assert.Len(t, again.Spec.Http, 3)
+
+	restored := RestoreInterceptVirtualService(again, "api")
+	require.NotNil(t, restored)
+	assert.Equal(t, vs.Spec.Http, restored.Spec.Http)
+
+	assert.Nil(t, RestoreInterceptVirtualService(vs, "api"))
+}
+
+func TestTranslateInterceptVirtualServiceNotRouted(t *testing.T) {
+	tests := []struct {
+		name  string
+		route *InterceptRoute
+	}{
+		{
+			name:  "unknown-service",
+			route: &InterceptRoute{Service: "worker", Namespace: "staging"},
+		},
+		{
+			name:  "short-name-of-other-namespace",
+			route: &InterceptRoute{Service: "api", Namespace: "other"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Nil(t, TranslateInterceptVirtualService(newInterceptTestVirtualService(), tt.route))
+		})
+	}
+}
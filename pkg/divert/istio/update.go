@@ -0,0 +1,82 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/k8s/virtualservices"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	istioV1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	istioclientset "istio.io/client-go/pkg/clientset/versioned"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// VirtualServiceTranslator returns a modified copy of a virtual service, or nil if it doesn't have to be updated
+type VirtualServiceTranslator func(vs *istioV1beta1.VirtualService) *istioV1beta1.VirtualService
+
+// UpdateVirtualServices applies translate to the virtual services of a namespace, retrying on conflicts.
+// It returns the names of the updated virtual services
+func UpdateVirtualServices(ctx context.Context, namespace string, ic istioclientset.Interface, translate VirtualServiceTranslator) ([]string, error) {
+	vsList, err := virtualservices.List(ctx, namespace, ic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list virtual services: %w", err)
+	}
+	updated := []string{}
+	for _, vs := range vsList {
+		var updateErr error
+		for retries := 0; retries < UPDATE_CONFLICT_RETRIES; retries++ {
+			translatedVS := translate(vs)
+			if translatedVS == nil {
+				updateErr = nil
+				break
+			}
+			updateErr = virtualservices.Update(ctx, translatedVS, ic)
+			if updateErr == nil {
+				oktetoLog.Infof("virtual service '%s/%s' updated", vs.Namespace, vs.Name)
+				updated = append(updated, vs.Name)
+				break
+			}
+			if !k8sErrors.IsConflict(updateErr) {
+				return updated, updateErr
+			}
+			vs, err = virtualservices.Get(ctx, vs.Name, vs.Namespace, ic)
+			if err != nil {
+				return updated, err
+			}
+		}
+		// the conflict of the last retry
+		if updateErr != nil {
+			return updated, fmt.Errorf("failed to update virtual service '%s/%s': %w", vs.Namespace, vs.Name, updateErr)
+		}
+	}
+	return updated, nil
+}
+
+// getServiceHost returns the name and the namespace of the service of a destination host of a virtual service of vsNamespace,
+// or empty strings if the host isn't the hostname of a service
+func getServiceHost(host, vsNamespace string) (string, string) {
+	host = strings.TrimSuffix(host, ".svc.cluster.local")
+	host = strings.TrimSuffix(host, ".svc")
+	parts := strings.Split(host, ".")
+	switch len(parts) {
+	case 1:
+		return parts[0], vsNamespace
+	case 2:
+		return parts[0], parts[1]
+	}
+	return "", ""
+}
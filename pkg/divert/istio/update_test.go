@@ -0,0 +1,87 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istioV1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"istio.io/client-go/pkg/clientset/versioned/fake"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func TestUpdateVirtualServices(t *testing.T) {
+	ctx := context.Background()
+	vs := newInterceptTestVirtualService()
+	other := &istioV1beta1.VirtualService{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "staging"}}
+	ic := fake.NewSimpleClientset(vs, other)
+
+	updated, err := UpdateVirtualServices(ctx, "staging", ic, func(vs *istioV1beta1.VirtualService) *istioV1beta1.VirtualService {
+		if vs.Name != "frontend" {
+			return nil
+		}
+		result := vs.DeepCopy()
+		result.Labels = map[string]string{"updated": "true"}
+		return result
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend"}, updated)
+
+	result, err := ic.NetworkingV1beta1().VirtualServices("staging").Get(ctx, "frontend", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "true", result.Labels["updated"])
+}
+
+func TestUpdateVirtualServicesConflicts(t *testing.T) {
+	ctx := context.Background()
+	ic := fake.NewSimpleClientset(newInterceptTestVirtualService())
+	ic.PrependReactor("update", "virtualservices", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, k8sErrors.NewConflict(schema.GroupResource{Resource: "virtualservices"}, "frontend", nil)
+	})
+
+	updated, err := UpdateVirtualServices(ctx, "staging", ic, func(vs *istioV1beta1.VirtualService) *istioV1beta1.VirtualService {
+		return vs.DeepCopy()
+	})
+	require.Error(t, err)
+	assert.True(t, k8sErrors.IsConflict(err))
+	assert.Empty(t, updated)
+}
+
+func TestGetServiceHost(t *testing.T) {
+	var tests = []struct {
+		host              string
+		expectedService   string
+		expectedNamespace string
+	}{
+		{host: "api", expectedService: "api", expectedNamespace: "staging"},
+		{host: "api.other", expectedService: "api", expectedNamespace: "other"},
+		{host: "api.other.svc", expectedService: "api", expectedNamespace: "other"},
+		{host: "api.other.svc.cluster.local", expectedService: "api", expectedNamespace: "other"},
+		{host: "api.okteto.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			service, namespace := getServiceHost(tt.host, "staging")
+			assert.Equal(t, tt.expectedService, service)
+			assert.Equal(t, tt.expectedNamespace, namespace)
+		})
+	}
+}
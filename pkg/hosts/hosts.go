@@ -0,0 +1,163 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hosts manages a block of entries generated by okteto in the hosts file of the operating system.
+// Writing the hosts file of the operating system requires root or administrator privileges
+package hosts
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/textblock"
+)
+
+// File is a block of entries of a hosts file, delimited by markers that identify its owner
+type File struct {
+	path  string
+	block *textblock.TextBlock
+	start string
+	end   string
+}
+
+// New returns the block of entries of id in the hosts file of the operating system
+func New(id string) *File {
+	return NewWithPath(GetPath(), id)
+}
+
+// NewWithPath returns the block of entries of id in the hosts file at path
+func NewWithPath(path, id string) *File {
+	start := fmt.Sprintf("# ---- BEGIN OKTETO %s ----", id)
+	end := fmt.Sprintf("# ---- END OKTETO %s ----", id)
+	return &File{
+		path:  path,
+		block: textblock.NewTextBlock(start, end),
+		start: start,
+		end:   end,
+	}
+}
+
+// GetPath returns the path of the hosts file of the operating system
+func GetPath() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("SystemRoot"), "System32", "drivers", "etc", "hosts")
+	}
+	return "/etc/hosts"
+}
+
+// Path returns the path of the hosts file
+func (f *File) Path() string {
+	return f.path
+}
+
+// Write replaces the block with entries resolving each hostname to its ip
+func (f *File) Write(entries map[string]string) error {
+	content, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	content, err = f.removeBlock(content)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) > 0 {
+		hostnames := make([]string, 0, len(entries))
+		for hostname := range entries {
+			hostnames = append(hostnames, hostname)
+		}
+		sort.Strings(hostnames)
+
+		lines := make([]string, 0, len(hostnames))
+		for _, hostname := range hostnames {
+			lines = append(lines, fmt.Sprintf("%s %s", entries[hostname], hostname))
+		}
+
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += f.block.WriteBlock(strings.Join(lines, "\n")) + "\n"
+	}
+
+	return f.write(content)
+}
+
+// Remove deletes the block from the hosts file
+func (f *File) Remove() error {
+	content, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	updated, err := f.removeBlock(content)
+	if err != nil {
+		return err
+	}
+	if updated == content {
+		return nil
+	}
+	return f.write(updated)
+}
+
+func (f *File) read() (string, error) {
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read hosts file %s: %w", f.path, err)
+	}
+	return string(b), nil
+}
+
+func (f *File) write(content string) error {
+	info, err := os.Stat(f.path)
+	mode := os.FileMode(0644)
+	if err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(f.path, []byte(content), mode); err != nil {
+		return fmt.Errorf("failed to update hosts file %s: %w", f.path, err)
+	}
+	oktetoLog.Infof("updated hosts file %s", f.path)
+	return nil
+}
+
+// removeBlock returns content without the lines of the block. Malformed blocks are not modified
+func (f *File) removeBlock(content string) (string, error) {
+	if _, err := f.block.FindBlocks(content); err != nil {
+		return "", fmt.Errorf("hosts file %s has a malformed okteto block: %w", f.path, err)
+	}
+
+	lines := strings.Split(content, "\n")
+	result := make([]string, 0, len(lines))
+	inBlock := false
+	for _, l := range lines {
+		switch {
+		case l == f.start:
+			inBlock = true
+		case l == f.end:
+			inBlock = false
+		case !inBlock:
+			result = append(result, l)
+		}
+	}
+	return strings.Join(result, "\n"), nil
+}
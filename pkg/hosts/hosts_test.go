@@ -0,0 +1,86 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hosts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const initialHosts = "127.0.0.1 localhost\n::1 localhost\n"
+
+func TestWriteAndRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts")
+	require.NoError(t, os.WriteFile(path, []byte(initialHosts), 0600))
+
+	f := NewWithPath(path, "ns/api")
+	require.NoError(t, f.Write(map[string]string{"redis": "127.0.0.1", "db.ns.svc.cluster.local": "127.0.0.1"}))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	expected := initialHosts +
+		"# ---- BEGIN OKTETO ns/api ----\n" +
+		"127.0.0.1 db.ns.svc.cluster.local\n" +
+		"127.0.0.1 redis\n" +
+		"# ---- END OKTETO ns/api ----\n"
+	assert.Equal(t, expected, string(b))
+
+	require.NoError(t, f.Write(map[string]string{"redis": "127.0.0.1"}))
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	expected = initialHosts +
+		"# ---- BEGIN OKTETO ns/api ----\n" +
+		"127.0.0.1 redis\n" +
+		"# ---- END OKTETO ns/api ----\n"
+	assert.Equal(t, expected, string(b))
+
+	other := NewWithPath(path, "ns/worker")
+	require.NoError(t, other.Write(map[string]string{"queue": "127.0.0.1"}))
+
+	require.NoError(t, f.Remove())
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	expected = initialHosts +
+		"# ---- BEGIN OKTETO ns/worker ----\n" +
+		"127.0.0.1 queue\n" +
+		"# ---- END OKTETO ns/worker ----\n"
+	assert.Equal(t, expected, string(b))
+
+	require.NoError(t, other.Remove())
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, initialHosts, string(b))
+}
+
+func TestWriteMalformedBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts")
+	malformed := initialHosts + "# ---- BEGIN OKTETO ns/api ----\n127.0.0.1 redis\n"
+	require.NoError(t, os.WriteFile(path, []byte(malformed), 0600))
+
+	f := NewWithPath(path, "ns/api")
+	assert.Error(t, f.Write(map[string]string{"redis": "127.0.0.1"}))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, malformed, string(b))
+}
+
+func TestRemoveMissingFile(t *testing.T) {
+	f := NewWithPath(filepath.Join(t.TempDir(), "hosts"), "ns/api")
+	assert.NoError(t, f.Remove())
+}
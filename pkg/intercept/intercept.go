@@ -0,0 +1,508 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package intercept sends the traffic of a service of the cluster to a local process.
+// An agent pod running the okteto SSH server receives the traffic, and a reverse forward tunnels it to localhost
+package intercept
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/services"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// InterceptLabel is the label of the resources created to intercept a service
+	InterceptLabel = "intercept.okteto.com/service"
+
+	// ReplicasAnnotation keeps the replicas of a workload swapped by an intercept
+	ReplicasAnnotation = "intercept.okteto.com/replicas"
+
+	// SSHPort is the port of the SSH server of the agent
+	SSHPort = 2222
+
+	agentSuffix        = "okteto-intercept"
+	authorizedKeysFile = "authorized_keys"
+	agentContainerName = "okteto-intercept"
+	agentVolumeName    = "okteto-intercept-keys"
+	remoteBinaryPath   = "/usr/local/bin/remote"
+)
+
+// Intercept defines how the traffic of a service is sent to a local port
+type Intercept struct {
+	Service   string
+	Namespace string
+	// LocalPort is the local port receiving the traffic
+	LocalPort int
+	// Port is the port of the service to intercept
+	Port int
+	// Headers only intercepts the requests with these headers, the rest of the traffic keeps going to the service
+	Headers map[string]string
+
+	// TargetPort is the port of the pods of the service, where the agent listens
+	TargetPort int
+	// TargetPortName is the name of the target port, if the service references it by name
+	TargetPortName string
+	selector       map[string]string
+}
+
+// ParsePort parses '<local>:<remote>' or '<port>' as the local port and the port of the service
+func ParsePort(value string) (int, int, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) > 2 {
+		return 0, 0, fmt.Errorf("invalid port '%s': must be '<local>:<remote>'", value)
+	}
+	ports := make([]int, 0, len(parts))
+	for _, p := range parts {
+		port, err := strconv.Atoi(p)
+		if err != nil || port < 1 || port > 65535 {
+			return 0, 0, fmt.Errorf("invalid port '%s': ports must be numbers between 1 and 65535", value)
+		}
+		ports = append(ports, port)
+	}
+	if len(ports) == 1 {
+		return ports[0], ports[0], nil
+	}
+	return ports[0], ports[1], nil
+}
+
+// ParseHeaders parses a list of '<name>=<value>' headers
+func ParseHeaders(values []string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, v := range values {
+		name, value, found := strings.Cut(v, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid header '%s': must be '<name>=<value>'", v)
+		}
+		result[strings.ToLower(name)] = strings.TrimSpace(value)
+	}
+	return result, nil
+}
+
+// AgentName returns the name of the resources created to intercept a service
+func AgentName(service string) string {
+	return fmt.Sprintf("%s-%s", service, agentSuffix)
+}
+
+// IsHeaderBased returns true if only the requests with some headers are intercepted
+func (i *Intercept) IsHeaderBased() bool {
+	return len(i.Headers) > 0
+}
+
+// Load resolves the selector and the target port of the service
+func (i *Intercept) Load(ctx context.Context, c kubernetes.Interface) error {
+	svc, err := services.Get(ctx, i.Service, i.Namespace, c)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("service '%s' not found in namespace '%s'", i.Service, i.Namespace),
+				Hint: "Run 'kubectl get services' to list the services of your namespace",
+			}
+		}
+		return err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return fmt.Errorf("service '%s' doesn't have a selector", i.Service)
+	}
+	i.selector = svc.Spec.Selector
+
+	for _, p := range svc.Spec.Ports {
+		if int(p.Port) != i.Port {
+			continue
+		}
+		if p.Protocol != "" && p.Protocol != apiv1.ProtocolTCP {
+			return fmt.Errorf("port %d of service '%s' is not a TCP port", i.Port, i.Service)
+		}
+		switch {
+		case p.TargetPort.Type == intstr.String:
+			i.TargetPortName = p.TargetPort.StrVal
+			i.TargetPort, err = i.getNamedPort(ctx, c, p.TargetPort.StrVal)
+			if err != nil {
+				return err
+			}
+		case p.TargetPort.IntVal != 0:
+			i.TargetPort = int(p.TargetPort.IntVal)
+		default:
+			i.TargetPort = int(p.Port)
+		}
+		if i.TargetPort == SSHPort {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("port %d of service '%s' can't be intercepted: its target port %d is the port of the SSH server of the intercept agent", i.Port, i.Service, SSHPort),
+				Hint: "Intercept another port of the service",
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("service '%s' doesn't expose port %d", i.Service, i.Port)
+}
+
+// getNamedPort returns the port named name in the workloads of the service
+func (i *Intercept) getNamedPort(ctx context.Context, c kubernetes.Interface, name string) (int, error) {
+	dList, sfsList, err := i.getWorkloads(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	specs := []apiv1.PodSpec{}
+	for _, d := range dList {
+		specs = append(specs, d.Spec.Template.Spec)
+	}
+	for _, sfs := range sfsList {
+		specs = append(specs, sfs.Spec.Template.Spec)
+	}
+	for _, spec := range specs {
+		for _, container := range spec.Containers {
+			for _, p := range container.Ports {
+				if p.Name == name {
+					return int(p.ContainerPort), nil
+				}
+			}
+		}
+	}
+	return 0, fmt.Errorf("the workloads of service '%s' don't have a port named '%s'", i.Service, name)
+}
+
+// getWorkloads returns the deployments and statefulsets with pods selected by the service, except the agent
+func (i *Intercept) getWorkloads(ctx context.Context, c kubernetes.Interface) ([]appsv1.Deployment, []appsv1.StatefulSet, error) {
+	selector := labels.SelectorFromSet(i.selector)
+
+	dList, err := deployments.List(ctx, i.Namespace, "", c)
+	if err != nil {
+		return nil, nil, err
+	}
+	resultD := []appsv1.Deployment{}
+	for _, d := range dList {
+		if d.Labels[InterceptLabel] != "" {
+			continue
+		}
+		if selector.Matches(labels.Set(d.Spec.Template.Labels)) {
+			resultD = append(resultD, d)
+		}
+	}
+
+	sfsList, err := statefulsets.List(ctx, i.Namespace, "", c)
+	if err != nil {
+		return nil, nil, err
+	}
+	resultSfs := []appsv1.StatefulSet{}
+	for _, sfs := range sfsList {
+		if selector.Matches(labels.Set(sfs.Spec.Template.Labels)) {
+			resultSfs = append(resultSfs, sfs)
+		}
+	}
+	return resultD, resultSfs, nil
+}
+
+// DeployAgent creates the agent receiving the traffic of the service.
+// publicKeyPath is the public key authorized to open the SSH tunnel
+func (i *Intercept) DeployAgent(ctx context.Context, c kubernetes.Interface, publicKeyPath string) error {
+	publicKey, err := os.ReadFile(publicKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read public key '%s': %w", publicKeyPath, err)
+	}
+
+	secret := i.translateSecret(publicKey)
+	if _, err := c.CoreV1().Secrets(i.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			return fmt.Errorf("failed to update secret '%s': %w", secret.Name, err)
+		}
+		if _, err := c.CoreV1().Secrets(i.Namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create secret '%s': %w", secret.Name, err)
+		}
+	}
+
+	if _, err := deployments.Deploy(ctx, i.translateAgent(), c); err != nil {
+		return fmt.Errorf("failed to deploy intercept agent: %w", err)
+	}
+
+	if i.IsHeaderBased() {
+		if err := services.Deploy(ctx, i.translateAgentService(), c); err != nil {
+			return fmt.Errorf("failed to deploy intercept service: %w", err)
+		}
+	}
+	return nil
+}
+
+// DestroyAgent deletes the resources of the agent
+func (i *Intercept) DestroyAgent(ctx context.Context, c kubernetes.Interface) error {
+	name := AgentName(i.Service)
+	if err := deployments.Destroy(ctx, name, i.Namespace, c); err != nil {
+		return err
+	}
+	if err := services.Destroy(ctx, name, i.Namespace, c); err != nil {
+		return err
+	}
+	if err := c.CoreV1().Secrets(i.Namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !oktetoErrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete secret '%s': %w", name, err)
+	}
+	return nil
+}
+
+// GetAgentPod returns the running pod of the agent
+func (i *Intercept) GetAgentPod(ctx context.Context, c kubernetes.Interface) (*apiv1.Pod, error) {
+	pods, err := c.CoreV1().Pods(i.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", InterceptLabel, i.Service),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for idx := range pods.Items {
+		pod := &pods.Items[idx]
+		if pod.DeletionTimestamp == nil && pod.Status.Phase == apiv1.PodRunning {
+			return pod, nil
+		}
+	}
+	return nil, oktetoErrors.ErrNotFound
+}
+
+// ExposeAgent adds the labels selected by the service to the pod of the agent, so it starts receiving the traffic of the service.
+// It must be called once the tunnel to the local process is open, otherwise the requests would reach a dead agent
+func (i *Intercept) ExposeAgent(ctx context.Context, c kubernetes.Interface, pod *apiv1.Pod) error {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": i.selector,
+		},
+	}
+	payload, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	if _, err := c.CoreV1().Pods(i.Namespace).Patch(ctx, pod.Name, types.MergePatchType, payload, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to add the labels of service '%s' to the intercept agent: %w", i.Service, err)
+	}
+	return nil
+}
+
+// SwapWorkloads scales to zero the workloads of the service, so all its traffic goes to the agent
+func (i *Intercept) SwapWorkloads(ctx context.Context, c kubernetes.Interface) error {
+	dList, sfsList, err := i.getWorkloads(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	for idx := range dList {
+		d := &dList[idx]
+		if _, ok := d.Annotations[ReplicasAnnotation]; ok {
+			continue
+		}
+		setReplicasAnnotation(&d.ObjectMeta, d.Spec.Replicas)
+		d.Spec.Replicas = pointer.Int32(0)
+		if _, err := c.AppsV1().Deployments(i.Namespace).Update(ctx, d, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to scale down deployment '%s': %w", d.Name, err)
+		}
+		oktetoLog.Infof("deployment '%s' scaled down for intercept", d.Name)
+	}
+
+	for idx := range sfsList {
+		sfs := &sfsList[idx]
+		if _, ok := sfs.Annotations[ReplicasAnnotation]; ok {
+			continue
+		}
+		setReplicasAnnotation(&sfs.ObjectMeta, sfs.Spec.Replicas)
+		sfs.Spec.Replicas = pointer.Int32(0)
+		if _, err := c.AppsV1().StatefulSets(i.Namespace).Update(ctx, sfs, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to scale down statefulset '%s': %w", sfs.Name, err)
+		}
+		oktetoLog.Infof("statefulset '%s' scaled down for intercept", sfs.Name)
+	}
+	return nil
+}
+
+// RestoreWorkloads scales the workloads of the service back to their original replicas
+func (i *Intercept) RestoreWorkloads(ctx context.Context, c kubernetes.Interface) error {
+	dList, sfsList, err := i.getWorkloads(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	for idx := range dList {
+		d := &dList[idx]
+		replicas, ok := getReplicasAnnotation(d.ObjectMeta)
+		if !ok {
+			continue
+		}
+		d.Spec.Replicas = pointer.Int32(replicas)
+		delete(d.Annotations, ReplicasAnnotation)
+		if _, err := c.AppsV1().Deployments(i.Namespace).Update(ctx, d, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to restore deployment '%s': %w", d.Name, err)
+		}
+		oktetoLog.Infof("deployment '%s' restored", d.Name)
+	}
+
+	for idx := range sfsList {
+		sfs := &sfsList[idx]
+		replicas, ok := getReplicasAnnotation(sfs.ObjectMeta)
+		if !ok {
+			continue
+		}
+		sfs.Spec.Replicas = pointer.Int32(replicas)
+		delete(sfs.Annotations, ReplicasAnnotation)
+		if _, err := c.AppsV1().StatefulSets(i.Namespace).Update(ctx, sfs, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to restore statefulset '%s': %w", sfs.Name, err)
+		}
+		oktetoLog.Infof("statefulset '%s' restored", sfs.Name)
+	}
+	return nil
+}
+
+func setReplicasAnnotation(meta *metav1.ObjectMeta, replicas *int32) {
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	value := int32(1)
+	if replicas != nil {
+		value = *replicas
+	}
+	meta.Annotations[ReplicasAnnotation] = strconv.Itoa(int(value))
+}
+
+func getReplicasAnnotation(meta metav1.ObjectMeta) (int32, bool) {
+	value, ok := meta.Annotations[ReplicasAnnotation]
+	if !ok {
+		return 0, false
+	}
+	replicas, err := strconv.Atoi(value)
+	if err != nil {
+		oktetoLog.Infof("invalid %s annotation '%s' in %s: %s", ReplicasAnnotation, value, meta.Name, err)
+		return 1, true
+	}
+	return int32(replicas), true
+}
+
+func (i *Intercept) translateSecret(publicKey []byte) *apiv1.Secret {
+	return &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AgentName(i.Service),
+			Namespace: i.Namespace,
+			Labels: map[string]string{
+				InterceptLabel: i.Service,
+			},
+		},
+		Type: apiv1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			authorizedKeysFile: publicKey,
+		},
+	}
+}
+
+// translateAgent returns the deployment of the agent. Its pods don't have the labels selected by the service,
+// they are added by ExposeAgent once the tunnel to the local process is open
+func (i *Intercept) translateAgent() *appsv1.Deployment {
+	podLabels := map[string]string{
+		InterceptLabel: i.Service,
+	}
+
+	ports := []apiv1.ContainerPort{
+		{Name: "ssh", ContainerPort: SSHPort},
+		{Name: i.TargetPortName, ContainerPort: int32(i.TargetPort)},
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AgentName(i.Service),
+			Namespace: i.Namespace,
+			Labels: map[string]string{
+				InterceptLabel:     i.Service,
+				constants.DevLabel: "true",
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(1),
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					InterceptLabel: i.Service,
+				},
+			},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podLabels,
+				},
+				Spec: apiv1.PodSpec{
+					TerminationGracePeriodSeconds: pointer.Int64(0),
+					Containers: []apiv1.Container{
+						{
+							Name:            agentContainerName,
+							Image:           model.OktetoBinImageTag,
+							ImagePullPolicy: apiv1.PullIfNotPresent,
+							Command:         []string{remoteBinaryPath},
+							Ports:           ports,
+							VolumeMounts: []apiv1.VolumeMount{
+								{
+									Name:      agentVolumeName,
+									MountPath: model.RemoteMountPath,
+								},
+							},
+						},
+					},
+					Volumes: []apiv1.Volume{
+						{
+							Name: agentVolumeName,
+							VolumeSource: apiv1.VolumeSource{
+								Secret: &apiv1.SecretVolumeSource{
+									SecretName: AgentName(i.Service),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// translateAgentService returns the service used to route the intercepted requests to the agent
+func (i *Intercept) translateAgentService() *apiv1.Service {
+	return &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AgentName(i.Service),
+			Namespace: i.Namespace,
+			Labels: map[string]string{
+				InterceptLabel: i.Service,
+			},
+		},
+		Spec: apiv1.ServiceSpec{
+			Selector: map[string]string{
+				InterceptLabel: i.Service,
+			},
+			Ports: []apiv1.ServicePort{
+				{
+					Name:       "http",
+					Port:       int32(i.Port),
+					TargetPort: intstr.FromInt(i.TargetPort),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intercept
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func TestParsePort(t *testing.T) {
+	tests := []struct {
+		value   string
+		local   int
+		remote  int
+		wantErr bool
+	}{
+		{value: "8080:80", local: 8080, remote: 80},
+		{value: "3000", local: 3000, remote: 3000},
+		{value: "8080:80:90", wantErr: true},
+		{value: "a:80", wantErr: true},
+		{value: "8080:70000", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			local, remote, err := ParsePort(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.local, local)
+			assert.Equal(t, tt.remote, remote)
+		})
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders([]string{"X-User=alice", "x-env = dev"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"x-user": "alice", "x-env": "dev"}, headers)
+
+	_, err = ParseHeaders([]string{"x-user"})
+	assert.Error(t, err)
+
+	_, err = ParseHeaders([]string{"=alice"})
+	assert.Error(t, err)
+}
+
+func newTestObjects() (*apiv1.Service, *appsv1.Deployment) {
+	selector := map[string]string{"app": "api"}
+	svc := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+		Spec: apiv1.ServiceSpec{
+			Selector: selector,
+			Ports: []apiv1.ServicePort{
+				{Port: 80, TargetPort: intstr.FromString("http")},
+				{Port: 9090},
+			},
+		},
+	}
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(3),
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api", "version": "v1"}},
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{
+						{Name: "api", Ports: []apiv1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+					},
+				},
+			},
+		},
+	}
+	return svc, d
+}
+
+func TestLoad(t *testing.T) {
+	svc, d := newTestObjects()
+	c := fake.NewSimpleClientset(svc, d)
+
+	i := &Intercept{Service: "api", Namespace: "test", Port: 80}
+	require.NoError(t, i.Load(context.Background(), c))
+	assert.Equal(t, 8080, i.TargetPort)
+	assert.Equal(t, "http", i.TargetPortName)
+
+	i = &Intercept{Service: "api", Namespace: "test", Port: 9090}
+	require.NoError(t, i.Load(context.Background(), c))
+	assert.Equal(t, 9090, i.TargetPort)
+
+	i = &Intercept{Service: "api", Namespace: "test", Port: 3000}
+	assert.Error(t, i.Load(context.Background(), c))
+
+	i = &Intercept{Service: "unknown", Namespace: "test", Port: 80}
+	assert.Error(t, i.Load(context.Background(), c))
+
+	svc.Spec.Ports = append(svc.Spec.Ports, apiv1.ServicePort{Port: 22, TargetPort: intstr.FromInt(SSHPort)})
+	c = fake.NewSimpleClientset(svc, d)
+	i = &Intercept{Service: "api", Namespace: "test", Port: 22}
+	err := i.Load(context.Background(), c)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
+
+func TestAgentLifecycle(t *testing.T) {
+	svc, d := newTestObjects()
+	c := fake.NewSimpleClientset(svc, d)
+	ctx := context.Background()
+
+	publicKey := filepath.Join(t.TempDir(), "id_rsa.pub")
+	require.NoError(t, os.WriteFile(publicKey, []byte("ssh-rsa AAAA"), 0600))
+
+	i := &Intercept{Service: "api", Namespace: "test", Port: 80, LocalPort: 8080}
+	require.NoError(t, i.Load(ctx, c))
+	require.NoError(t, i.DeployAgent(ctx, c, publicKey))
+
+	agent, err := c.AppsV1().Deployments("test").Get(ctx, "api-okteto-intercept", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{InterceptLabel: "api"}, agent.Spec.Template.Labels)
+	assert.Equal(t, int32(8080), agent.Spec.Template.Spec.Containers[0].Ports[1].ContainerPort)
+	assert.Equal(t, "http", agent.Spec.Template.Spec.Containers[0].Ports[1].Name)
+
+	pod := &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-okteto-intercept-abc", Namespace: "test", Labels: agent.Spec.Template.Labels}}
+	_, err = c.CoreV1().Pods("test").Create(ctx, pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, i.ExposeAgent(ctx, c, pod))
+	exposed, err := c.CoreV1().Pods("test").Get(ctx, pod.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "api", InterceptLabel: "api"}, exposed.Labels)
+
+	secret, err := c.CoreV1().Secrets("test").Get(ctx, "api-okteto-intercept", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ssh-rsa AAAA"), secret.Data[authorizedKeysFile])
+
+	require.NoError(t, i.SwapWorkloads(ctx, c))
+	swapped, err := c.AppsV1().Deployments("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *swapped.Spec.Replicas)
+	assert.Equal(t, "3", swapped.Annotations[ReplicasAnnotation])
+
+	require.NoError(t, i.SwapWorkloads(ctx, c))
+	swapped, err = c.AppsV1().Deployments("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "3", swapped.Annotations[ReplicasAnnotation])
+
+	require.NoError(t, i.RestoreWorkloads(ctx, c))
+	restored, err := c.AppsV1().Deployments("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), *restored.Spec.Replicas)
+	assert.NotContains(t, restored.Annotations, ReplicasAnnotation)
+
+	require.NoError(t, i.DestroyAgent(ctx, c))
+	_, err = c.AppsV1().Deployments("test").Get(ctx, "api-okteto-intercept", metav1.GetOptions{})
+	assert.Error(t, err)
+	_, err = c.CoreV1().Secrets("test").Get(ctx, "api-okteto-intercept", metav1.GetOptions{})
+	assert.Error(t, err)
+}
+
+func TestHeaderBasedAgent(t *testing.T) {
+	svc, d := newTestObjects()
+	c := fake.NewSimpleClientset(svc, d)
+	ctx := context.Background()
+
+	publicKey := filepath.Join(t.TempDir(), "id_rsa.pub")
+	require.NoError(t, os.WriteFile(publicKey, []byte("ssh-rsa AAAA"), 0600))
+
+	i := &Intercept{Service: "api", Namespace: "test", Port: 80, LocalPort: 8080, Headers: map[string]string{"x-user": "alice"}}
+	require.NoError(t, i.Load(ctx, c))
+	require.NoError(t, i.DeployAgent(ctx, c, publicKey))
+
+	agent, err := c.AppsV1().Deployments("test").Get(ctx, "api-okteto-intercept", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{InterceptLabel: "api"}, agent.Spec.Template.Labels)
+
+	agentSvc, err := c.CoreV1().Services("test").Get(ctx, "api-okteto-intercept", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(80), agentSvc.Spec.Ports[0].Port)
+	assert.Equal(t, 8080, agentSvc.Spec.Ports[0].TargetPort.IntValue())
+
+	require.NoError(t, i.DestroyAgent(ctx, c))
+	_, err = c.CoreV1().Services("test").Get(ctx, "api-okteto-intercept", metav1.GetOptions{})
+	assert.Error(t, err)
+}
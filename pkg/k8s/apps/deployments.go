@@ -79,6 +79,13 @@ func (i *DeploymentApp) PodSpec() *apiv1.PodSpec {
 	return &i.d.Spec.Template.Spec
 }
 
+func (i *DeploymentApp) PodSelector() *metav1.LabelSelector {
+	if i.d.Spec.Selector == nil {
+		i.d.Spec.Selector = &metav1.LabelSelector{}
+	}
+	return i.d.Spec.Selector
+}
+
 func (i *DeploymentApp) DevClone() App {
 	clone := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
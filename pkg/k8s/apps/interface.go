@@ -29,6 +29,7 @@ type App interface {
 	SetReplicas(n int32)
 	TemplateObjectMeta() metav1.ObjectMeta
 	PodSpec() *apiv1.PodSpec
+	PodSelector() *metav1.LabelSelector
 
 	// DevClone() creates in memory a clone of the app for dev mode
 	DevClone() App
@@ -77,6 +77,13 @@ func (i *StatefulSetApp) PodSpec() *apiv1.PodSpec {
 	return &i.sfs.Spec.Template.Spec
 }
 
+func (i *StatefulSetApp) PodSelector() *metav1.LabelSelector {
+	if i.sfs.Spec.Selector == nil {
+		i.sfs.Spec.Selector = &metav1.LabelSelector{}
+	}
+	return i.sfs.Spec.Selector
+}
+
 func (i *StatefulSetApp) DevClone() App {
 	clone := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -71,12 +71,17 @@ func (tr *Translation) translate() error {
 	delete(tr.App.ObjectMeta().Annotations, model.StateBeforeSleepingAnnontation)
 
 	tr.DevApp = tr.App.DevClone()
+	if tr.MainDev.IsSideBySide() {
+		TranslateSideBySideLabels(tr.DevApp, tr.MainDev.Name)
+	}
 
 	tr.App.ObjectMeta().Annotations[model.AppReplicasAnnotation] = strconv.Itoa(int(replicas))
 	tr.App.ObjectMeta().Labels[constants.DevLabel] = "true"
 	tr.App.ObjectMeta().Annotations[constants.OktetoDevModeAnnotation] = tr.Dev.Mode
 	tr.DevApp.ObjectMeta().Annotations[constants.OktetoDevModeAnnotation] = tr.Dev.Mode
-	tr.App.SetReplicas(0)
+	if !tr.MainDev.IsSideBySide() {
+		tr.App.SetReplicas(0)
+	}
 
 	for k, v := range tr.Dev.Metadata.Annotations {
 		tr.App.ObjectMeta().Annotations[k] = v
@@ -94,7 +99,9 @@ func (tr *Translation) translate() error {
 	if tr.MainDev == tr.Dev {
 		tr.DevApp.SetReplicas(1)
 		tr.DevApp.TemplateObjectMeta().Labels[model.InteractiveDevLabel] = tr.getDevName()
-		TranslateOktetoSyncSecret(tr.DevApp.PodSpec(), tr.Dev.Name)
+		if !tr.Dev.Sync.IsSSHSyncEngine() {
+			TranslateOktetoSyncSecret(tr.DevApp.PodSpec(), tr.Dev.Name)
+		}
 	} else {
 		if tr.Dev.Replicas != nil {
 			tr.DevApp.SetReplicas(int32(*tr.Dev.Replicas))
@@ -156,6 +163,24 @@ func (tr *Translation) DevModeOff() error {
 	return nil
 }
 
+// TranslateSideBySideLabels renames the pod labels of a dev clone so the services of the original app don't send traffic to it
+func TranslateSideBySideLabels(devApp App, name string) {
+	selector := devApp.PodSelector()
+	if selector.MatchLabels == nil {
+		selector.MatchLabels = map[string]string{}
+	}
+	templateLabels := devApp.TemplateObjectMeta().Labels
+	for k, v := range templateLabels {
+		templateLabels[k] = model.SideBySideLabelValue(v)
+	}
+	for k, v := range selector.MatchLabels {
+		selector.MatchLabels[k] = model.SideBySideLabelValue(v)
+	}
+	selector.MatchLabels[model.SideBySideLabel] = name
+	templateLabels[model.SideBySideLabel] = name
+	devApp.ObjectMeta().Labels[model.SideBySideLabel] = name
+}
+
 // TranslateDevTolerations sets the user provided toleretions
 func TranslateDevTolerations(spec *apiv1.PodSpec, tolerations []apiv1.Toleration) {
 	spec.Tolerations = append(spec.Tolerations, tolerations...)
@@ -319,13 +344,15 @@ func TranslateVolumeMounts(c *apiv1.Container, rule *model.TranslationRule) {
 	if rule.Marker == "" {
 		return
 	}
-	c.VolumeMounts = append(
-		c.VolumeMounts,
-		apiv1.VolumeMount{
-			Name:      oktetoSyncSecretVolume,
-			MountPath: "/var/syncthing/secret/",
-		},
-	)
+	if rule.SyncEngine != model.SyncEngineSSH {
+		c.VolumeMounts = append(
+			c.VolumeMounts,
+			apiv1.VolumeMount{
+				Name:      oktetoSyncSecretVolume,
+				MountPath: "/var/syncthing/secret/",
+			},
+		)
+	}
 	if len(rule.Secrets) > 0 {
 		c.VolumeMounts = append(
 			c.VolumeMounts,
@@ -2149,3 +2149,39 @@ func Test_getDevName(t *testing.T) {
 		})
 	}
 }
+
+func Test_translateSideBySide(t *testing.T) {
+	manifestBytes := []byte(`name: web
+namespace: n
+image: web:latest
+replace: false
+sync:
+  - .:/okteto`)
+
+	manifest, err := model.Read(manifestBytes)
+	require.NoError(t, err)
+	dev := manifest.Dev["web"]
+
+	d := deployments.Sandbox(dev)
+	d.Spec.Replicas = pointer.Int32Ptr(3)
+	delete(d.Annotations, model.OktetoAutoCreateAnnotation)
+	rule := dev.ToTranslationRule(dev, false)
+	tr := &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     NewDeploymentApp(d),
+		Rules:   []*model.TranslationRule{rule},
+	}
+	require.NoError(t, tr.translate())
+
+	assert.Equal(t, int32(3), tr.App.Replicas())
+	assert.Equal(t, "3", tr.App.ObjectMeta().Annotations[model.AppReplicasAnnotation])
+	assert.Equal(t, map[string]string{"app": "web"}, tr.App.PodSelector().MatchLabels)
+	assert.Equal(t, "web", tr.App.TemplateObjectMeta().Labels["app"])
+
+	assert.Equal(t, int32(1), tr.DevApp.Replicas())
+	assert.Equal(t, map[string]string{"app": "web-okteto", model.SideBySideLabel: "web"}, tr.DevApp.PodSelector().MatchLabels)
+	assert.Equal(t, "web-okteto", tr.DevApp.TemplateObjectMeta().Labels["app"])
+	assert.Equal(t, "web", tr.DevApp.TemplateObjectMeta().Labels[model.SideBySideLabel])
+	assert.Equal(t, "web", tr.DevApp.ObjectMeta().Labels[model.SideBySideLabel])
+}
@@ -0,0 +1,263 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/k8s/services"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// clusterDomainSuffix is the suffix of the services of the cluster network
+const clusterDomainSuffix = ".svc.cluster.local"
+
+// ServiceDialer opens connections to the services of the cluster with a port-forward to one of their pods.
+// It doesn't need a development container, so it works for any deployed environment
+type ServiceDialer struct {
+	namespace  string
+	restConfig *rest.Config
+	client     kubernetes.Interface
+	requestID  int32
+}
+
+// NewServiceDialer returns a dialer for the services of the cluster. Service names without a namespace are resolved in namespace
+func NewServiceDialer(namespace string, restConfig *rest.Config, c kubernetes.Interface) *ServiceDialer {
+	return &ServiceDialer{
+		namespace:  namespace,
+		restConfig: restConfig,
+		client:     c,
+	}
+}
+
+// DialContext connects to address, which must be of the form '<service>[.<namespace>[.svc[.cluster.local]]]:<port>'
+func (d *ServiceDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if network != "tcp" {
+		return nil, fmt.Errorf("network '%s' is not supported", network)
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	servicePort, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port '%s'", port)
+	}
+	name, namespace, err := parseServiceHost(host, d.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, podPort, err := d.resolve(ctx, name, namespace, servicePort)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer, err := newPodDialer(d.client, d.restConfig, pod.Namespace, pod.Name)
+	if err != nil {
+		return nil, err
+	}
+	conn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to port-forward to pod/%s: %w", pod.Name, err)
+	}
+
+	requestID := atomic.AddInt32(&d.requestID, 1)
+	headers := http.Header{}
+	headers.Set(apiv1.StreamType, apiv1.StreamTypeError)
+	headers.Set(apiv1.PortHeader, strconv.Itoa(podPort))
+	headers.Set(apiv1.PortForwardRequestIDHeader, strconv.Itoa(int(requestID)))
+	errorStream, err := conn.CreateStream(headers)
+	if err != nil {
+		closeStreamConnection(conn)
+		return nil, fmt.Errorf("failed to create error stream: %w", err)
+	}
+	if err := errorStream.Close(); err != nil {
+		oktetoLog.Debugf("Error closing error stream: %s", err)
+	}
+	go func() {
+		message, err := io.ReadAll(errorStream)
+		if err == nil && len(message) > 0 {
+			oktetoLog.Infof("port-forward to %s failed: %s", address, string(message))
+		}
+	}()
+
+	headers.Set(apiv1.StreamType, apiv1.StreamTypeData)
+	dataStream, err := conn.CreateStream(headers)
+	if err != nil {
+		closeStreamConnection(conn)
+		return nil, fmt.Errorf("failed to create data stream: %w", err)
+	}
+
+	return &streamConn{Stream: dataStream, conn: conn, address: address}, nil
+}
+
+// resolve returns a running pod of the service and the pod port mapped to the service port
+func (d *ServiceDialer) resolve(ctx context.Context, name, namespace string, servicePort int) (*apiv1.Pod, int, error) {
+	svc, err := services.Get(ctx, name, namespace, d.client)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get service/%s in namespace '%s': %w", name, namespace, err)
+	}
+
+	var target *apiv1.ServicePort
+	for i := range svc.Spec.Ports {
+		if int(svc.Spec.Ports[i].Port) == servicePort {
+			target = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if target == nil {
+		return nil, 0, fmt.Errorf("service/%s doesn't expose port %d", name, servicePort)
+	}
+
+	list, err := pods.ListBySelector(ctx, namespace, svc.Spec.Selector, d.client)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get the pods of service/%s: %w", name, err)
+	}
+	for i := range list {
+		if list[i].Status.Phase != apiv1.PodRunning || list[i].DeletionTimestamp != nil {
+			continue
+		}
+		podPort, err := getPodPort(&list[i], target)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &list[i], podPort, nil
+	}
+	return nil, 0, fmt.Errorf("service/%s doesn't have running pods", name)
+}
+
+// getPodPort returns the port of the pod targeted by the service port, resolving named ports
+func getPodPort(pod *apiv1.Pod, servicePort *apiv1.ServicePort) (int, error) {
+	switch servicePort.TargetPort.Type {
+	case intstr.String:
+		for _, c := range pod.Spec.Containers {
+			for _, p := range c.Ports {
+				if p.Name == servicePort.TargetPort.StrVal {
+					return int(p.ContainerPort), nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("pod/%s doesn't have a port named '%s'", pod.Name, servicePort.TargetPort.StrVal)
+	default:
+		if servicePort.TargetPort.IntVal == 0 {
+			return int(servicePort.Port), nil
+		}
+		return int(servicePort.TargetPort.IntVal), nil
+	}
+}
+
+// parseServiceHost returns the name and the namespace of the service of the host
+func parseServiceHost(host, defaultNamespace string) (string, string, error) {
+	if net.ParseIP(host) != nil {
+		return "", "", fmt.Errorf("'%s' is an IP address, use the name of the service instead", host)
+	}
+
+	trimmed := strings.TrimSuffix(strings.TrimSuffix(host, "."), clusterDomainSuffix)
+	trimmed = strings.TrimSuffix(trimmed, ".svc")
+	parts := strings.Split(trimmed, ".")
+	switch len(parts) {
+	case 1:
+		return parts[0], defaultNamespace, nil
+	case 2:
+		return parts[0], parts[1], nil
+	default:
+		return "", "", fmt.Errorf("'%s' is not the address of a service of the cluster", host)
+	}
+}
+
+func newPodDialer(c kubernetes.Interface, restConfig *rest.Config, namespace, pod string) (httpstream.Dialer, error) {
+	url := c.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("portforward").URL()
+
+	if restConfig == nil {
+		return nil, fmt.Errorf("restConfig is nil")
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url), nil
+}
+
+func closeStreamConnection(c httpstream.Connection) {
+	if err := c.Close(); err != nil {
+		oktetoLog.Debugf("Error closing port-forward connection: %s", err)
+	}
+}
+
+// streamConn is the net.Conn of the data stream of a port-forward. Deadlines are not supported
+type streamConn struct {
+	httpstream.Stream
+	conn    httpstream.Connection
+	address string
+}
+
+func (c *streamConn) Close() error {
+	err := c.Stream.Close()
+	closeStreamConnection(c.conn)
+	return err
+}
+
+func (*streamConn) LocalAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
+}
+
+func (c *streamConn) RemoteAddr() net.Addr {
+	return portForwardAddr(c.address)
+}
+
+func (*streamConn) SetDeadline(_ time.Time) error {
+	return nil
+}
+
+func (*streamConn) SetReadDeadline(_ time.Time) error {
+	return nil
+}
+
+func (*streamConn) SetWriteDeadline(_ time.Time) error {
+	return nil
+}
+
+type portForwardAddr string
+
+func (portForwardAddr) Network() string {
+	return "tcp"
+}
+
+func (a portForwardAddr) String() string {
+	return string(a)
+}
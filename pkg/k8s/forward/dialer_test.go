@@ -0,0 +1,113 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_parseServiceHost(t *testing.T) {
+	tests := []struct {
+		host      string
+		name      string
+		namespace string
+		wantErr   bool
+	}{
+		{host: "api", name: "api", namespace: "default"},
+		{host: "api.staging", name: "api", namespace: "staging"},
+		{host: "api.staging.svc", name: "api", namespace: "staging"},
+		{host: "api.staging.svc.cluster.local", name: "api", namespace: "staging"},
+		{host: "api.staging.svc.cluster.local.", name: "api", namespace: "staging"},
+		{host: "api.okteto.example.com", wantErr: true},
+		{host: "10.0.0.1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			name, namespace, err := parseServiceHost(tt.host, "default")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.namespace, namespace)
+		})
+	}
+}
+
+func TestServiceDialerResolve(t *testing.T) {
+	selector := map[string]string{"app": "api"}
+	svc := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+		Spec: apiv1.ServiceSpec{
+			Selector: selector,
+			Ports: []apiv1.ServicePort{
+				{Port: 80, TargetPort: intstr.FromString("http")},
+				{Port: 9090, TargetPort: intstr.FromInt(9000)},
+				{Port: 5432},
+				{Port: 6379, TargetPort: intstr.FromString("redis")},
+			},
+		},
+	}
+	pending := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-pending", Namespace: "test", Labels: selector},
+		Status:     apiv1.PodStatus{Phase: apiv1.PodPending},
+	}
+	running := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-running", Namespace: "test", Labels: selector},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{
+				{Ports: []apiv1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+			},
+		},
+		Status: apiv1.PodStatus{Phase: apiv1.PodRunning},
+	}
+	d := NewServiceDialer("test", nil, fake.NewSimpleClientset(svc, pending, running))
+
+	tests := []struct {
+		name     string
+		port     int
+		expected int
+		wantErr  bool
+	}{
+		{name: "named-port", port: 80, expected: 8080},
+		{name: "numeric-port", port: 9090, expected: 9000},
+		{name: "default-target-port", port: 5432, expected: 5432},
+		{name: "missing-named-port", port: 6379, wantErr: true},
+		{name: "not-exposed", port: 3000, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod, port, err := d.resolve(context.Background(), "api", "test", tt.port)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "api-running", pod.Name)
+			assert.Equal(t, tt.expected, port)
+		})
+	}
+
+	_, _, err := d.resolve(context.Background(), "unknown", "test", 80)
+	assert.Error(t, err)
+}
@@ -18,8 +18,9 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"net/http"
 	"runtime"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/okteto/okteto/pkg/k8s/labels"
@@ -33,7 +34,6 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
-	"k8s.io/client-go/transport/spdy"
 )
 
 // PortForwardManager keeps a list of all the active port forwards
@@ -48,6 +48,25 @@ type PortForwardManager struct {
 	restConfig     *rest.Config
 	client         kubernetes.Interface
 	namespace      string
+	mu             sync.Mutex
+	status         map[string]*serviceStatus
+}
+
+// serviceStatus is the state of the port forwards to a service
+type serviceStatus struct {
+	pod           string
+	connected     bool
+	reconnections int
+	err           error
+}
+
+// ForwardStatus is the state of a port forward to a service
+type ForwardStatus struct {
+	Forward       forward.Forward
+	Pod           string
+	Connected     bool
+	Reconnections int
+	Error         string
 }
 
 type active struct {
@@ -86,6 +105,7 @@ func NewPortForwardManager(ctx context.Context, iface string, restConfig *rest.C
 		iface:      iface,
 		ports:      make(map[int]forward.Forward),
 		services:   make(map[string]struct{}),
+		status:     make(map[string]*serviceStatus),
 		restConfig: restConfig,
 		client:     c,
 		namespace:  namespace,
@@ -98,6 +118,10 @@ func (p *PortForwardManager) Add(f forward.Forward) error {
 		return fmt.Errorf("port %d is listed multiple times, please check your configuration", f.Local)
 	}
 
+	if f.RequiresSSH() {
+		return fmt.Errorf("forward '%s' is only supported over the SSH server of your development container", f.String())
+	}
+
 	if !model.IsPortAvailable(p.iface, f.Local) {
 		if f.Local <= 1024 {
 			os := runtime.GOOS
@@ -129,6 +153,11 @@ func (*PortForwardManager) AddReverse(_ model.Reverse) error {
 	return fmt.Errorf("not implemented")
 }
 
+// AddProxy is not implemented
+func (*PortForwardManager) AddProxy(_ int) error {
+	return fmt.Errorf("not implemented")
+}
+
 // Start starts all the port forwarders to the development container
 func (p *PortForwardManager) Start(devPod, namespace string) error {
 	p.stopped = false
@@ -147,10 +176,7 @@ func (p *PortForwardManager) Start(devPod, namespace string) error {
 		}
 	}()
 
-	p.activeServices = map[string]*active{}
-	for svc := range p.services {
-		go p.forwardService(p.ctx, namespace, svc)
-	}
+	p.startServices(namespace)
 
 	<-p.activeDev.readyChan
 
@@ -162,16 +188,72 @@ func (p *PortForwardManager) Start(devPod, namespace string) error {
 	return nil
 }
 
+// StartServices starts the port forwards to services without a development container.
+// The port forwards to a service reconnect when its pods are restarted
+func (p *PortForwardManager) StartServices(namespace string) {
+	p.stopped = false
+	p.startServices(namespace)
+}
+
+func (p *PortForwardManager) startServices(namespace string) {
+	p.mu.Lock()
+	p.activeServices = map[string]*active{}
+	p.mu.Unlock()
+	for svc := range p.services {
+		go p.forwardService(p.ctx, namespace, svc)
+	}
+}
+
+// Status returns the state of the port forwards to services, sorted by local port
+func (p *PortForwardManager) Status() []ForwardStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := []ForwardStatus{}
+	for _, f := range p.ports {
+		if !f.Service {
+			continue
+		}
+		fs := ForwardStatus{Forward: f}
+		if st, ok := p.status[f.ServiceName]; ok {
+			fs.Pod = st.pod
+			fs.Connected = st.connected
+			fs.Reconnections = st.reconnections
+			if st.err != nil {
+				fs.Error = st.err.Error()
+			}
+		}
+		result = append(result, fs)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Forward.Local < result[j].Forward.Local
+	})
+	return result
+}
+
+func (p *PortForwardManager) setStatus(service string, update func(st *serviceStatus)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st, ok := p.status[service]
+	if !ok {
+		st = &serviceStatus{}
+		p.status[service] = st
+	}
+	update(st)
+}
+
 // Stop stops all the port forwarders
 func (p *PortForwardManager) Stop() {
 	p.stopped = true
 	p.activeDev.stop()
 
+	p.mu.Lock()
 	for _, a := range p.activeServices {
 		a.stop()
 	}
-
 	p.activeServices = nil
+	p.mu.Unlock()
+
 	p.activeDev = nil
 	oktetoLog.Infof("stopped k8s forwarder")
 }
@@ -224,23 +306,24 @@ func (p *PortForwardManager) buildForwarder(namespace, pod string, ports []strin
 	return a, pf, nil
 }
 
-func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namespace, service string) (*active, *portforward.PortForwarder, error) {
+func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namespace, service string) (*active, *portforward.PortForwarder, string, error) {
 	svc, err := services.Get(ctx, service, namespace, p.client)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
 	}
 
 	if len(svc.Spec.Ports) == 0 {
-		return nil, nil, fmt.Errorf("service/%s doesn't have ports", svc.GetName())
+		return nil, nil, "", fmt.Errorf("service/%s doesn't have ports", svc.GetName())
 	}
 
 	pod, err := pods.GetBySelector(ctx, namespace, svc.Spec.Selector, p.client)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get pod mapped to service/%s: %w", svc.GetName(), err)
+		return nil, nil, "", fmt.Errorf("failed to get pod mapped to service/%s: %w", svc.GetName(), err)
 	}
 
 	ports := getServicePorts(svc.GetName(), p.ports)
-	return p.buildForwarder(pod.GetNamespace(), pod.GetName(), ports)
+	a, pf, err := p.buildForwarder(pod.GetNamespace(), pod.GetName(), ports)
+	return a, pf, pod.GetName(), err
 }
 
 func getServicePorts(service string, forwards map[int]forward.Forward) []string {
@@ -256,22 +339,7 @@ func getServicePorts(service string, forwards map[int]forward.Forward) []string
 }
 
 func (p *PortForwardManager) buildDialer(namespace, pod string) (httpstream.Dialer, error) {
-	url := p.client.CoreV1().RESTClient().Post().
-		Resource("pods").
-		Namespace(namespace).
-		Name(pod).
-		SubResource("portforward").URL()
-
-	if p.restConfig == nil {
-		return nil, fmt.Errorf("restConfig is nil")
-	}
-
-	transport, upgrader, err := spdy.RoundTripperFor(p.restConfig)
-	if err != nil {
-		return nil, err
-	}
-
-	return spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url), nil
+	return newPodDialer(p.client, p.restConfig, namespace, pod)
 }
 
 func (p *PortForwardManager) forwardService(ctx context.Context, namespace, service string) {
@@ -283,21 +351,60 @@ func (p *PortForwardManager) forwardService(ctx context.Context, namespace, serv
 		}
 
 		oktetoLog.Infof("k8s forwarding ports for service/%s", service)
-		a, pf, err := p.buildForwarderToService(ctx, namespace, service)
+		a, pf, pod, err := p.buildForwarderToService(ctx, namespace, service)
 		if err != nil {
 			oktetoLog.Infof("failed to k8s forward ports to service/%s: %s", service, err)
-			<-t.C
-			continue
+			p.setStatus(service, func(st *serviceStatus) {
+				st.connected = false
+				st.err = err
+			})
+			select {
+			case <-t.C:
+				continue
+			case <-ctx.Done():
+				return
+			}
 		}
 
-		if err := pf.ForwardPorts(); err != nil {
+		p.mu.Lock()
+		if p.activeServices != nil {
+			p.activeServices[service] = a
+		}
+		p.mu.Unlock()
+
+		ready := a.readyChan
+		go func() {
+			select {
+			case <-ready:
+				p.setStatus(service, func(st *serviceStatus) {
+					st.pod = pod
+					st.connected = true
+					st.err = nil
+				})
+			case <-ctx.Done():
+			}
+		}()
+
+		err = pf.ForwardPorts()
+		if err != nil {
 			oktetoLog.Infof("k8s forwarding to service/%s finished with errors: %s", service, err)
+			p.mu.Lock()
 			a.stop()
+			p.mu.Unlock()
 		} else {
 			oktetoLog.Infof("k8s forwarding to service/%s finished", service)
 		}
-
-		<-t.C
+		p.setStatus(service, func(st *serviceStatus) {
+			st.connected = false
+			st.err = err
+			st.reconnections++
+		})
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
 	}
 }
 
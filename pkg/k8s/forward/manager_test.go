@@ -15,6 +15,7 @@ package forward
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"sort"
 	"testing"
@@ -184,3 +185,29 @@ func Test_getServicePorts(t *testing.T) {
 		})
 	}
 }
+
+func TestStatus(t *testing.T) {
+	pf := NewPortForwardManager(context.Background(), model.Localhost, nil, nil, "")
+	pf.ports = map[int]forward.Forward{
+		22000: {Local: 22000, Remote: 22},
+		8080:  {Local: 8080, Remote: 80, ServiceName: "api", Service: true},
+		5432:  {Local: 5432, Remote: 5432, ServiceName: "db", Service: true},
+	}
+
+	pf.setStatus("db", func(st *serviceStatus) {
+		st.pod = "db-0"
+		st.connected = true
+	})
+	pf.setStatus("api", func(st *serviceStatus) {
+		st.err = fmt.Errorf("pod not found")
+		st.reconnections = 1
+	})
+
+	expected := []ForwardStatus{
+		{Forward: pf.ports[5432], Pod: "db-0", Connected: true},
+		{Forward: pf.ports[8080], Reconnections: 1, Error: "pod not found"},
+	}
+	if got := pf.Status(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected: %+v, Got: %+v", expected, got)
+	}
+}
@@ -14,6 +14,7 @@
 package secrets
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -53,7 +54,7 @@ func Get(ctx context.Context, name, namespace string, c kubernetes.Interface) (*
 	return secret, nil
 }
 
-// Create creates the syncthing config secret
+// Create creates the secret of the development container with its secrets and the syncthing config, if s is not nil
 func Create(ctx context.Context, dev *model.Dev, c kubernetes.Interface, s *syncthing.Syncthing) error {
 	secretName := GetSecretName(dev)
 
@@ -62,10 +63,6 @@ func Create(ctx context.Context, dev *model.Dev, c kubernetes.Interface, s *sync
 		return fmt.Errorf("error getting kubernetes secret: %s", err)
 	}
 
-	config, err := getConfigXML(s)
-	if err != nil {
-		return fmt.Errorf("error generating syncthing configuration: %s", err)
-	}
 	data := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: secretName,
@@ -74,11 +71,18 @@ func Create(ctx context.Context, dev *model.Dev, c kubernetes.Interface, s *sync
 			},
 		},
 		Type: v1.SecretTypeOpaque,
-		Data: map[string][]byte{
-			"config.xml": config,
-			"cert.pem":   []byte(certPEM),
-			"key.pem":    []byte(keyPEM),
-		},
+		Data: map[string][]byte{},
+	}
+
+	// the syncthing configuration isn't needed by the ssh sync engine
+	if s != nil {
+		config, err := getConfigXML(s)
+		if err != nil {
+			return fmt.Errorf("error generating syncthing configuration: %s", err)
+		}
+		data.Data["config.xml"] = config
+		data.Data["cert.pem"] = []byte(certPEM)
+		data.Data["key.pem"] = []byte(keyPEM)
 	}
 
 	idx := 0
@@ -113,6 +117,41 @@ func Create(ctx context.Context, dev *model.Dev, c kubernetes.Interface, s *sync
 	return nil
 }
 
+// UpdateAuthorizedKey replaces the public key authorized in the development containers of a namespace.
+// It returns the names of the updated secrets
+func UpdateAuthorizedKey(ctx context.Context, namespace string, previous, current []byte, c kubernetes.Interface) ([]string, error) {
+	if len(bytes.TrimSpace(previous)) == 0 {
+		return nil, nil
+	}
+
+	sList, err := c.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=true", constants.DevLabel)})
+	if err != nil {
+		return nil, fmt.Errorf("error listing kubernetes okteto secrets: %s", err)
+	}
+
+	updated := []string{}
+	for i := range sList.Items {
+		sct := &sList.Items[i]
+		found := false
+		for key, value := range sct.Data {
+			if bytes.Equal(bytes.TrimSpace(value), bytes.TrimSpace(previous)) {
+				sct.Data[key] = current
+				found = true
+			}
+		}
+		if !found {
+			continue
+		}
+
+		if _, err := c.CoreV1().Secrets(sct.Namespace).Update(ctx, sct, metav1.UpdateOptions{}); err != nil {
+			return updated, fmt.Errorf("error updating kubernetes okteto secret: %s", err)
+		}
+		oktetoLog.Infof("updated the authorized key of okteto secret '%s'", sct.Name)
+		updated = append(updated, sct.Name)
+	}
+	return updated, nil
+}
+
 // Destroy deletes the syncthing config secret
 func Destroy(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
 	secretName := GetSecretName(dev)
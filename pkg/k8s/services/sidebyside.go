@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CreateSideBySide creates a copy of every service selecting the pods of the original app pointing to the side-by-side dev clone.
+// It returns a map with the name of the original services and the name of its copy
+func CreateSideBySide(ctx context.Context, dev *model.Dev, podLabels map[string]string, c kubernetes.Interface) (map[string]string, error) {
+	svcList, err := List(ctx, dev.Namespace, "", c)
+	if err != nil {
+		return nil, fmt.Errorf("error listing kubernetes services: %w", err)
+	}
+	result := map[string]string{}
+	for i := range svcList {
+		if !selectsPods(&svcList[i], podLabels) {
+			continue
+		}
+		s := translateSideBySide(dev, &svcList[i])
+		if err := Deploy(ctx, s, c); err != nil {
+			return nil, err
+		}
+		result[svcList[i].Name] = s.Name
+	}
+	return result, nil
+}
+
+// DestroySideBySide destroys the services created for a side-by-side dev clone
+func DestroySideBySide(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
+	svcList, err := List(ctx, dev.Namespace, fmt.Sprintf("%s=%s", model.SideBySideLabel, dev.Name), c)
+	if err != nil {
+		return fmt.Errorf("error listing kubernetes services: %w", err)
+	}
+	for i := range svcList {
+		if err := Destroy(ctx, svcList[i].Name, svcList[i].Namespace, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func selectsPods(s *apiv1.Service, podLabels map[string]string) bool {
+	if len(s.Spec.Selector) == 0 {
+		return false
+	}
+	if s.Labels[model.SideBySideLabel] != "" {
+		return false
+	}
+	for k, v := range s.Spec.Selector {
+		if podLabels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func translateSideBySide(dev *model.Dev, s *apiv1.Service) *apiv1.Service {
+	result := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        model.DevCloneName(s.Name),
+			Namespace:   s.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: apiv1.ServiceSpec{
+			Type:     apiv1.ServiceTypeClusterIP,
+			Selector: map[string]string{},
+			Ports:    []apiv1.ServicePort{},
+		},
+	}
+	for k, v := range s.Labels {
+		result.Labels[k] = v
+	}
+	result.Labels[constants.DevLabel] = "true"
+	result.Labels[model.SideBySideLabel] = dev.Name
+	for k, v := range s.Spec.Selector {
+		result.Spec.Selector[k] = model.SideBySideLabelValue(v)
+	}
+	if s.Spec.ClusterIP == apiv1.ClusterIPNone {
+		result.Spec.ClusterIP = apiv1.ClusterIPNone
+	}
+	for _, p := range s.Spec.Ports {
+		p.NodePort = 0
+		result.Spec.Ports = append(result.Spec.Ports, p)
+	}
+	return result
+}
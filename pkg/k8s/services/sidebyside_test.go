@@ -0,0 +1,70 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateSideBySide(t *testing.T) {
+	ctx := context.Background()
+	dev := &model.Dev{Name: "api", Namespace: "test"}
+	c := fake.NewSimpleClientset(
+		&apiv1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test", Labels: map[string]string{"stack": "demo"}},
+			Spec: apiv1.ServiceSpec{
+				Type:      apiv1.ServiceTypeLoadBalancer,
+				ClusterIP: "10.0.0.1",
+				Selector:  map[string]string{"app": "api"},
+				Ports: []apiv1.ServicePort{
+					{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080), NodePort: 30080},
+				},
+			},
+		},
+		&apiv1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test"},
+			Spec: apiv1.ServiceSpec{
+				Selector: map[string]string{"app": "db"},
+			},
+		},
+	)
+
+	svcs, err := CreateSideBySide(ctx, dev, map[string]string{"app": "api", "version": "v1"}, c)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"api": "api-okteto"}, svcs)
+
+	s, err := Get(ctx, "api-okteto", "test", c)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"stack": "demo", constants.DevLabel: "true", model.SideBySideLabel: "api"}, s.Labels)
+	assert.Equal(t, map[string]string{"app": "api-okteto"}, s.Spec.Selector)
+	assert.Equal(t, apiv1.ServiceTypeClusterIP, s.Spec.Type)
+	assert.Empty(t, s.Spec.ClusterIP)
+	assert.Equal(t, []apiv1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080)}}, s.Spec.Ports)
+
+	require.NoError(t, DestroySideBySide(ctx, dev, c))
+	_, err = Get(ctx, "api-okteto", "test", c)
+	assert.Error(t, err)
+	_, err = Get(ctx, "api", "test", c)
+	assert.NoError(t, err)
+}
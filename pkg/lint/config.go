@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigFile is the name of the lint configuration file, loaded from the folder of the manifest
+const ConfigFile = ".okteto-lint.yml"
+
+// Config configures the rules of the linter
+type Config struct {
+	// Disable has the IDs or names of the disabled rules
+	Disable []string `yaml:"disable,omitempty"`
+
+	// Severity overrides the severity of rules by ID or name
+	Severity map[string]Severity `yaml:"severity,omitempty"`
+}
+
+// LoadConfig reads the lint configuration at path. An empty configuration is returned if the file doesn't exist
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return nil, err
+	}
+
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, fmt.Errorf("invalid lint configuration '%s': %w", path, err)
+	}
+	for rule, severity := range c.Severity {
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityInfo:
+		default:
+			return nil, fmt.Errorf("invalid lint configuration '%s': severity of '%s' must be one of 'error', 'warning' or 'info'", path, rule)
+		}
+	}
+	return c, nil
+}
+
+func (c *Config) isDisabled(r Rule) bool {
+	for _, disabled := range c.Disable {
+		if r.matches(disabled) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) severity(r Rule) Severity {
+	for idOrName, severity := range c.Severity {
+		if r.matches(idOrName) {
+			return severity
+		}
+	}
+	return r.Severity
+}
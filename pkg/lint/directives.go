@@ -0,0 +1,81 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// directiveRegex matches the inline comments that disable rules:
+//
+//	# okteto-lint-disable OKL001           disables the rule in the whole file
+//	# okteto-lint-disable-line OKL001      disables the rule in the line of the comment
+//	# okteto-lint-disable-next-line OKL001 disables the rule in the following line
+//
+// The rules are separated by commas, and all rules are disabled when none is given
+var directiveRegex = regexp.MustCompile(`#\s*okteto-lint-(disable-next-line|disable-line|disable)\b([^#]*)$`)
+
+const allRules = "*"
+
+// directives has the rules disabled by inline comments, by line. Line 0 is the whole file
+type directives map[int][]string
+
+func parseDirectives(content []byte) directives {
+	result := directives{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		match := directiveRegex.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+
+		rules := []string{}
+		for _, rule := range strings.Split(match[2], ",") {
+			if rule = strings.TrimSpace(rule); rule != "" {
+				rules = append(rules, rule)
+			}
+		}
+		if len(rules) == 0 {
+			rules = []string{allRules}
+		}
+
+		switch match[1] {
+		case "disable":
+			result[0] = append(result[0], rules...)
+		case "disable-line":
+			result[line] = append(result[line], rules...)
+		case "disable-next-line":
+			result[line+1] = append(result[line+1], rules...)
+		}
+	}
+	return result
+}
+
+func (d directives) isDisabled(r Rule, line int) bool {
+	lines := []int{0}
+	if line > 0 {
+		lines = append(lines, line)
+	}
+	for _, l := range lines {
+		for _, disabled := range d[l] {
+			if disabled == allRules || r.matches(disabled) {
+				return true
+			}
+		}
+	}
+	return false
+}
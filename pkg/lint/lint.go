@@ -0,0 +1,192 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks okteto manifests for common mistakes that are valid for the schema
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/okteto/okteto/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is the severity of the issues of a rule
+type Severity string
+
+const (
+	// SeverityError is the severity of the issues that break the development environment
+	SeverityError Severity = "error"
+
+	// SeverityWarning is the severity of the issues that should be fixed
+	SeverityWarning Severity = "warning"
+
+	// SeverityInfo is the severity of the suggestions
+	SeverityInfo Severity = "info"
+)
+
+// Rule is a check over a parsed okteto manifest
+type Rule struct {
+	// ID is the stable identifier of the rule
+	ID string
+
+	// Name is a readable identifier of the rule, it can be used instead of the ID to disable it
+	Name string
+
+	// Severity is the default severity of the issues of the rule
+	Severity Severity
+
+	// Description explains what the rule checks
+	Description string
+
+	// Check returns the issues of the manifest, with their message and path
+	Check func(m *Manifest) []Issue
+}
+
+// Issue is a problem found by a rule
+type Issue struct {
+	RuleID   string   `json:"ruleId"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Path     string   `json:"path,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+}
+
+// Manifest is an okteto manifest to be linted
+type Manifest struct {
+	// Path is the path of the manifest file
+	Path string
+
+	// Root is the folder of the repository of the manifest
+	Root string
+
+	// Content is the raw content of the manifest file
+	Content []byte
+
+	// Manifest is the parsed manifest
+	Manifest *model.Manifest
+
+	doc *yaml.Node
+}
+
+// NewManifest parses the content of the manifest at path
+func NewManifest(path string, content []byte) (*Manifest, error) {
+	manifest, err := model.Read(content)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(content, doc); err != nil {
+		return nil, err
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manifest{
+		Path:     path,
+		Root:     findRepositoryRoot(dir),
+		Content:  content,
+		Manifest: manifest,
+		doc:      doc,
+	}, nil
+}
+
+// Linter runs a set of rules over okteto manifests
+type Linter struct {
+	rules  []Rule
+	config *Config
+}
+
+// NewLinter returns a linter with the default rules and the given configuration
+func NewLinter(config *Config) *Linter {
+	if config == nil {
+		config = &Config{}
+	}
+	return &Linter{rules: Rules(), config: config}
+}
+
+// Rules returns the rules enabled by the linter
+func (l *Linter) Rules() []Rule {
+	var result []Rule
+	for _, r := range l.rules {
+		if !l.config.isDisabled(r) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Lint returns the issues of the manifest sorted by position, skipping the rules disabled in the config or inline
+func (l *Linter) Lint(m *Manifest) []Issue {
+	directives := parseDirectives(m.Content)
+	var result []Issue
+	for _, r := range l.Rules() {
+		for _, issue := range r.Check(m) {
+			issue.RuleID = r.ID
+			issue.Severity = l.config.severity(r)
+			issue.Line, issue.Column = m.position(issue.Path)
+			if directives.isDisabled(r, issue.Line) {
+				continue
+			}
+			result = append(result, issue)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Line != result[j].Line {
+			return result[i].Line < result[j].Line
+		}
+		return result[i].Column < result[j].Column
+	})
+	return result
+}
+
+// HasErrors returns if any of the issues has the error severity
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matches(idOrName string) bool {
+	return r.ID == idOrName || r.Name == idOrName
+}
+
+func (r Rule) String() string {
+	return fmt.Sprintf("%s (%s)", r.ID, r.Name)
+}
+
+// findRepositoryRoot returns the closest folder with a git repository, or dir if there is none
+func findRepositoryRoot(dir string) string {
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestManifest(t *testing.T, content string) *Manifest {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0700))
+	path := filepath.Join(dir, "okteto.yml")
+	m, err := NewManifest(path, []byte(content))
+	require.NoError(t, err)
+	return m
+}
+
+func ruleIDs(issues []Issue) []string {
+	var result []string
+	for _, issue := range issues {
+		result = append(result, issue.RuleID)
+	}
+	return result
+}
+
+func TestLint(t *testing.T) {
+	m := newTestManifest(t, `dev:
+  api:
+    image: okteto/golang:1
+    healthchecks: true
+    sync:
+      - ../secrets:/secrets
+    forward:
+      - 8080:80
+      - 8080:81
+`)
+	issues := NewLinter(nil).Lint(m)
+	assert.Equal(t, []Issue{
+		{RuleID: "OKL006", Severity: SeverityInfo, Message: "development container 'api' has no cpu and memory limits", Path: "dev.api", Line: 2, Column: 3},
+		{RuleID: "OKL003", Severity: SeverityWarning, Message: "the field 'healthchecks' is deprecated, use the field 'probes' instead", Path: "dev.api.healthchecks", Line: 4, Column: 19},
+		{RuleID: "OKL001", Severity: SeverityError, Message: "sync path '../secrets' is outside of the repository '" + m.Root + "'", Path: "dev.api.sync[0]", Line: 6, Column: 9},
+		{RuleID: "OKL002", Severity: SeverityError, Message: "local port 8080 is forwarded more than once, it's also used by the forward to remote port 80", Path: "dev.api.forward[1]", Line: 9, Column: 9},
+	}, issues)
+	assert.True(t, HasErrors(issues))
+}
+
+func TestLintConfig(t *testing.T) {
+	m := newTestManifest(t, `dev:
+  api:
+    image: okteto/golang
+    healthchecks: true
+`)
+	c := &Config{
+		Disable:  []string{"OKL006", "deprecated-field"},
+		Severity: map[string]Severity{"latest-image": SeverityError},
+	}
+	issues := NewLinter(c).Lint(m)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "OKL005", issues[0].RuleID)
+	assert.Equal(t, SeverityError, issues[0].Severity)
+}
+
+func TestLintInlineDirectives(t *testing.T) {
+	m := newTestManifest(t, `# okteto-lint-disable OKL006
+dev:
+  api:
+    # okteto-lint-disable-next-line latest-image
+    image: okteto/golang
+  worker:
+    image: okteto/golang # okteto-lint-disable-line
+    healthchecks: true
+  front:
+    image: okteto/node
+`)
+	issues := NewLinter(nil).Lint(m)
+	assert.Equal(t, []string{"OKL003", "OKL005"}, ruleIDs(issues))
+	assert.Equal(t, 10, issues[1].Line)
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	c, err := LoadConfig(filepath.Join(dir, ConfigFile))
+	require.NoError(t, err)
+	assert.Equal(t, &Config{}, c)
+
+	path := filepath.Join(dir, "valid.yml")
+	require.NoError(t, os.WriteFile(path, []byte("disable: [OKL001]\nseverity:\n  OKL002: warning\n"), 0600))
+	c, err = LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, &Config{Disable: []string{"OKL001"}, Severity: map[string]Severity{"OKL002": SeverityWarning}}, c)
+
+	path = filepath.Join(dir, "invalid-severity.yml")
+	require.NoError(t, os.WriteFile(path, []byte("severity:\n  OKL002: fatal\n"), 0600))
+	_, err = LoadConfig(path)
+	assert.Error(t, err)
+
+	path = filepath.Join(dir, "unknown-field.yml")
+	require.NoError(t, os.WriteFile(path, []byte("ignore: [OKL001]\n"), 0600))
+	_, err = LoadConfig(path)
+	assert.Error(t, err)
+}
+
+func TestParseDirectives(t *testing.T) {
+	d := parseDirectives([]byte(`# okteto-lint-disable OKL001, OKL002
+a: b # okteto-lint-disable-line
+# okteto-lint-disable-next-line latest-image
+c: d
+e: f # not a directive okteto-lint-disable-foo`))
+	assert.Equal(t, directives{0: {"OKL001", "OKL002"}, 2: {allRules}, 4: {"latest-image"}}, d)
+}
+
+func TestLookup(t *testing.T) {
+	m := newTestManifest(t, `dev:
+  api:
+    sync:
+      - .:/app
+    forward:
+      - localPort: 8080
+        remotePort: 80
+`)
+	var tests = []struct {
+		path   string
+		line   int
+		column int
+		found  bool
+	}{
+		{path: "dev.api", line: 2, column: 3, found: true},
+		{path: "dev.api.sync[0]", line: 4, column: 9, found: true},
+		{path: "dev.api.forward[0].localPort", line: 6, column: 20, found: true},
+		{path: "dev.api.sync[1]", line: 3, column: 5, found: false},
+		{path: "dev.worker.image", line: 1, column: 1, found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			n, found := m.lookup(tt.path)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.line, n.Line)
+			assert.Equal(t, tt.column, n.Column)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testIssues = []Issue{
+	{RuleID: "OKL005", Severity: SeverityWarning, Message: "image 'okteto/golang' uses the 'latest' tag", Path: "dev.api.image", Line: 3, Column: 12},
+	{RuleID: "OKL006", Severity: SeverityInfo, Message: "no limits"},
+}
+
+func TestWriteText(t *testing.T) {
+	b := &bytes.Buffer{}
+	require.NoError(t, WriteText(b, "okteto.yml", testIssues))
+	assert.Equal(t, `okteto.yml:3:12: warning: image 'okteto/golang' uses the 'latest' tag [OKL005]
+okteto.yml: info: no limits [OKL006]
+`, b.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	b := &bytes.Buffer{}
+	require.NoError(t, WriteJSON(b, "okteto.yml", nil))
+	assert.JSONEq(t, `{"file": "okteto.yml", "issues": []}`, b.String())
+}
+
+func TestWriteSARIF(t *testing.T) {
+	b := &bytes.Buffer{}
+	require.NoError(t, WriteSARIF(b, "okteto.yml", "2.20.0", Rules(), testIssues))
+
+	var result sarifLog
+	require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+	assert.Equal(t, sarifVersion, result.Version)
+	require.Len(t, result.Runs, 1)
+	run := result.Runs[0]
+	assert.Equal(t, "2.20.0", run.Tool.Driver.Version)
+	assert.Len(t, run.Tool.Driver.Rules, len(Rules()))
+	require.Len(t, run.Results, 2)
+
+	assert.Equal(t, "OKL005", run.Results[0].RuleID)
+	assert.Equal(t, 4, run.Results[0].RuleIndex)
+	assert.Equal(t, "warning", run.Results[0].Level)
+	assert.Equal(t, &sarifRegion{StartLine: 3, StartColumn: 12}, run.Results[0].Locations[0].PhysicalLocation.Region)
+
+	assert.Equal(t, "note", run.Results[1].Level)
+	assert.Nil(t, run.Results[1].Locations[0].PhysicalLocation.Region)
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// position returns the line and column of the deepest node of the manifest found in path
+func (m *Manifest) position(path string) (int, int) {
+	n, _ := m.lookup(path)
+	if n == nil {
+		return 0, 0
+	}
+	return n.Line, n.Column
+}
+
+// exists returns if the manifest has a node at path
+func (m *Manifest) exists(path string) bool {
+	_, found := m.lookup(path)
+	return found
+}
+
+// firstPath returns the first of the paths that exists in the manifest, or the last one
+func (m *Manifest) firstPath(paths ...string) string {
+	for _, path := range paths {
+		if m.exists(path) {
+			return path
+		}
+	}
+	return paths[len(paths)-1]
+}
+
+// lookup returns the deepest node of the manifest found in path, and if the whole path was found.
+// Paths are keys separated by dots, with list indexes between brackets: 'dev.api.sync[0]'
+func (m *Manifest) lookup(path string) (*yaml.Node, bool) {
+	n := m.doc
+	if n != nil && n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	if n == nil {
+		return nil, false
+	}
+
+	for _, segment := range splitPath(path) {
+		var next *yaml.Node
+		if index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(segment, "["), "]")); err == nil && strings.HasPrefix(segment, "[") {
+			if n.Kind == yaml.SequenceNode && index < len(n.Content) {
+				next = n.Content[index]
+			}
+		} else if n.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value == segment {
+					// issues on scalars are reported at the value, and the ones on maps and lists at the key
+					next = n.Content[i+1]
+					if next.Kind != yaml.ScalarNode {
+						next = &yaml.Node{Kind: next.Kind, Content: next.Content, Line: n.Content[i].Line, Column: n.Content[i].Column}
+					}
+					break
+				}
+			}
+		}
+		if next == nil {
+			return n, false
+		}
+		n = next
+	}
+	return n, true
+}
+
+// splitPath returns the keys and indexes of a path
+func splitPath(path string) []string {
+	var result []string
+	for _, key := range strings.Split(path, ".") {
+		if key == "" {
+			continue
+		}
+		if i := strings.Index(key, "["); i >= 0 {
+			if i > 0 {
+				result = append(result, key[:i])
+			}
+			for _, index := range strings.SplitAfter(key[i:], "]") {
+				if index != "" {
+					result = append(result, index)
+				}
+			}
+			continue
+		}
+		result = append(result, key)
+	}
+	return result
+}
@@ -0,0 +1,320 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/model"
+	"gopkg.in/yaml.v3"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// Rules returns all the rules of the linter
+func Rules() []Rule {
+	return []Rule{
+		{
+			ID:          "OKL001",
+			Name:        "sync-outside-repository",
+			Severity:    SeverityError,
+			Description: "Local sync paths must be inside the repository of the manifest",
+			Check:       checkSyncOutsideRepository,
+		},
+		{
+			ID:          "OKL002",
+			Name:        "forward-collision",
+			Severity:    SeverityError,
+			Description: "Port forwards of a development container must use different local ports",
+			Check:       checkForwardCollisions,
+		},
+		{
+			ID:          "OKL003",
+			Name:        "deprecated-field",
+			Severity:    SeverityWarning,
+			Description: "Deprecated fields will be removed in a future version",
+			Check:       checkDeprecatedFields,
+		},
+		{
+			ID:          "OKL004",
+			Name:        "unused-build",
+			Severity:    SeverityWarning,
+			Description: "Images of the build section should be referenced by a development container or the deploy commands",
+			Check:       checkUnusedBuilds,
+		},
+		{
+			ID:          "OKL005",
+			Name:        "latest-image",
+			Severity:    SeverityWarning,
+			Description: "Images should be pinned to a tag other than 'latest'",
+			Check:       checkLatestImages,
+		},
+		{
+			ID:          "OKL006",
+			Name:        "missing-resource-limits",
+			Severity:    SeverityInfo,
+			Description: "Development containers should define CPU and memory limits",
+			Check:       checkResourceLimits,
+		},
+	}
+}
+
+// devContainer is a development container of the manifest, or one of its services
+type devContainer struct {
+	dev  *model.Dev
+	path string
+}
+
+// devContainers returns the development containers of the manifest sorted by name, with the path of their definition
+func (m *Manifest) devContainers() []devContainer {
+	names := make([]string, 0, len(m.Manifest.Dev))
+	for name := range m.Manifest.Dev {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []devContainer
+	for _, name := range names {
+		dev := m.Manifest.Dev[name]
+		path := ""
+		if m.Manifest.IsV2 {
+			path = fmt.Sprintf("dev.%s", name)
+		}
+		result = append(result, devContainer{dev: dev, path: path})
+		for i, svc := range dev.Services {
+			result = append(result, devContainer{dev: svc, path: joinPath(path, fmt.Sprintf("services[%d]", i))})
+		}
+	}
+	return result
+}
+
+func checkSyncOutsideRepository(m *Manifest) []Issue {
+	var result []Issue
+	manifestDir, err := filepath.Abs(filepath.Dir(m.Path))
+	if err != nil {
+		return nil
+	}
+	for _, d := range m.devContainers() {
+		for i, folder := range d.dev.Sync.Folders {
+			if folder.LocalPath == "" || strings.Contains(folder.LocalPath, "$") {
+				continue
+			}
+			localPath := folder.LocalPath
+			if !filepath.IsAbs(localPath) {
+				localPath = filepath.Join(manifestDir, localPath)
+			}
+			rel, err := filepath.Rel(m.Root, localPath)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				result = append(result, Issue{
+					Message: fmt.Sprintf("sync path '%s' is outside of the repository '%s'", folder.LocalPath, m.Root),
+					Path:    m.firstPath(joinPath(d.path, fmt.Sprintf("sync[%d]", i)), joinPath(d.path, fmt.Sprintf("sync.folders[%d]", i))),
+				})
+			}
+		}
+	}
+	return result
+}
+
+func checkForwardCollisions(m *Manifest) []Issue {
+	var result []Issue
+	for _, d := range m.devContainers() {
+		// global forwards are added to every development container
+		used := map[string]string{}
+		for _, f := range m.Manifest.GlobalForward {
+			if f.Local != 0 {
+				used[fmt.Sprintf("%d/tcp", f.Local)] = "the global forward section"
+			}
+		}
+		occurrences := map[int]int{}
+		for _, f := range d.dev.Forward {
+			if f.IsAuto() || f.LocalSocket != "" {
+				continue
+			}
+			protocol := f.Protocol
+			if protocol == "" {
+				protocol = "tcp"
+			}
+			key := fmt.Sprintf("%d/%s", f.Local, protocol)
+			occurrences[f.Local]++
+			if previous, ok := used[key]; ok {
+				result = append(result, Issue{
+					Message: fmt.Sprintf("local port %d is forwarded more than once, it's also used by %s", f.Local, previous),
+					Path:    m.forwardPath(joinPath(d.path, "forward"), f.Local, occurrences[f.Local]),
+				})
+				continue
+			}
+			used[key] = fmt.Sprintf("the forward to remote port %d", f.Remote)
+		}
+	}
+	return result
+}
+
+// forwardPath returns the path of the nth forward of the local port in the list at path.
+// Forwards are sorted when the manifest is read, so they are found by their local port
+func (m *Manifest) forwardPath(path string, local, nth int) string {
+	n, found := m.lookup(path)
+	if !found || n.Kind != yaml.SequenceNode {
+		return path
+	}
+	for i, item := range n.Content {
+		if forwardLocalPort(item) != strconv.Itoa(local) {
+			continue
+		}
+		if nth--; nth == 0 {
+			return fmt.Sprintf("%s[%d]", path, i)
+		}
+	}
+	return path
+}
+
+// forwardLocalPort returns the local port of a forward written as 'local:remote' or with the extended syntax
+func forwardLocalPort(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		local, _, _ := strings.Cut(n.Value, ":")
+		return strings.TrimSpace(local)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == "localPort" {
+				return n.Content[i+1].Value
+			}
+		}
+	}
+	return ""
+}
+
+func checkDeprecatedFields(m *Manifest) []Issue {
+	var result []Issue
+	if m.exists("devs") {
+		result = append(result, Issue{Message: "the field 'devs' is deprecated, use the field 'dev' instead", Path: "devs"})
+	}
+	for _, d := range m.devContainers() {
+		if d.dev.Healthchecks {
+			result = append(result, Issue{Message: "the field 'healthchecks' is deprecated, use the field 'probes' instead", Path: joinPath(d.path, "healthchecks")})
+		}
+		if len(d.dev.Labels) > 0 {
+			result = append(result, Issue{Message: "the field 'labels' is deprecated, use the field 'selector' instead", Path: joinPath(d.path, "labels")})
+		}
+		if len(d.dev.Annotations) > 0 {
+			result = append(result, Issue{Message: "the field 'annotations' is deprecated, use the field 'metadata.annotations' instead", Path: joinPath(d.path, "annotations")})
+		}
+		// the context and dockerfile of images have default values, the syntax is checked in the manifest file
+		if m.exists(joinPath(d.path, "image.context")) || m.exists(joinPath(d.path, "image.dockerfile")) {
+			result = append(result, Issue{Message: "the 'image' extended syntax is deprecated, define the image in the 'build' section instead", Path: joinPath(d.path, "image")})
+		}
+	}
+	return result
+}
+
+func checkUnusedBuilds(m *Manifest) []Issue {
+	// images of compose services are referenced by the compose file
+	if m.Manifest.Deploy != nil && m.Manifest.Deploy.ComposeSection != nil {
+		return nil
+	}
+
+	var result []Issue
+	names := make([]string, 0, len(m.Manifest.Build))
+	for name := range m.Manifest.Build {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if isBuildReferenced(m, name) {
+			continue
+		}
+		result = append(result, Issue{
+			Message: fmt.Sprintf("image '%s' is built but never used, reference it with '${OKTETO_BUILD_%s_IMAGE}'", name, buildEnvName(name)),
+			Path:    fmt.Sprintf("build.%s", name),
+		})
+	}
+	return result
+}
+
+func isBuildReferenced(m *Manifest, name string) bool {
+	if bytes.Contains(m.Content, []byte(fmt.Sprintf("OKTETO_BUILD_%s_", buildEnvName(name)))) {
+		return true
+	}
+	// development containers use the image of the build section with their name
+	if _, ok := m.Manifest.Dev[name]; ok {
+		return true
+	}
+	for _, b := range m.Manifest.Build {
+		for _, dependency := range b.DependsOn {
+			if dependency == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func buildEnvName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+func checkLatestImages(m *Manifest) []Issue {
+	var result []Issue
+	for _, d := range m.devContainers() {
+		if d.dev.Image == nil || !usesLatestTag(d.dev.Image.Name) {
+			continue
+		}
+		result = append(result, Issue{
+			Message: fmt.Sprintf("image '%s' uses the 'latest' tag, pin it to a specific version", d.dev.Image.Name),
+			Path:    m.firstPath(joinPath(d.path, "image.name"), joinPath(d.path, "image")),
+		})
+	}
+	return result
+}
+
+// usesLatestTag returns if the image has the tag 'latest' or no tag nor digest
+func usesLatestTag(image string) bool {
+	if image == "" || strings.Contains(image, "$") || strings.Contains(image, "@") {
+		return false
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	i := strings.LastIndex(name, ":")
+	return i < 0 || name[i+1:] == "latest"
+}
+
+func checkResourceLimits(m *Manifest) []Issue {
+	var result []Issue
+	for _, d := range m.devContainers() {
+		var missing []string
+		for _, resource := range []apiv1.ResourceName{apiv1.ResourceCPU, apiv1.ResourceMemory} {
+			if _, ok := d.dev.Resources.Limits[resource]; !ok {
+				missing = append(missing, string(resource))
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		name := d.dev.Name
+		result = append(result, Issue{
+			Message: fmt.Sprintf("development container '%s' has no %s limits", name, strings.Join(missing, " and ")),
+			Path:    m.firstPath(joinPath(d.path, "resources"), d.path),
+		})
+	}
+	return result
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
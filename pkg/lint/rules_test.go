@@ -0,0 +1,96 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUnusedBuilds(t *testing.T) {
+	m := newTestManifest(t, `build:
+  api:
+    context: api
+  base:
+    context: base
+  worker:
+    context: worker
+    depends_on: [base]
+  unused:
+    context: unused
+deploy:
+  - helm upgrade --install app chart --set worker=${OKTETO_BUILD_WORKER_IMAGE}
+dev:
+  api:
+    command: bash
+`)
+	issues := checkUnusedBuilds(m)
+	assert.Equal(t, []Issue{{
+		Message: "image 'unused' is built but never used, reference it with '${OKTETO_BUILD_UNUSED_IMAGE}'",
+		Path:    "build.unused",
+	}}, issues)
+}
+
+func TestCheckForwardCollisionsWithGlobalForwards(t *testing.T) {
+	m := newTestManifest(t, `forward:
+  - 5432:postgres:5432
+dev:
+  api:
+    forward:
+      - 8080:80
+      - 5432:5432
+      - localPort: auto
+        remotePort: 9000
+      - localPort: auto
+        remotePort: 9001
+`)
+	issues := checkForwardCollisions(m)
+	assert.Equal(t, []Issue{{
+		Message: "local port 5432 is forwarded more than once, it's also used by the global forward section",
+		Path:    "dev.api.forward[1]",
+	}}, issues)
+}
+
+func TestCheckDeprecatedFieldsV1(t *testing.T) {
+	m := newTestManifest(t, `name: api
+image:
+  context: .
+labels:
+  app: api
+`)
+	issues := checkDeprecatedFields(m)
+	assert.Equal(t, []string{"labels", "image"}, []string{issues[0].Path, issues[1].Path})
+}
+
+func TestUsesLatestTag(t *testing.T) {
+	var tests = []struct {
+		image    string
+		expected bool
+	}{
+		{image: "okteto/golang", expected: true},
+		{image: "okteto/golang:latest", expected: true},
+		{image: "localhost:5000/golang", expected: true},
+		{image: "okteto/golang:1", expected: false},
+		{image: "localhost:5000/golang:1.20", expected: false},
+		{image: "okteto/golang@sha256:abc", expected: false},
+		{image: "${OKTETO_BUILD_API_IMAGE}", expected: false},
+		{image: "", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.expected, usesLatestTag(tt.image))
+		})
+	}
+}
@@ -18,6 +18,9 @@ const (
 	// DevCloneLabel indicates it is a dev pod clone
 	DevCloneLabel = "dev.okteto.com/clone"
 
+	// SideBySideLabel indicates the dev clone runs next to the original workload
+	SideBySideLabel = "dev.okteto.com/side-by-side"
+
 	// AppReplicasAnnotation indicates the number of replicas before dev mode was activated
 	AppReplicasAnnotation = "dev.okteto.com/replicas"
 
@@ -134,6 +137,21 @@ const (
 	SyncthingSubPath = "syncthing"
 	// DefaultSyncthingRescanInterval default syncthing re-scan interval
 	DefaultSyncthingRescanInterval = 300
+
+	// SyncEngineSyncthing synchronizes files with syncthing
+	SyncEngineSyncthing = "syncthing"
+
+	// SyncEngineSSH synchronizes files over the SSH connection of the development container
+	SyncEngineSSH = "ssh"
+
+	// SyncDirectionBoth synchronizes the changes of the local and the remote folder
+	SyncDirectionBoth = "both"
+
+	// SyncDirectionUp only synchronizes the changes of the local folder to the remote folder
+	SyncDirectionUp = "up"
+
+	// SyncDirectionDown only synchronizes the changes of the remote folder to the local folder
+	SyncDirectionDown = "down"
 	// RemoteSubPath subpath in the development container persistent volume for the remote data
 	RemoteSubPath = "okteto-remote"
 	// OktetoAutoCreateAnnotation indicates if the deployment was auto generatted by okteto up
@@ -156,6 +174,9 @@ const (
 	// this path is expected by remote
 	authorizedKeysPath = "/var/okteto/remote/authorized_keys"
 
+	// oktetoSecretMountPath is the path where the secrets of the development container are mounted
+	oktetoSecretMountPath = "/var/okteto/secret"
+
 	syncFieldDocsURL = "https://okteto.com/docs/reference/manifest/#sync-string-required"
 
 	// HelmSecretType indicates the type for secrets created by Helm
@@ -218,6 +239,9 @@ const (
 	// OktetoSSHTimeoutEnvVar defines the timeout for ssh operations
 	OktetoSSHTimeoutEnvVar = "OKTETO_SSH_TIMEOUT"
 
+	// OktetoSSHKeyTypeEnvVar defines the type of the ssh keys generated by okteto
+	OktetoSSHKeyTypeEnvVar = "OKTETO_SSH_KEY_TYPE"
+
 	// OktetoRescanIntervalEnvVar defines the time between scans for syncthing
 	OktetoRescanIntervalEnvVar = "OKTETO_RESCAN_INTERVAL"
 
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -28,6 +29,7 @@ import (
 	"github.com/a8m/envsubst"
 	"github.com/compose-spec/godotenv"
 	"github.com/google/uuid"
+	"github.com/kballard/go-shellquote"
 	"github.com/okteto/okteto/pkg/cache"
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -52,34 +54,37 @@ var (
 
 // Dev represents a development container
 type Dev struct {
-	Name                 string             `json:"name,omitempty" yaml:"name,omitempty"`
-	Username             string             `json:"-" yaml:"-"`
-	RegistryURL          string             `json:"-" yaml:"-"`
-	Selector             Selector           `json:"selector,omitempty" yaml:"selector,omitempty"`
-	Annotations          Annotations        `json:"annotations,omitempty" yaml:"annotations,omitempty"`
-	Tolerations          []apiv1.Toleration `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
-	Context              string             `json:"context,omitempty" yaml:"context,omitempty"`
-	Namespace            string             `json:"namespace,omitempty" yaml:"namespace,omitempty"`
-	Container            string             `json:"container,omitempty" yaml:"container,omitempty"`
-	EmptyImage           bool               `json:"-" yaml:"-"`
-	Image                *BuildInfo         `json:"image,omitempty" yaml:"image,omitempty"`
-	Push                 *BuildInfo         `json:"-" yaml:"push,omitempty"`
-	ImagePullPolicy      apiv1.PullPolicy   `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
-	Secrets              []Secret           `json:"secrets,omitempty" yaml:"secrets,omitempty"`
-	Command              Command            `json:"command,omitempty" yaml:"command,omitempty"`
-	Args                 Command            `json:"args,omitempty" yaml:"args,omitempty"`
-	Probes               *Probes            `json:"probes,omitempty" yaml:"probes,omitempty"`
-	Lifecycle            *Lifecycle         `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
-	Workdir              string             `json:"workdir,omitempty" yaml:"workdir,omitempty"`
-	SecurityContext      *SecurityContext   `json:"securityContext,omitempty" yaml:"securityContext,omitempty"`
-	ServiceAccount       string             `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
-	RemotePort           int                `json:"remote,omitempty" yaml:"remote,omitempty"`
-	SSHServerPort        int                `json:"sshServerPort,omitempty" yaml:"sshServerPort,omitempty"`
-	ExternalVolumes      []ExternalVolume   `json:"externalVolumes,omitempty" yaml:"externalVolumes,omitempty"`
-	Sync                 Sync               `json:"sync,omitempty" yaml:"sync,omitempty"`
+	Name                 string           `json:"name,omitempty" yaml:"name,omitempty"`
+	Extends              string           `json:"extends,omitempty" yaml:"extends,omitempty"`
+	Username             string           `json:"-" yaml:"-"`
+	RegistryURL          string           `json:"-" yaml:"-"`
+	Selector             Selector         `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Annotations          Annotations      `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	Tolerations          Tolerations      `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
+	Context              string           `json:"context,omitempty" yaml:"context,omitempty"`
+	Namespace            string           `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Container            string           `json:"container,omitempty" yaml:"container,omitempty"`
+	EmptyImage           bool             `json:"-" yaml:"-"`
+	Image                *BuildInfo       `json:"image,omitempty" yaml:"image,omitempty"`
+	Push                 *BuildInfo       `json:"-" yaml:"push,omitempty"`
+	ImagePullPolicy      apiv1.PullPolicy `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
+	Secrets              []Secret         `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+	Command              Command          `json:"command,omitempty" yaml:"command,omitempty"`
+	Args                 Command          `json:"args,omitempty" yaml:"args,omitempty"`
+	Probes               *Probes          `json:"probes,omitempty" yaml:"probes,omitempty"`
+	Lifecycle            *Lifecycle       `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+	Workdir              string           `json:"workdir,omitempty" yaml:"workdir,omitempty"`
+	SecurityContext      *SecurityContext `json:"securityContext,omitempty" yaml:"securityContext,omitempty"`
+	ServiceAccount       string           `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+	RemotePort           int              `json:"remote,omitempty" yaml:"remote,omitempty"`
+	SSHServerPort        int              `json:"sshServerPort,omitempty" yaml:"sshServerPort,omitempty"`
+	ExternalVolumes      []ExternalVolume `json:"externalVolumes,omitempty" yaml:"externalVolumes,omitempty"`
+	Sync                 Sync             `json:"sync,omitempty" yaml:"sync,omitempty"`
 	parentSyncFolder     string
 	Forward              []forward.Forward     `json:"forward,omitempty" yaml:"forward,omitempty"`
 	Reverse              []Reverse             `json:"reverse,omitempty" yaml:"reverse,omitempty"`
+	Proxy                int                   `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	SSHAgent             bool                  `json:"sshAgent,omitempty" yaml:"sshAgent,omitempty"`
 	Interface            string                `json:"interface,omitempty" yaml:"interface,omitempty"`
 	Resources            ResourceRequirements  `json:"resources,omitempty" yaml:"resources,omitempty"`
 	Services             []*Dev                `json:"services,omitempty" yaml:"services,omitempty"`
@@ -95,6 +100,7 @@ type Dev struct {
 	Environment          Environment           `json:"environment,omitempty" yaml:"environment,omitempty"`
 	Volumes              []Volume              `json:"volumes,omitempty" yaml:"volumes,omitempty"`
 	Mode                 string                `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Replace              *bool                 `json:"replace,omitempty" yaml:"replace,omitempty"`
 
 	Replicas *int `json:"replicas,omitempty" yaml:"replicas,omitempty"`
 	// Deprecated fields
@@ -104,6 +110,9 @@ type Dev struct {
 
 type Affinity apiv1.Affinity
 
+// Tolerations represents the tolerations of a development container
+type Tolerations []apiv1.Toleration
+
 // Entrypoint represents the start command of a development container
 type Entrypoint struct {
 	Values []string
@@ -232,15 +241,27 @@ type Sync struct {
 	Compression    bool         `json:"compression" yaml:"compression"`
 	Verbose        bool         `json:"verbose" yaml:"verbose"`
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
+	Engine         string       `json:"engine,omitempty" yaml:"engine,omitempty"`
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
+	Hooks          []SyncHook   `json:"hooks,omitempty" yaml:"hooks,omitempty"`
 	LocalPath      string
 	RemotePath     string
 }
 
+// SyncHook represents a command executed in the development container after a folder is synchronized
+type SyncHook struct {
+	Name     string        `json:"name,omitempty" yaml:"name,omitempty"`
+	Command  Command       `json:"command,omitempty" yaml:"command,omitempty"`
+	Paths    []string      `json:"paths,omitempty" yaml:"paths,omitempty"`
+	Debounce time.Duration `json:"debounce,omitempty" yaml:"debounce,omitempty"`
+	Restart  bool          `json:"restart,omitempty" yaml:"restart,omitempty"`
+}
+
 // SyncFolder represents a sync folder in the development container
 type SyncFolder struct {
 	LocalPath  string
 	RemotePath string
+	Direction  string `json:"direction,omitempty" yaml:"direction,omitempty"`
 }
 
 // ExternalVolume represents a external volume in the development container
@@ -312,8 +333,11 @@ type Secret struct {
 
 // Reverse represents a remote forward port
 type Reverse struct {
-	Remote int
-	Local  int
+	Remote       int
+	Local        int
+	Protocol     string
+	RemoteSocket string
+	LocalSocket  string
 }
 
 // ResourceRequirements describes the compute resource requirements.
@@ -362,7 +386,7 @@ func Get(devPath string) (*Manifest, error) {
 
 	manifest, err := Read(b)
 	if err != nil {
-		return nil, err
+		return nil, setYAMLErrorFile(err, devPath)
 	}
 
 	for _, dev := range manifest.Dev {
@@ -422,6 +446,7 @@ func (dev *Dev) loadAbsPaths(devPath string) error {
 	}
 
 	dev.loadVolumeAbsPaths(devDir)
+	dev.loadSocketAbsPaths(devDir)
 	for _, s := range dev.Services {
 		s.loadVolumeAbsPaths(devDir)
 	}
@@ -440,6 +465,20 @@ func (dev *Dev) loadVolumeAbsPaths(folder string) {
 	}
 }
 
+// loadSocketAbsPaths makes the local sockets of forwards relative to the manifest folder
+func (dev *Dev) loadSocketAbsPaths(folder string) {
+	for i := range dev.Forward {
+		if dev.Forward[i].LocalSocket != "" {
+			dev.Forward[i].LocalSocket = loadAbsPath(folder, dev.Forward[i].LocalSocket)
+		}
+	}
+	for i := range dev.Reverse {
+		if dev.Reverse[i].LocalSocket != "" {
+			dev.Reverse[i].LocalSocket = loadAbsPath(folder, dev.Reverse[i].LocalSocket)
+		}
+	}
+}
+
 func loadAbsPath(folder, path string) string {
 	if filepath.IsAbs(path) {
 		return path
@@ -529,6 +568,11 @@ func (dev *Dev) IsHybridModeEnabled() bool {
 	return dev.Mode == constants.OktetoHybridModeFieldValue
 }
 
+// IsSideBySide returns true if the dev container runs next to the original workload instead of replacing it
+func (dev *Dev) IsSideBySide() bool {
+	return dev.Replace != nil && !*dev.Replace
+}
+
 func (dev *Dev) SetDefaults() error {
 	if dev.Command.Values == nil {
 		dev.Command.Values = []string{"sh"}
@@ -601,6 +645,15 @@ func (dev *Dev) SetDefaults() error {
 		dev.Sync.RescanInterval = DefaultSyncthingRescanInterval
 	}
 
+	// the ssh sync engine only sends the local changes to the development container
+	if dev.Sync.Engine == SyncEngineSSH {
+		for i := range dev.Sync.Folders {
+			if dev.Sync.Folders[i].Direction == "" {
+				dev.Sync.Folders[i].Direction = SyncDirectionUp
+			}
+		}
+	}
+
 	for _, s := range dev.Services {
 		if s.ImagePullPolicy == "" {
 			s.ImagePullPolicy = apiv1.PullAlways
@@ -797,6 +850,14 @@ func (dev *Dev) Validate() error {
 		return fmt.Errorf("'sshServerPort' must be > 0")
 	}
 
+	if dev.Proxy < 0 || dev.Proxy > 65535 {
+		return fmt.Errorf("'proxy' must be a port between 1 and 65535")
+	}
+
+	if dev.IsSideBySide() && dev.Autocreate {
+		return fmt.Errorf("'replace: false' is not supported when 'autocreate' is enabled")
+	}
+
 	for _, s := range dev.Services {
 		if err := validatePullPolicy(s.ImagePullPolicy); err != nil {
 			return err
@@ -810,6 +871,28 @@ func (dev *Dev) Validate() error {
 }
 
 func (dev *Dev) validateSync() error {
+	switch dev.Sync.Engine {
+	case "", SyncEngineSyncthing:
+	case SyncEngineSSH:
+		if dev.IsHybridModeEnabled() {
+			return fmt.Errorf("'sync.engine: %s' is not supported in hybrid mode", SyncEngineSSH)
+		}
+		// the ssh sync engine only sends the local changes to the development container,
+		// so the folders can't be bidirectional or download the remote changes
+		for _, folder := range dev.Sync.Folders {
+			if folder.Direction != SyncDirectionUp {
+				return fmt.Errorf("'direction: %s' of the sync folder '%s' is not supported by 'sync.engine: %s': it only sends your local changes to the development container, set 'direction: %s'", folder.GetDirection(), folder.LocalPath, SyncEngineSSH, SyncDirectionUp)
+			}
+		}
+	default:
+		return fmt.Errorf("'sync.engine' must be '%s' or '%s'", SyncEngineSyncthing, SyncEngineSSH)
+	}
+	if err := validateSyncHooks(dev.Sync.Hooks); err != nil {
+		return err
+	}
+	if err := dev.validateSyncFolderDirections(); err != nil {
+		return err
+	}
 	for _, folder := range dev.Sync.Folders {
 		validPath, err := os.Stat(folder.LocalPath)
 
@@ -838,6 +921,50 @@ func (dev *Dev) validateSync() error {
 	return nil
 }
 
+func validateSyncHooks(hooks []SyncHook) error {
+	seen := map[string]bool{}
+	for i, hook := range hooks {
+		name := hook.GetName(i)
+		if seen[name] {
+			return fmt.Errorf("sync hooks with the same name '%s' are not supported", name)
+		}
+		seen[name] = true
+		if len(hook.Command.Values) == 0 {
+			return fmt.Errorf("the field 'command' of the sync hook '%s' cannot be empty", name)
+		}
+		if hook.Debounce < 0 {
+			return fmt.Errorf("the field 'debounce' of the sync hook '%s' must be a positive duration", name)
+		}
+		for _, p := range hook.Paths {
+			if _, err := path.Match(p, ""); err != nil {
+				return fmt.Errorf("the path '%s' of the sync hook '%s' is not a valid glob pattern", p, name)
+			}
+		}
+	}
+	return nil
+}
+
+// GetDirection returns the direction of the synchronization of the folder, 'both' if it is not defined
+func (s *SyncFolder) GetDirection() string {
+	if s.Direction == "" {
+		return SyncDirectionBoth
+	}
+	return s.Direction
+}
+
+// IsSSHSyncEngine returns true if the files are synchronized over the SSH connection instead of syncthing
+func (s *Sync) IsSSHSyncEngine() bool {
+	return s.Engine == SyncEngineSSH
+}
+
+// GetName returns the name of the sync hook, or a name derived from its position if it is not defined
+func (h *SyncHook) GetName(index int) string {
+	if h.Name != "" {
+		return h.Name
+	}
+	return fmt.Sprintf("hook-%d", index+1)
+}
+
 func validatePullPolicy(pullPolicy apiv1.PullPolicy) error {
 	switch pullPolicy {
 	case apiv1.PullAlways:
@@ -1067,14 +1194,16 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 				},
 			)
 		}
-		rule.Volumes = append(
-			rule.Volumes,
-			VolumeMount{
-				Name:      main.GetVolumeName(),
-				MountPath: OktetoSyncthingMountPath,
-				SubPath:   SyncthingSubPath,
-			},
-		)
+		if !dev.Sync.IsSSHSyncEngine() {
+			rule.Volumes = append(
+				rule.Volumes,
+				VolumeMount{
+					Name:      main.GetVolumeName(),
+					MountPath: OktetoSyncthingMountPath,
+					SubPath:   SyncthingSubPath,
+				},
+			)
+		}
 		if main.RemoteModeEnabled() {
 			rule.Volumes = append(
 				rule.Volumes,
@@ -1085,24 +1214,14 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 				},
 			)
 		}
-		rule.Command = []string{"/var/okteto/bin/start.sh"}
-		if main.RemoteModeEnabled() {
-			rule.Args = []string{"-r"}
-		} else {
+		if dev.Sync.IsSSHSyncEngine() {
+			// start.sh runs the remote syncthing, only the ssh server is needed by the ssh sync engine
+			rule.SyncEngine = SyncEngineSSH
+			rule.Command = []string{"sh", "-c", sshSyncEngineStartScript(rule.Secrets)}
 			rule.Args = []string{}
-		}
-		if reset {
-			rule.Args = append(rule.Args, "-e")
-		}
-		if dev.Sync.Verbose {
-			rule.Args = append(rule.Args, "-v")
-		}
-		for _, s := range rule.Secrets {
-			filename := s.GetFileName()
-			if strings.Contains(filename, ".stignore") {
-				filename = filepath.Base(s.LocalPath)
-			}
-			rule.Args = append(rule.Args, "-s", fmt.Sprintf("%s:%s", filename, s.RemotePath))
+		} else {
+			rule.Command = []string{"/var/okteto/bin/start.sh"}
+			rule.Args = translateStartArgs(main, rule.Secrets, reset)
 		}
 	} else if len(dev.Args.Values) > 0 {
 		rule.Args = dev.Args.Values
@@ -1149,6 +1268,47 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 	return rule
 }
 
+// translateStartArgs returns the arguments of the start script of the okteto/bin image
+func translateStartArgs(main *Dev, secrets []Secret, reset bool) []string {
+	args := []string{}
+	if main.RemoteModeEnabled() {
+		args = append(args, "-r")
+	}
+	if reset {
+		args = append(args, "-e")
+	}
+	if main.Sync.Verbose {
+		args = append(args, "-v")
+	}
+	for _, s := range secrets {
+		filename := s.GetFileName()
+		if strings.Contains(filename, ".stignore") {
+			filename = filepath.Base(s.LocalPath)
+		}
+		args = append(args, "-s", fmt.Sprintf("%s:%s", filename, s.RemotePath))
+	}
+	return args
+}
+
+// sshSyncEngineStartScript returns the script that copies the secrets and runs the ssh server of the okteto/bin image.
+// The '.stignore' files are read locally by the ssh sync engine, they are not copied
+func sshSyncEngineStartScript(secrets []Secret) string {
+	lines := []string{"set -e"}
+	for _, s := range secrets {
+		if strings.Contains(s.GetFileName(), ".stignore") {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf(
+			"mkdir -p %s && cp %s %s",
+			shellquote.Join(filepath.Dir(s.RemotePath)),
+			shellquote.Join(path.Join(oktetoSecretMountPath, s.GetFileName())),
+			shellquote.Join(s.RemotePath),
+		))
+	}
+	lines = append(lines, "exec /var/okteto/bin/remote")
+	return strings.Join(lines, "\n")
+}
+
 func enableHistoryVolume(rule *TranslationRule, main *Dev) {
 	rule.Volumes = append(rule.Volumes,
 		VolumeMount{
@@ -1212,12 +1372,31 @@ func (dev *Dev) RemoteModeEnabled() bool {
 		return true
 	}
 
+	if dev.Proxy > 0 {
+		return true
+	}
+
+	if dev.SSHAgent {
+		return true
+	}
+
+	for _, f := range dev.Forward {
+		if f.RequiresSSH() {
+			return true
+		}
+	}
+
 	if v, ok := os.LookupEnv(OktetoExecuteSSHEnvVar); ok && v == "false" {
 		return false
 	}
 	return true
 }
 
+// IsSocket returns true if any side of the reverse forward is an Unix socket
+func (r *Reverse) IsSocket() bool {
+	return r.LocalSocket != "" || r.RemoteSocket != ""
+}
+
 // GetKeyName returns the secret key name
 func (s *Secret) GetKeyName() string {
 	return fmt.Sprintf("dev-secret-%s", filepath.Base(s.RemotePath))
@@ -1341,6 +1520,12 @@ func (service *Dev) validateForExtraFields() error {
 	if service.Reverse != nil {
 		return fmt.Errorf(errorMessage, "reverse")
 	}
+	if service.Proxy != 0 {
+		return fmt.Errorf(errorMessage, "proxy")
+	}
+	if service.SSHAgent {
+		return fmt.Errorf(errorMessage, "sshAgent")
+	}
 	if service.Interface != "" {
 		return fmt.Errorf(errorMessage, "interface")
 	}
@@ -1359,6 +1544,12 @@ func (service *Dev) validateForExtraFields() error {
 	if service.Timeout != (Timeout{}) {
 		return fmt.Errorf(errorMessage, "timeout")
 	}
+	if service.Replace != nil {
+		return fmt.Errorf(errorMessage, "replace")
+	}
+	if service.Extends != "" {
+		return fmt.Errorf(errorMessage, "extends")
+	}
 	return nil
 }
 
@@ -1367,6 +1558,19 @@ func DevCloneName(name string) string {
 	return fmt.Sprintf("%s-okteto", name)
 }
 
+// SideBySideLabelValue returns the value of a pod label for a side-by-side dev clone
+func SideBySideLabelValue(value string) string {
+	if value == "" {
+		return value
+	}
+	// label values can't be longer than 63 characters
+	maxLength := 63 - len(DevCloneName(""))
+	if len(value) > maxLength {
+		value = strings.TrimRight(value[:maxLength], "-_.")
+	}
+	return DevCloneName(value)
+}
+
 // Copy clones the buildInfo without the pointers
 func (b *BuildInfo) Copy() *BuildInfo {
 	result := &BuildInfo{
@@ -934,6 +934,24 @@ func Test_validate(t *testing.T) {
       sshServerPort: -1`),
 			expectErr: true,
 		},
+		{
+			name: "valid-proxy",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      proxy: 1080`),
+			expectErr: false,
+		},
+		{
+			name: "invalid-proxy",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      proxy: 70000`),
+			expectErr: true,
+		},
 		{
 			name: "runAsNonRoot-with-root-user",
 			manifest: []byte(`
@@ -983,6 +1001,152 @@ func Test_validate(t *testing.T) {
         runAsGroup: 0`),
 			expectErr: false,
 		},
+		{
+			name: "sync-hooks",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        folders:
+          - .:/app
+        hooks:
+          - command: go build
+            paths:
+              - "**/*.go"`),
+			expectErr: false,
+		},
+		{
+			name: "sync-hooks-without-command",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        folders:
+          - .:/app
+        hooks:
+          - name: build`),
+			expectErr: true,
+		},
+		{
+			name: "sync-hooks-with-duplicated-names",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        folders:
+          - .:/app
+        hooks:
+          - name: build
+            command: go build
+          - name: build
+            command: make`),
+			expectErr: true,
+		},
+		{
+			name: "sync-hooks-with-invalid-path",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        folders:
+          - .:/app
+        hooks:
+          - command: go build
+            paths:
+              - "[a-"`),
+			expectErr: true,
+		},
+		{
+			name: "sync-folder-direction",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - localPath: .
+          remotePath: /app
+          direction: up`),
+			expectErr: false,
+		},
+		{
+			name: "sync-folder-invalid-direction",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - localPath: .
+          remotePath: /app
+          direction: sideways`),
+			expectErr: true,
+		},
+		{
+			name: "sync-engine-ssh",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        engine: ssh
+        folders:
+          - localPath: .
+            remotePath: /app
+            direction: up`),
+			expectErr: false,
+		},
+		{
+			name: "sync-engine-ssh-without-direction",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        engine: ssh
+        folders:
+          - .:/app`),
+			expectErr: false,
+		},
+		{
+			name: "sync-engine-ssh-direction-both",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        engine: ssh
+        folders:
+          - localPath: .
+            remotePath: /app
+            direction: both`),
+			expectErr: true,
+		},
+		{
+			name: "sync-engine-ssh-direction-down",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        engine: ssh
+        folders:
+          - localPath: .
+            remotePath: /app
+            direction: down`),
+			expectErr: true,
+		},
+		{
+			name: "sync-engine-unknown",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        engine: rsync
+        folders:
+          - .:/app`),
+			expectErr: true,
+		},
+		{
+			name: "side-by-side",
+			manifest: []byte(`
+      name: deployment
+      replace: false
+      sync:
+        - .:/app`),
+			expectErr: false,
+		},
+		{
+			name: "side-by-side-with-autocreate",
+			manifest: []byte(`
+      name: deployment
+      replace: false
+      autocreate: true
+      sync:
+        - .:/app`),
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1351,6 +1515,14 @@ func Test_validateForExtraFields(t *testing.T) {
 			name:  "sshServerPort",
 			value: "sshServerPort: 2222",
 		},
+		{
+			name:  "proxy",
+			value: "proxy: 1080",
+		},
+		{
+			name:  "sshAgent",
+			value: "sshAgent: true",
+		},
 		{
 			name:  "externalVolumes",
 			value: `externalVolumes: []`,
@@ -1820,3 +1992,92 @@ func TestExpandBuildArgs(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateSyncFolderDirections(t *testing.T) {
+	tests := []struct {
+		name      string
+		folders   []SyncFolder
+		expectErr bool
+	}{
+		{
+			name: "independent folders",
+			folders: []SyncFolder{
+				{LocalPath: "/src/app", RemotePath: "/app", Direction: SyncDirectionUp},
+				{LocalPath: "/src/coverage", RemotePath: "/coverage", Direction: SyncDirectionDown},
+			},
+		},
+		{
+			name: "nested folder with the same direction",
+			folders: []SyncFolder{
+				{LocalPath: "/src", RemotePath: "/app"},
+				{LocalPath: "/src/pkg", RemotePath: "/pkg", Direction: SyncDirectionBoth},
+			},
+		},
+		{
+			name: "nested folder with different direction",
+			folders: []SyncFolder{
+				{LocalPath: "/src", RemotePath: "/app"},
+				{LocalPath: "/src/node_modules", RemotePath: "/app/node_modules", Direction: SyncDirectionDown},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &Dev{Sync: Sync{Folders: tt.folders}}
+			err := dev.validateSyncFolderDirections()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_loadSocketAbsPaths(t *testing.T) {
+	dev := &Dev{
+		Forward: []forward.Forward{
+			{LocalSocket: "./run/pg.sock", RemoteSocket: "/var/run/postgresql/.s.PGSQL.5432"},
+			{LocalSocket: "/tmp/docker.sock", RemoteSocket: "/var/run/docker.sock"},
+			{Local: 8080, Remote: 8080},
+		},
+		Reverse: []Reverse{
+			{RemoteSocket: "/tmp/agent.sock", LocalSocket: "../agent.sock"},
+		},
+	}
+	folder := filepath.Join(os.TempDir(), "app")
+	dev.loadSocketAbsPaths(folder)
+
+	assert.Equal(t, filepath.Join(folder, "run", "pg.sock"), dev.Forward[0].LocalSocket)
+	assert.Equal(t, "/tmp/docker.sock", dev.Forward[1].LocalSocket)
+	assert.Equal(t, "", dev.Forward[2].LocalSocket)
+	assert.Equal(t, filepath.Join(os.TempDir(), "agent.sock"), dev.Reverse[0].LocalSocket)
+	assert.Equal(t, "/tmp/agent.sock", dev.Reverse[0].RemoteSocket)
+}
+
+func TestRemoteModeEnabledWithSSHAgent(t *testing.T) {
+	t.Setenv(OktetoExecuteSSHEnvVar, "false")
+	dev := &Dev{}
+	assert.False(t, dev.RemoteModeEnabled())
+
+	dev.SSHAgent = true
+	assert.True(t, dev.RemoteModeEnabled())
+}
+
+func TestSetDefaultsSyncEngineSSHDirection(t *testing.T) {
+	manifest, err := Read([]byte(`
+name: deployment
+sync:
+  engine: ssh
+  folders:
+    - .:/app
+    - localPath: .
+      remotePath: /other
+      direction: both`))
+	assert.NoError(t, err)
+
+	folders := manifest.Dev["deployment"].Sync.Folders
+	assert.Equal(t, SyncDirectionUp, folders[0].Direction)
+	assert.Equal(t, SyncDirectionBoth, folders[1].Direction)
+}
@@ -0,0 +1,409 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/model/forward"
+)
+
+// devContainer represents the fields of a devcontainer.json file translated to an okteto manifest.
+// See https://containers.dev/implementors/json_reference/
+type devContainer struct {
+	Image             string              `json:"image"`
+	DockerFile        string              `json:"dockerFile"`
+	Context           string              `json:"context"`
+	Build             *devContainerBuild  `json:"build"`
+	WorkspaceFolder   string              `json:"workspaceFolder"`
+	ForwardPorts      []interface{}       `json:"forwardPorts"`
+	ContainerEnv      map[string]string   `json:"containerEnv"`
+	PostCreateCommand interface{}         `json:"postCreateCommand"`
+	PostStartCommand  interface{}         `json:"postStartCommand"`
+	Mounts            []devContainerMount `json:"mounts"`
+}
+
+type devContainerBuild struct {
+	Dockerfile string            `json:"dockerfile"`
+	Context    string            `json:"context"`
+	Args       map[string]string `json:"args"`
+	Target     string            `json:"target"`
+	CacheFrom  interface{}       `json:"cacheFrom"`
+}
+
+type devContainerMount struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// supportedDevContainerKeys are the keys of a devcontainer.json file translated to the okteto manifest.
+// 'name' is a display name, the dev is named after the folder
+var supportedDevContainerKeys = map[string]bool{
+	"$schema":           true,
+	"name":              true,
+	"image":             true,
+	"dockerFile":        true,
+	"context":           true,
+	"build":             true,
+	"workspaceFolder":   true,
+	"forwardPorts":      true,
+	"containerEnv":      true,
+	"postCreateCommand": true,
+	"postStartCommand":  true,
+	"mounts":            true,
+}
+
+// lifecycleDevContainerKeys are the commands of a devcontainer.json file that don't run when the container is created or starts,
+// they can't be translated to the command of the dev
+var lifecycleDevContainerKeys = map[string]bool{
+	"postAttachCommand": true,
+}
+
+// devContainerStatePath is a volume of the dev translated from a devcontainer.json file.
+// It keeps the marker of the 'postCreateCommand' in the persistent volume, so it runs once
+const (
+	devContainerStatePath = "/var/okteto/devcontainer"
+
+	devContainerPostCreateMarker = devContainerStatePath + "/post-create"
+)
+
+var supportedDevContainerBuildKeys = map[string]bool{
+	"dockerfile": true,
+	"context":    true,
+	"args":       true,
+	"target":     true,
+	"cacheFrom":  true,
+}
+
+// devContainerLocalEnvRegex matches the '${localEnv:NAME}' and '${localEnv:NAME:default}' variables of a devcontainer.json file
+var devContainerLocalEnvRegex = regexp.MustCompile(`\$\{localEnv:([A-Za-z_][A-Za-z0-9_]*)(?::([^}]*))?\}`)
+
+// ManifestFromDevContainer returns a manifest with a dev entry translated from the devcontainer.json file at path.
+// workdir is the folder synchronized with the development container.
+// It also returns the keys of the devcontainer.json file that have no equivalent in the okteto manifest
+func ManifestFromDevContainer(path, workdir string) (*Manifest, []string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	b = stripJSONComments(b)
+
+	keys := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return nil, nil, fmt.Errorf("error reading '%s': %w", path, err)
+	}
+	dc := &devContainer{}
+	if err := json.Unmarshal(b, dc); err != nil {
+		return nil, nil, fmt.Errorf("error reading '%s': %w", path, err)
+	}
+	unsupported := unsupportedDevContainerKeys(keys)
+
+	workdir, err = filepath.Abs(workdir)
+	if err != nil {
+		return nil, nil, err
+	}
+	name, err := GetValidNameFromFolder(workdir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workspaceFolder := dc.WorkspaceFolder
+	if workspaceFolder == "" {
+		workspaceFolder = "/workspaces/${localWorkspaceFolderBasename}"
+	}
+	workspaceFolder = translateDevContainerVariables(workspaceFolder, workdir, "")
+	translate := func(value string) string {
+		return translateDevContainerVariables(value, workdir, workspaceFolder)
+	}
+
+	dev := &Dev{
+		Workdir: workspaceFolder,
+		Sync: Sync{
+			RescanInterval: DefaultSyncthingRescanInterval,
+			Folders:        []SyncFolder{{LocalPath: ".", RemotePath: workspaceFolder}},
+		},
+		Autocreate: true,
+	}
+	manifest := NewManifest()
+	manifest.Deploy = nil
+	manifest.Dev[name] = dev
+
+	if dc.Image != "" {
+		dev.Image = &BuildInfo{Name: translate(dc.Image)}
+	}
+	if dc.Build != nil || dc.DockerFile != "" {
+		build, err := dc.buildInfo(filepath.Dir(path), workdir)
+		if err != nil {
+			return nil, nil, err
+		}
+		// the dev uses the image of the build entry with its name
+		dev.Image = nil
+		manifest.Build[name] = build
+	}
+
+	for _, p := range dc.ForwardPorts {
+		f, err := translateDevContainerPort(p)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading '%s': %w", path, err)
+		}
+		dev.Forward = append(dev.Forward, f)
+	}
+
+	envNames := make([]string, 0, len(dc.ContainerEnv))
+	for envName := range dc.ContainerEnv {
+		envNames = append(envNames, envName)
+	}
+	sort.Strings(envNames)
+	for _, envName := range envNames {
+		dev.Environment = append(dev.Environment, EnvVar{Name: envName, Value: translate(dc.ContainerEnv[envName])})
+	}
+
+	for i, m := range dc.Mounts {
+		if m.Type != "volume" || m.Target == "" {
+			unsupported = append(unsupported, fmt.Sprintf("mounts[%d] (only mounts of type 'volume' are supported)", i))
+			continue
+		}
+		dev.Volumes = append(dev.Volumes, Volume{RemotePath: translate(m.Target)})
+	}
+
+	// the manifest lifecycle only enables the hooks of the original container,
+	// so the create and start commands run before the shell started by 'okteto up'
+	commands := []string{}
+	createCommands := devContainerCommands(dc.PostCreateCommand)
+	if len(createCommands) > 0 {
+		for i := range createCommands {
+			createCommands[i] = translate(createCommands[i])
+		}
+		commands = append(commands, fmt.Sprintf("if [ ! -f %[1]s ]; then %[2]s && touch %[1]s; fi", devContainerPostCreateMarker, strings.Join(createCommands, " && ")))
+		dev.Volumes = append(dev.Volumes, Volume{RemotePath: devContainerStatePath})
+	}
+	for _, command := range devContainerCommands(dc.PostStartCommand) {
+		commands = append(commands, translate(command))
+	}
+	if len(commands) > 0 {
+		dev.Command = Command{Values: []string{"sh", "-c", strings.Join(append(commands, "exec sh"), " && ")}}
+	}
+
+	return manifest, unsupported, nil
+}
+
+// buildInfo returns the build of the devcontainer.json file in dir with paths relative to workdir
+func (dc *devContainer) buildInfo(dir, workdir string) (*BuildInfo, error) {
+	b := dc.Build
+	if b == nil {
+		b = &devContainerBuild{Dockerfile: dc.DockerFile, Context: dc.Context}
+	}
+	if b.Context == "" {
+		b.Context = "."
+	}
+	contextPath := filepath.Join(dir, b.Context)
+	context, err := filepath.Rel(workdir, contextPath)
+	if err != nil {
+		return nil, err
+	}
+	// the dockerfile of a devcontainer.json file is relative to the file, and relative to the context in the okteto manifest
+	dockerfile, err := filepath.Rel(contextPath, filepath.Join(dir, b.Dockerfile))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BuildInfo{
+		Context:    filepath.ToSlash(context),
+		Dockerfile: filepath.ToSlash(dockerfile),
+		Target:     b.Target,
+	}
+	argNames := make([]string, 0, len(b.Args))
+	for argName := range b.Args {
+		argNames = append(argNames, argName)
+	}
+	sort.Strings(argNames)
+	for _, argName := range argNames {
+		result.Args = append(result.Args, BuildArg{Name: argName, Value: b.Args[argName]})
+	}
+	switch cacheFrom := b.CacheFrom.(type) {
+	case string:
+		result.CacheFrom = []string{cacheFrom}
+	case []interface{}:
+		for _, c := range cacheFrom {
+			if s, ok := c.(string); ok {
+				result.CacheFrom = append(result.CacheFrom, s)
+			}
+		}
+	}
+	return result, nil
+}
+
+func unsupportedDevContainerKeys(keys map[string]json.RawMessage) []string {
+	result := []string{}
+	for key, value := range keys {
+		if lifecycleDevContainerKeys[key] {
+			result = append(result, fmt.Sprintf("%s (only 'postCreateCommand' and 'postStartCommand' run when the development container starts)", key))
+			continue
+		}
+		if !supportedDevContainerKeys[key] {
+			result = append(result, key)
+			continue
+		}
+		if key != "build" {
+			continue
+		}
+		buildKeys := map[string]json.RawMessage{}
+		if err := json.Unmarshal(value, &buildKeys); err != nil {
+			continue
+		}
+		for buildKey := range buildKeys {
+			if !supportedDevContainerBuildKeys[buildKey] {
+				result = append(result, fmt.Sprintf("build.%s", buildKey))
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// translateDevContainerPort returns the forward of a port of the 'forwardPorts' field: a number or 'service:port'
+func translateDevContainerPort(p interface{}) (forward.Forward, error) {
+	switch port := p.(type) {
+	case float64:
+		return forward.Forward{Local: int(port), Remote: int(port)}, nil
+	case string:
+		service, portValue, found := strings.Cut(port, ":")
+		if !found {
+			portValue = service
+			service = ""
+		}
+		number, err := strconv.Atoi(portValue)
+		if err != nil {
+			return forward.Forward{}, fmt.Errorf("'%s' is not a valid port of the 'forwardPorts' field", port)
+		}
+		return forward.Forward{Local: number, Remote: number, Service: service != "", ServiceName: service}, nil
+	default:
+		return forward.Forward{}, fmt.Errorf("'%v' is not a valid port of the 'forwardPorts' field", p)
+	}
+}
+
+// devContainerCommands returns the commands of a lifecycle field: a string, an array with a command and its arguments, or an object with commands run in parallel
+func devContainerCommands(c interface{}) []string {
+	switch command := c.(type) {
+	case string:
+		if command == "" {
+			return nil
+		}
+		return []string{command}
+	case []interface{}:
+		args := []string{}
+		for _, arg := range command {
+			args = append(args, quoteShellArg(fmt.Sprint(arg)))
+		}
+		if len(args) == 0 {
+			return nil
+		}
+		return []string{strings.Join(args, " ")}
+	case map[string]interface{}:
+		names := make([]string, 0, len(command))
+		for name := range command {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		result := []string{}
+		for _, name := range names {
+			result = append(result, devContainerCommands(command[name])...)
+		}
+		return result
+	}
+	return nil
+}
+
+func quoteShellArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`&|;<>()*?") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// translateDevContainerVariables replaces the variables of a devcontainer.json value with their values, or with the variables expanded by okteto
+func translateDevContainerVariables(value, workdir, workspaceFolder string) string {
+	replacer := strings.NewReplacer(
+		"${localWorkspaceFolderBasename}", filepath.Base(workdir),
+		"${containerWorkspaceFolder}", workspaceFolder,
+	)
+	return devContainerLocalEnvRegex.ReplaceAllStringFunc(replacer.Replace(value), translateDevContainerLocalEnv)
+}
+
+// translateDevContainerLocalEnv translates '${localEnv:NAME:default}' to '${NAME:-default}', expanded by okteto
+func translateDevContainerLocalEnv(variable string) string {
+	match := devContainerLocalEnvRegex.FindStringSubmatch(variable)
+	if match[2] == "" {
+		return fmt.Sprintf("${%s}", match[1])
+	}
+	return fmt.Sprintf("${%s:-%s}", match[1], match[2])
+}
+
+// stripJSONComments removes the comments and trailing commas allowed in devcontainer.json files
+func stripJSONComments(b []byte) []byte {
+	result := make([]byte, 0, len(b))
+	inString := false
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		if inString {
+			result = append(result, c)
+			if c == '\\' && i+1 < len(b) {
+				i++
+				result = append(result, b[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+			result = append(result, c)
+		case c == '/' && i+1 < len(b) && b[i+1] == '/':
+			for i < len(b) && b[i] != '\n' {
+				i++
+			}
+			if i < len(b) {
+				result = append(result, '\n')
+			}
+		case c == '/' && i+1 < len(b) && b[i+1] == '*':
+			i += 2
+			for i+1 < len(b) && !(b[i] == '*' && b[i+1] == '/') {
+				i++
+			}
+			i++
+		case c == '}' || c == ']':
+			// remove the comma before the closing character
+			j := len(result) - 1
+			for j >= 0 && strings.ContainsRune(" \t\r\n", rune(result[j])) {
+				j--
+			}
+			if j >= 0 && result[j] == ',' {
+				result = append(result[:j], result[j+1:]...)
+			}
+			result = append(result, c)
+		default:
+			result = append(result, c)
+		}
+	}
+	return result
+}
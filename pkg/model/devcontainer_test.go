@@ -0,0 +1,122 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestFromDevContainer(t *testing.T) {
+	workdir := filepath.Join(t.TempDir(), "my-app")
+	path := filepath.Join(workdir, ".devcontainer", "devcontainer.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	content := `// Dev container of my-app
+{
+  "name": "My App",
+  "build": {
+    "dockerfile": "Dockerfile", // relative to this file
+    "context": "..",
+    "args": {"VARIANT": "18"},
+    "options": ["--network=host"]
+  },
+  /* the sources */
+  "workspaceFolder": "/workspaces/${localWorkspaceFolderBasename}",
+  "forwardPorts": [3000, "db:5432"],
+  "containerEnv": {"TOKEN": "${localEnv:TOKEN}", "URL": "http://localhost//api"},
+  "postCreateCommand": "npm install",
+  "postStartCommand": ["npm", "run", "dev server"],
+  "postAttachCommand": "git status",
+  "mounts": [
+    {"source": "modules", "target": "${containerWorkspaceFolder}/node_modules", "type": "volume"},
+    {"source": "/tmp", "target": "/tmp", "type": "bind"},
+  ],
+  "features": {},
+}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	m, unsupported, err := ManifestFromDevContainer(path, workdir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"build.options",
+		"features",
+		"postAttachCommand (only 'postCreateCommand' and 'postStartCommand' run when the development container starts)",
+		"mounts[1] (only mounts of type 'volume' are supported)",
+	}, unsupported)
+
+	assert.Nil(t, m.Deploy)
+	require.Contains(t, m.Build, "my-app")
+	assert.Equal(t, &BuildInfo{
+		Context:    ".",
+		Dockerfile: ".devcontainer/Dockerfile",
+		Args:       BuildArgs{{Name: "VARIANT", Value: "18"}},
+	}, m.Build["my-app"])
+
+	require.Contains(t, m.Dev, "my-app")
+	dev := m.Dev["my-app"]
+	assert.Nil(t, dev.Image)
+	assert.True(t, dev.Autocreate)
+	assert.Equal(t, "/workspaces/my-app", dev.Workdir)
+	assert.Equal(t, []SyncFolder{{LocalPath: ".", RemotePath: "/workspaces/my-app"}}, dev.Sync.Folders)
+	assert.Equal(t, []forward.Forward{
+		{Local: 3000, Remote: 3000},
+		{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+	}, dev.Forward)
+	assert.Equal(t, Environment{{Name: "TOKEN", Value: "${TOKEN}"}, {Name: "URL", Value: "http://localhost//api"}}, dev.Environment)
+	assert.Equal(t, []Volume{{RemotePath: "/workspaces/my-app/node_modules"}, {RemotePath: "/var/okteto/devcontainer"}}, dev.Volumes)
+	assert.Equal(t, []string{"sh", "-c", "if [ ! -f /var/okteto/devcontainer/post-create ]; then npm install && touch /var/okteto/devcontainer/post-create; fi && npm run 'dev server' && exec sh"}, dev.Command.Values)
+}
+
+func TestManifestFromDevContainerImage(t *testing.T) {
+	workdir := filepath.Join(t.TempDir(), "api")
+	path := filepath.Join(workdir, ".devcontainer.json")
+	require.NoError(t, os.MkdirAll(workdir, 0700))
+	require.NoError(t, os.WriteFile(path, []byte(`{"image": "mcr.microsoft.com/devcontainers/go:1"}`), 0600))
+
+	m, unsupported, err := ManifestFromDevContainer(path, workdir)
+	require.NoError(t, err)
+	assert.Empty(t, unsupported)
+	assert.Empty(t, m.Build)
+	dev := m.Dev["api"]
+	assert.Equal(t, "mcr.microsoft.com/devcontainers/go:1", dev.Image.Name)
+	assert.Equal(t, "/workspaces/api", dev.Workdir)
+	assert.Empty(t, dev.Command.Values)
+}
+
+func TestTranslateDevContainerPortError(t *testing.T) {
+	_, err := translateDevContainerPort("db:http")
+	assert.EqualError(t, err, "'db:http' is not a valid port of the 'forwardPorts' field")
+}
+
+func TestStripJSONComments(t *testing.T) {
+	content := `{
+  // comment
+  "url": "http://a/*b*/", /* block */
+  "list": [1, 2,],
+  "escaped": "\"//\"",
+}`
+	result := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(stripJSONComments([]byte(content)), &result))
+	assert.Equal(t, map[string]interface{}{
+		"url":     "http://a/*b*/",
+		"list":    []interface{}{float64(1), float64(2)},
+		"escaped": `"//"`,
+	}, result)
+}
@@ -41,7 +41,7 @@ func GetRc(devPath string) (*DevRC, error) {
 
 	dev, err := ReadRC(b)
 	if err != nil {
-		return nil, err
+		return nil, setYAMLErrorFile(err, devPath)
 	}
 
 	return dev, nil
@@ -53,6 +53,9 @@ func ReadRC(bytes []byte) (*DevRC, error) {
 
 	if bytes != nil {
 		if err := yaml.UnmarshalStrict(bytes, dev); err != nil {
+			if yamlErr := newYAMLError("Invalid developer level manifest:", manifestDocsURL, bytes, devRCSchema(), err); yamlErr != nil {
+				return nil, yamlErr
+			}
 			if strings.HasPrefix(err.Error(), "yaml: unmarshal errors:") {
 				var sb strings.Builder
 				_, _ = sb.WriteString("Invalid developer level manifest:\n")
@@ -217,7 +220,7 @@ func getEnvVarIdx(environment Environment, envVar EnvVar) int {
 func getForwardPortIdx(forwardList []forward.Forward, forward forward.Forward) int {
 	idx := -1
 	for aux, fwd := range forwardList {
-		if fwd.Remote == forward.Remote {
+		if fwd.Remote == forward.Remote && fwd.Protocol == forward.Protocol && fwd.RemoteSocket == forward.RemoteSocket {
 			return aux
 		}
 	}
@@ -227,7 +230,7 @@ func getForwardPortIdx(forwardList []forward.Forward, forward forward.Forward) i
 func getReversePortIdx(reverseList []Reverse, reverse Reverse) int {
 	idx := -1
 	for aux, rvrs := range reverseList {
-		if rvrs.Remote == reverse.Remote {
+		if rvrs.Remote == reverse.Remote && rvrs.Protocol == reverse.Protocol && rvrs.RemoteSocket == reverse.RemoteSocket {
 			return aux
 		}
 	}
@@ -0,0 +1,271 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const extendsField = "extends"
+
+var resolvedLineRegex = regexp.MustCompile(`line \d+: `)
+
+// notInheritedFields are the fields of a dev entry that are never copied from the entry it extends
+var notInheritedFields = map[string]bool{
+	extendsField: true,
+	"name":       true,
+}
+
+// replacedLists are the lists of a dev entry that replace the list of the entry it extends instead of being merged
+var replacedLists = map[string]bool{
+	"command": true,
+	"args":    true,
+}
+
+// hasExtends returns true if any dev entry extends another entry
+func (d ManifestDevs) hasExtends() bool {
+	for _, dev := range d {
+		if dev != nil && dev.Extends != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// readDevExtends reads the manifest with the dev entries that extend other entries resolved.
+// The manifest must be already read without resolving them, so errors are reported at their position in the user's file
+func readDevExtends(bytes []byte) (*Manifest, error) {
+	manifest := NewManifest()
+	if err := yaml.UnmarshalStrict(resolveDevExtends(bytes), manifest); err != nil {
+		// the lines of the errors are positions in the resolved manifest, not in the user's file
+		msg := resolvedLineRegex.ReplaceAllString(err.Error(), "")
+		return nil, fmt.Errorf("failed to resolve the 'extends' of the dev section: %s", msg)
+	}
+	return manifest, nil
+}
+
+// resolveDevExtends returns the manifest with the fields of the dev entries that extend other entries deep merged into them.
+// Maps are merged key by key and lists are appended, the values of the extending entry win.
+// Unknown or cyclic 'extends' are left as they are and reported by Manifest.validate
+func resolveDevExtends(bytes []byte) []byte {
+	root := yaml.MapSlice{}
+	if err := yaml.Unmarshal(bytes, &root); err != nil {
+		return bytes
+	}
+	devIndex := -1
+	for i, item := range root {
+		if item.Key == "dev" {
+			devIndex = i
+		}
+	}
+	if devIndex < 0 {
+		return bytes
+	}
+	devs, ok := root[devIndex].Value.(yaml.MapSlice)
+	if !ok {
+		return bytes
+	}
+
+	entries := map[string]yaml.MapSlice{}
+	extends := map[string]string{}
+	for _, item := range devs {
+		name, ok := item.Key.(string)
+		if !ok {
+			continue
+		}
+		entry, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+		entries[name] = entry
+		if base, ok := mapSliceValue(entry, extendsField).(string); ok && base != "" {
+			extends[name] = base
+		}
+	}
+	if len(extends) == 0 {
+		return bytes
+	}
+
+	resolved := map[string]bool{}
+	var resolve func(name string, visiting map[string]bool)
+	resolve = func(name string, visiting map[string]bool) {
+		base, ok := extends[name]
+		if !ok || resolved[name] || visiting[name] {
+			return
+		}
+		if _, ok := entries[base]; !ok {
+			return
+		}
+		visiting[name] = true
+		resolve(base, visiting)
+		delete(visiting, name)
+		if visiting[base] {
+			return
+		}
+		entries[name] = mergeMapSlices(inheritedFields(entries[base]), entries[name], true)
+		resolved[name] = true
+	}
+	for name := range extends {
+		resolve(name, map[string]bool{})
+	}
+
+	for i, item := range devs {
+		if name, ok := item.Key.(string); ok && resolved[name] {
+			devs[i].Value = entries[name]
+		}
+	}
+	result, err := yaml.Marshal(root)
+	if err != nil {
+		return bytes
+	}
+	return result
+}
+
+func inheritedFields(entry yaml.MapSlice) yaml.MapSlice {
+	result := yaml.MapSlice{}
+	for _, item := range entry {
+		if key, ok := item.Key.(string); ok && notInheritedFields[key] {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// mergeMapSlices returns base with the values of override, merging the maps and lists they both define
+func mergeMapSlices(base, override yaml.MapSlice, isDevEntry bool) yaml.MapSlice {
+	result := make(yaml.MapSlice, len(base))
+	copy(result, base)
+	for _, item := range override {
+		i := mapSliceIndex(result, item.Key)
+		if i < 0 {
+			result = append(result, item)
+			continue
+		}
+		key, _ := item.Key.(string)
+		switch value := item.Value.(type) {
+		case yaml.MapSlice:
+			if baseValue, ok := result[i].Value.(yaml.MapSlice); ok {
+				result[i].Value = mergeMapSlices(baseValue, value, false)
+				continue
+			}
+		case []interface{}:
+			if baseValue, ok := result[i].Value.([]interface{}); ok && !(isDevEntry && replacedLists[key]) {
+				result[i].Value = mergeLists(baseValue, value)
+				continue
+			}
+		}
+		result[i].Value = item.Value
+	}
+	return result
+}
+
+// mergeLists appends the items of override that are not in base. Items like 'NAME=VALUE' replace the item of base with the same name
+func mergeLists(base, override []interface{}) []interface{} {
+	result := make([]interface{}, len(base))
+	copy(result, base)
+	for _, item := range override {
+		found := false
+		for i, baseItem := range result {
+			if reflect.DeepEqual(item, baseItem) || sameVariable(item, baseItem) {
+				result[i] = item
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func sameVariable(a, b interface{}) bool {
+	aValue, ok := a.(string)
+	if !ok {
+		return false
+	}
+	bValue, ok := b.(string)
+	if !ok {
+		return false
+	}
+	aName, _, aFound := strings.Cut(aValue, "=")
+	bName, _, bFound := strings.Cut(bValue, "=")
+	return aFound && bFound && aName == bName
+}
+
+func mapSliceIndex(m yaml.MapSlice, key interface{}) int {
+	for i, item := range m {
+		if item.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func mapSliceValue(m yaml.MapSlice, key interface{}) interface{} {
+	if i := mapSliceIndex(m, key); i >= 0 {
+		return m[i].Value
+	}
+	return nil
+}
+
+// validateDevExtends checks that the dev entries extend entries of the dev section without cycles
+func (m *Manifest) validateDevExtends() error {
+	names := make([]string, 0, len(m.Dev))
+	for name := range m.Dev {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		base := m.Dev[name].Extends
+		if base == "" {
+			continue
+		}
+		if base == name {
+			return fmt.Errorf("manifest dev validation failed: dev '%s' extends itself", name)
+		}
+		if _, ok := m.Dev[base]; !ok {
+			return fmt.Errorf("manifest dev validation failed: dev '%s' extends '%s', which is not defined in the 'dev' section", name, base)
+		}
+
+		chain := []string{name}
+		for current := base; current != ""; current = m.Dev[current].Extends {
+			if current == name {
+				return fmt.Errorf("manifest dev validation failed: cyclic 'extends' found between %s", strings.Join(append(chain, name), " -> "))
+			}
+			if _, ok := m.Dev[current]; !ok || contains(chain[1:], current) {
+				break
+			}
+			chain = append(chain, current)
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestReadDevExtends(t *testing.T) {
+	manifest := []byte(`dev:
+  base:
+    image: okteto/golang:1
+    command: bash
+    environment:
+      LOG_LEVEL: info
+      REGION: eu
+    nodeSelector:
+      pool: dev
+    resources:
+      limits:
+        cpu: 1
+        memory: 1Gi
+    tolerations:
+      - key: dev
+        operator: Exists
+  api:
+    extends: base
+    command: ["go", "run", "main.go"]
+    environment:
+      LOG_LEVEL: debug
+    nodeSelector:
+      disk: ssd
+    resources:
+      limits:
+        memory: 2Gi
+  worker:
+    extends: api
+    autocreate: true
+    tolerations:
+      - key: gpu
+        operator: Exists
+`)
+
+	m, err := Read(manifest)
+	require.NoError(t, err)
+
+	api := m.Dev["api"]
+	assert.Equal(t, "api", api.Name)
+	assert.Equal(t, "okteto/golang:1", api.Image.Name)
+	assert.Equal(t, []string{"go", "run", "main.go"}, api.Command.Values)
+	assert.ElementsMatch(t, Environment{{Name: "LOG_LEVEL", Value: "debug"}, {Name: "REGION", Value: "eu"}}, api.Environment)
+	assert.Equal(t, map[string]string{"pool": "dev", "disk": "ssd"}, api.NodeSelector)
+	assert.Equal(t, resource.MustParse("1"), api.Resources.Limits[apiv1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("2Gi"), api.Resources.Limits[apiv1.ResourceMemory])
+
+	worker := m.Dev["worker"]
+	assert.Equal(t, "worker", worker.Name)
+	assert.True(t, worker.Autocreate)
+	assert.Equal(t, []string{"go", "run", "main.go"}, worker.Command.Values)
+	assert.Equal(t, resource.MustParse("2Gi"), worker.Resources.Limits[apiv1.ResourceMemory])
+	require.Len(t, worker.Tolerations, 2)
+	assert.Equal(t, "dev", worker.Tolerations[0].Key)
+	assert.Equal(t, "gpu", worker.Tolerations[1].Key)
+
+	base := m.Dev["base"]
+	assert.Equal(t, []string{"bash"}, base.Command.Values)
+	assert.False(t, base.Autocreate)
+}
+
+func TestReadDevExtendsErrors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		manifest string
+		expected string
+	}{
+		{
+			name: "unknown",
+			manifest: `dev:
+  api:
+    extends: base
+    command: bash`,
+			expected: "manifest dev validation failed: dev 'api' extends 'base', which is not defined in the 'dev' section",
+		},
+		{
+			name: "itself",
+			manifest: `dev:
+  api:
+    extends: api
+    command: bash`,
+			expected: "manifest dev validation failed: dev 'api' extends itself",
+		},
+		{
+			name: "cycle",
+			manifest: `dev:
+  api:
+    extends: worker
+    command: bash
+  worker:
+    extends: api
+    command: bash`,
+			expected: "manifest dev validation failed: cyclic 'extends' found between api -> worker -> api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read([]byte(tt.manifest))
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMergeLists(t *testing.T) {
+	base := []interface{}{"A=1", "B=2", "8080:80"}
+	override := []interface{}{"A=3", "8080:80", "9000:9000"}
+	assert.Equal(t, []interface{}{"A=3", "B=2", "8080:80", "9000:9000"}, mergeLists(base, override))
+}
+
+func TestReadDevExtendsYAMLError(t *testing.T) {
+	_, err := Read([]byte(`dev:
+  base:
+    image: okteto/golang:1
+    enviroment:
+      LOG_LEVEL: info
+  api:
+    extends: base
+    command: bash
+`))
+	require.Error(t, err)
+	var yamlErr *YAMLError
+	require.ErrorAs(t, err, &yamlErr)
+	assert.Equal(t, []YAMLErrorDetail{{Line: 4, Column: 5, Path: "dev.base", Message: "field 'enviroment' is not allowed", Suggestion: "environment"}}, yamlErr.Errors)
+	assert.Contains(t, err.Error(), "        4 |     enviroment:\n")
+}
@@ -14,27 +14,169 @@
 package forward
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 const MalformedPortForward = "Wrong port-forward syntax '%s', must be of the form 'localPort:remotePort' or 'localPort:serviceName:remotePort'"
 
+// AutoPort is the local port of the forwards whose local port is allocated by okteto
+const AutoPort = "auto"
+
+const (
+	// TCPProtocol is the default protocol of port forwards
+	TCPProtocol = "tcp"
+
+	// UDPProtocol is the protocol of the forwards with the '/udp' suffix, rejected by ErrUDPNotSupported
+	UDPProtocol = "udp"
+)
+
+// ErrUDPNotSupported is returned for '/udp' forwards until the okteto remote server of the development containers forwards datagrams
+var ErrUDPNotSupported = errors.New("'udp' forwards and reverses are not supported yet by the SSH server of the development containers")
+
 // Forward represents a port forwarding definition
 type Forward struct {
-	Local       int               `json:"localPort" yaml:"localPort"`
-	Remote      int               `json:"remotePort" yaml:"remotePort"`
-	Service     bool              `json:"-" yaml:"-"`
-	ServiceName string            `json:"name" yaml:"name"`
-	Labels      map[string]string `json:"labels" yaml:"labels"`
-	IsGlobal    bool              `json:"-" yaml:"-"`
+	Local        int               `json:"localPort" yaml:"localPort"`
+	Remote       int               `json:"remotePort" yaml:"remotePort"`
+	Service      bool              `json:"-" yaml:"-"`
+	ServiceName  string            `json:"name" yaml:"name"`
+	Labels       map[string]string `json:"labels" yaml:"labels"`
+	Protocol     string            `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	LocalSocket  string            `json:"localSocket,omitempty" yaml:"localSocket,omitempty"`
+	RemoteSocket string            `json:"remoteSocket,omitempty" yaml:"remoteSocket,omitempty"`
+	IsGlobal     bool              `json:"-" yaml:"-"`
 }
 
 func (f Forward) String() string {
+	if f.IsSocket() {
+		return fmt.Sprintf("%s:%s", Endpoint(f.Local, f.LocalSocket), Endpoint(f.Remote, f.RemoteSocket))
+	}
+
 	if f.Service {
-		return fmt.Sprintf("%d:%s:%d", f.Local, f.ServiceName, f.Remote)
+		return fmt.Sprintf("%s:%s:%d%s", LocalPortString(f.Local), f.ServiceName, f.Remote, ProtocolSuffix(f.Protocol))
+	}
+
+	return fmt.Sprintf("%s:%d%s", LocalPortString(f.Local), f.Remote, ProtocolSuffix(f.Protocol))
+}
+
+// IsAuto returns true if the local port of the forward is allocated by okteto
+func (f *Forward) IsAuto() bool {
+	return f.Local == 0 && f.LocalSocket == ""
+}
+
+// IsUDP returns true if the forward sends UDP datagrams
+func (f *Forward) IsUDP() bool {
+	return f.Protocol == UDPProtocol
+}
+
+// IsSocket returns true if any side of the forward is an Unix socket
+func (f *Forward) IsSocket() bool {
+	return f.LocalSocket != "" || f.RemoteSocket != ""
+}
+
+// RequiresSSH returns true if the forward can't be created with a kubernetes port-forward
+func (f *Forward) RequiresSSH() bool {
+	return f.IsUDP() || f.IsSocket()
+}
+
+// LocalPort is the local port of a forward in its extended form, which also accepts 'auto'
+type LocalPort int
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg for local ports
+func (p *LocalPort) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw string
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	port, err := ParseLocalPort(raw)
+	if err != nil {
+		return fmt.Errorf("Cannot convert local port '%s'", raw)
 	}
+	*p = LocalPort(port)
+	return nil
+}
 
-	return fmt.Sprintf("%d:%d", f.Local, f.Remote)
+// ParseLocalPort parses the local port of a forward definition. 'auto' and '0' return 0
+func ParseLocalPort(value string) (int, error) {
+	if value == AutoPort {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// LocalPortString returns the definition of a local port, which is 'auto' for allocated ports
+func LocalPortString(port int) string {
+	if port == 0 {
+		return AutoPort
+	}
+	return strconv.Itoa(port)
+}
+
+// IsSocketPath returns true if a side of a forward definition is the path of an Unix socket instead of a port
+func IsSocketPath(value string) bool {
+	return strings.HasPrefix(value, "/") || strings.HasPrefix(value, "./") || strings.HasPrefix(value, "../")
+}
+
+// ParseEndpoint parses a side of a forward definition, returning its port or its socket path
+func ParseEndpoint(value string) (int, string, error) {
+	if IsSocketPath(value) {
+		return 0, value, nil
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, "", fmt.Errorf("'%s' is not a port or the path of an Unix socket", value)
+	}
+	return port, "", nil
+}
+
+// Endpoint returns the definition of a side of a forward
+func Endpoint(port int, socket string) string {
+	if socket != "" {
+		return socket
+	}
+	return strconv.Itoa(port)
+}
+
+// IsSocketDefinition returns true if the forward definition has a socket path in any of its sides
+func IsSocketDefinition(parts []string) bool {
+	for _, p := range parts {
+		if IsSocketPath(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProtocolSuffix returns the suffix added to the definition of a forward for the protocol
+func ProtocolSuffix(protocol string) string {
+	if protocol == "" || protocol == TCPProtocol {
+		return ""
+	}
+	return "/" + protocol
+}
+
+// ParseProtocol splits the '/tcp' suffix of a forward definition.
+// The protocol is empty for TCP forwards
+func ParseProtocol(raw string) (string, string, error) {
+	value, protocol, found := strings.Cut(raw, "/")
+	if !found {
+		return raw, "", nil
+	}
+	return value, protocol, ValidateProtocol(protocol)
+}
+
+// ValidateProtocol returns an error if the protocol is not supported by forwards
+func ValidateProtocol(protocol string) error {
+	switch protocol {
+	case "", TCPProtocol:
+		return nil
+	case UDPProtocol:
+		return ErrUDPNotSupported
+	default:
+		return fmt.Errorf("protocol '%s' is not supported, must be '%s'", protocol, TCPProtocol)
+	}
 }
 
 func (f *Forward) Less(c *Forward) bool {
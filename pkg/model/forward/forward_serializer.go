@@ -7,17 +7,21 @@ import (
 )
 
 type ForwardRaw struct {
-	Local       int               `json:"localPort" yaml:"localPort"`
+	Local       LocalPort         `json:"localPort" yaml:"localPort"`
 	Remote      int               `json:"remotePort" yaml:"remotePort"`
 	Service     bool              `json:"-" yaml:"-"`
 	ServiceName string            `json:"name" yaml:"name"`
 	Labels      map[string]string `json:"labels" yaml:"labels"`
+	Protocol    string            `json:"protocol" yaml:"protocol"`
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg for port forwards.
 // It supports the following options:
 // - int:int
 // - int:serviceName:int
+// The local port can also be 'auto', to let okteto allocate it.
+// Both of them accept a '/tcp' suffix.
+// Any side of 'int:int' can also be the path of an Unix socket, starting with '/', './' or '../'.
 // Anything else will result in an error
 func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
@@ -26,12 +30,25 @@ func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return f.UnmarshalExtendedForm(unmarshal)
 	}
 
-	parts := strings.Split(raw, ":")
+	if parts := strings.Split(raw, ":"); len(parts) == 2 && IsSocketDefinition(parts) {
+		return f.unmarshalSocket(raw, parts)
+	}
+
+	value, protocol, err := ParseProtocol(raw)
+	if err != nil {
+		return fmt.Errorf("Wrong port-forward '%s': %w", raw, err)
+	}
+	if protocol != UDPProtocol {
+		protocol = ""
+	}
+	f.Protocol = protocol
+
+	parts := strings.Split(value, ":")
 	if len(parts) < 2 || len(parts) > 3 {
 		return fmt.Errorf(MalformedPortForward, raw)
 	}
 
-	localPort, err := strconv.Atoi(parts[0])
+	localPort, err := ParseLocalPort(parts[0])
 	if err != nil {
 		return fmt.Errorf("Cannot convert local port '%s' in port-forward '%s'", parts[0], raw)
 	}
@@ -58,6 +75,22 @@ func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+func (f *Forward) unmarshalSocket(raw string, parts []string) error {
+	local, localSocket, err := ParseEndpoint(parts[0])
+	if err != nil {
+		return fmt.Errorf("Wrong port-forward '%s': %w", raw, err)
+	}
+	remote, remoteSocket, err := ParseEndpoint(parts[1])
+	if err != nil {
+		return fmt.Errorf("Wrong port-forward '%s': %w", raw, err)
+	}
+	f.Local = local
+	f.LocalSocket = localSocket
+	f.Remote = remote
+	f.RemoteSocket = remoteSocket
+	return nil
+}
+
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (f Forward) MarshalYAML() (interface{}, error) {
 	return f.String(), nil
@@ -69,10 +102,16 @@ func (f *Forward) UnmarshalExtendedForm(unmarshal func(interface{}) error) error
 	if err != nil {
 		return err
 	}
-	f.Local = rawForward.Local
+	f.Local = int(rawForward.Local)
 	f.Remote = rawForward.Remote
 	f.ServiceName = rawForward.ServiceName
 	f.Labels = rawForward.Labels
+	if err := ValidateProtocol(rawForward.Protocol); err != nil {
+		return err
+	}
+	if rawForward.Protocol == UDPProtocol {
+		f.Protocol = UDPProtocol
+	}
 	if len(rawForward.Labels) != 0 || rawForward.ServiceName != "" {
 		f.Service = true
 	}
@@ -24,6 +24,16 @@ func TestForward_MarshalYAML(t *testing.T) {
 			expected: "8080:svc:5214",
 			data:     Forward{Local: 8080, Remote: 5214, Service: true, ServiceName: "svc"},
 		},
+		{
+			name:     "udp",
+			expected: "8125:8125/udp",
+			data:     Forward{Local: 8125, Remote: 8125, Protocol: UDPProtocol},
+		},
+		{
+			name:     "service-with-udp-port",
+			expected: "8125:svc:8125/udp",
+			data:     Forward{Local: 8125, Remote: 8125, Service: true, ServiceName: "svc", Protocol: UDPProtocol},
+		},
 	}
 
 	for _, tt := range tests {
@@ -59,12 +69,62 @@ func TestForward_UnmarshalYAML(t *testing.T) {
 			data:     "8080:8080",
 			expected: Forward{Local: 8080, Remote: 8080},
 		},
+		{
+			name:     "auto",
+			data:     "auto:8080",
+			expected: Forward{Local: 0, Remote: 8080},
+		},
+		{
+			name:     "service-with-auto",
+			data:     "auto:svc:5432",
+			expected: Forward{Local: 0, Remote: 5432, Service: true, ServiceName: "svc"},
+		},
 		{
 			name:      "service-with-port",
 			data:      "8080:svc:5214",
 			expectErr: false,
 			expected:  Forward{Local: 8080, Remote: 5214, Service: true, ServiceName: "svc"},
 		},
+		{
+			name:      "udp",
+			data:      "8125:8125/udp",
+			expectErr: true,
+		},
+		{
+			name:      "service-with-udp-port",
+			data:      "8125:svc:8125/udp",
+			expectErr: true,
+		},
+		{
+			name:      "extended-udp",
+			data:      "localPort: 8125\nremotePort: 8125\nname: svc\nprotocol: udp",
+			expectErr: true,
+		},
+		{
+			name:     "sockets",
+			data:     "./run/pg.sock:/var/run/postgresql/.s.PGSQL.5432",
+			expected: Forward{LocalSocket: "./run/pg.sock", RemoteSocket: "/var/run/postgresql/.s.PGSQL.5432"},
+		},
+		{
+			name:     "port-to-socket",
+			data:     "2375:/var/run/docker.sock",
+			expected: Forward{Local: 2375, RemoteSocket: "/var/run/docker.sock"},
+		},
+		{
+			name:     "socket-to-port",
+			data:     "../pg.sock:5432",
+			expected: Forward{LocalSocket: "../pg.sock", Remote: 5432},
+		},
+		{
+			name:      "socket-with-bad-port",
+			data:      "./pg.sock:svc",
+			expectErr: true,
+		},
+		{
+			name:      "unknown-protocol",
+			data:      "8080:8080/sctp",
+			expectErr: true,
+		},
 		{
 			name:      "bad-local-port",
 			data:      "local:8080",
@@ -164,3 +224,45 @@ func TestForwardExtended_MarshalYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestForward_UnmarshalYAMLProtocol(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected Forward
+	}{
+		{
+			name:     "tcp-suffix",
+			data:     "8080:9090/tcp",
+			expected: Forward{Local: 8080, Remote: 9090},
+		},
+		{
+			name:     "extended-auto",
+			data:     "localPort: auto\nremotePort: 5432\nname: svc",
+			expected: Forward{Local: 0, Remote: 5432, Service: true, ServiceName: "svc"},
+		},
+		{
+			name:     "zero-local-port",
+			data:     "0:8080",
+			expected: Forward{Local: 0, Remote: 8080},
+		},
+		{
+			name:     "extended-tcp",
+			data:     "localPort: 8080\nremotePort: 8080\nname: svc\nprotocol: tcp",
+			expected: Forward{Local: 8080, Remote: 8080, Service: true, ServiceName: "svc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result Forward
+			if err := yaml.Unmarshal([]byte(tt.data), &result); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("didn't unmarshal correctly. Actual '%+v', Expected '%+v'", result, tt.expected)
+			}
+		})
+	}
+}
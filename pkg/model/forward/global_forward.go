@@ -15,6 +15,8 @@ package forward
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 const malformedGlobalForward = "Wrong global forward syntax '%s', must be of the form 'localPort:serviceName:remotePort'"
@@ -29,16 +31,44 @@ type GlobalForward struct {
 }
 
 type GlobalForwardRaw struct {
-	Local       int               `json:"localPort" yaml:"localPort"`
+	Local       LocalPort         `json:"localPort" yaml:"localPort"`
 	Remote      int               `json:"remotePort" yaml:"remotePort"`
 	ServiceName string            `json:"name" yaml:"name"`
 	Labels      map[string]string `json:"labels" yaml:"labels"`
 }
 
 func (gf GlobalForward) String() string {
-	return fmt.Sprintf("%d:%s:%d", gf.Local, gf.ServiceName, gf.Remote)
+	return fmt.Sprintf("%s:%s:%d", LocalPortString(gf.Local), gf.ServiceName, gf.Remote)
+}
+
+// IsAuto returns true if the local port of the global forward is allocated by okteto
+func (gf *GlobalForward) IsAuto() bool {
+	return gf.Local == 0
 }
 
 func (gf *GlobalForward) less(c *GlobalForward) bool {
 	return gf.Local < c.Local
 }
+
+// ParseGlobalForward parses a global forward of the form 'serviceName:remotePort' or 'localPort:serviceName:remotePort'.
+// The local port is the remote port if it is not defined, and 'auto' lets okteto allocate it
+func ParseGlobalForward(raw string) (GlobalForward, error) {
+	parts := strings.Split(raw, ":")
+	if len(parts) == 2 {
+		parts = []string{parts[1], parts[0], parts[1]}
+	}
+	if len(parts) != 3 || parts[1] == "" {
+		return GlobalForward{}, fmt.Errorf(malformedGlobalForward, raw)
+	}
+
+	local, err := ParseLocalPort(parts[0])
+	if err != nil || local < 0 || local > 65535 {
+		return GlobalForward{}, fmt.Errorf("invalid local port '%s' in port-forward '%s'", parts[0], raw)
+	}
+	remote, err := strconv.Atoi(parts[2])
+	if err != nil || remote < 1 || remote > 65535 {
+		return GlobalForward{}, fmt.Errorf("invalid remote port '%s' in port-forward '%s'", parts[2], raw)
+	}
+
+	return GlobalForward{Local: local, Remote: remote, ServiceName: parts[1]}, nil
+}
@@ -9,6 +9,7 @@ import (
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg for port forwards.
 // It supports the following options:
 // - int:serviceName:int
+// The local port can also be 'auto', to let okteto allocate it.
 // Anything else will result in an error
 func (gf *GlobalForward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
@@ -29,7 +30,7 @@ func (gf *GlobalForward) UnmarshalYAML(unmarshal func(interface{}) error) error
 
 	gf.ServiceName = svcName
 
-	localPort, err := strconv.Atoi(parts[0])
+	localPort, err := ParseLocalPort(parts[0])
 	if err != nil {
 		return fmt.Errorf("Cannot convert local port '%s' in port-forward '%s'", parts[0], raw)
 	}
@@ -55,7 +56,7 @@ func (gf *GlobalForward) UnmarshalExtendedForm(unmarshal func(interface{}) error
 	if err != nil {
 		return err
 	}
-	gf.Local = rawGlobalForward.Local
+	gf.Local = int(rawGlobalForward.Local)
 	gf.Remote = rawGlobalForward.Remote
 	gf.ServiceName = rawGlobalForward.ServiceName
 	gf.Labels = rawGlobalForward.Labels
@@ -70,6 +70,11 @@ func TestGlobalForward_UnmarshalYAML(t *testing.T) {
 			expectErr: false,
 			expected:  GlobalForward{Local: 8080, Remote: 5214, ServiceName: "svc"},
 		},
+		{
+			name:     "service-with-auto",
+			data:     "auto:svc:5214",
+			expected: GlobalForward{Local: 0, Remote: 5214, ServiceName: "svc"},
+		},
 		{
 			name:      "bad-local-port",
 			data:      "local:8080",
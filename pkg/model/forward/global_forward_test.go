@@ -14,6 +14,7 @@
 package forward
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -63,3 +64,31 @@ func TestGlobalForward_less(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGlobalForward(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    GlobalForward
+		wantErr bool
+	}{
+		{raw: "api:8080", want: GlobalForward{Local: 8080, Remote: 8080, ServiceName: "api"}},
+		{raw: "9000:api:8080", want: GlobalForward{Local: 9000, Remote: 8080, ServiceName: "api"}},
+		{raw: "auto:api:8080", want: GlobalForward{Local: 0, Remote: 8080, ServiceName: "api"}},
+		{raw: "api", wantErr: true},
+		{raw: "9000::8080", wantErr: true},
+		{raw: "api:http", wantErr: true},
+		{raw: "a:api:8080", wantErr: true},
+		{raw: "1:2:3:4", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := ParseGlobalForward(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGlobalForward() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseGlobalForward() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
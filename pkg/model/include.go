@@ -0,0 +1,328 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/discovery"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/externalresource"
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/model/forward"
+)
+
+// includedManifest is a manifest of the include section, with its paths relative to the folder of the root manifest
+type includedManifest struct {
+	path     string
+	manifest *Manifest
+}
+
+// includeLoader reads the manifests included by a manifest and the manifests they include
+type includeLoader struct {
+	rootDir  string
+	stack    []string
+	visited  map[string]bool
+	included []includedManifest
+}
+
+// loadIncludes merges the build, dev, deploy, destroy, dependencies, forward, external and profiles sections of the included manifests.
+// Paths of the included manifests are relative to their own file, and a name defined by two manifests is an error
+func (m *Manifest) loadIncludes(manifestPath string) error {
+	if len(m.Include) == 0 {
+		return nil
+	}
+	manifestPath, err := filepath.Abs(manifestPath)
+	if err != nil {
+		return err
+	}
+	l := &includeLoader{
+		rootDir: filepath.Dir(manifestPath),
+		stack:   []string{manifestPath},
+		visited: map[string]bool{manifestPath: true},
+	}
+	if err := l.collect(m, manifestPath); err != nil {
+		return err
+	}
+
+	origins := newIncludeOrigins(m, l.relPath(manifestPath))
+	for _, included := range l.included {
+		if err := origins.merge(m, included); err != nil {
+			return err
+		}
+	}
+	return m.validate()
+}
+
+// collect reads the manifests included by m, depth first
+func (l *includeLoader) collect(m *Manifest, manifestPath string) error {
+	for _, include := range m.Include {
+		path, err := resolveIncludePath(filepath.Dir(manifestPath), include)
+		if err != nil {
+			return err
+		}
+		for _, p := range l.stack {
+			if p == path {
+				return fmt.Errorf("%w: '%s' is included by itself: %s", oktetoErrors.ErrInvalidManifest, l.relPath(path), l.cycle(path))
+			}
+		}
+		// manifests included more than once are merged once
+		if l.visited[path] {
+			continue
+		}
+		l.visited[path] = true
+
+		included, err := l.read(path)
+		if err != nil {
+			return err
+		}
+		l.included = append(l.included, includedManifest{path: l.relPath(path), manifest: included})
+
+		l.stack = append(l.stack, path)
+		if err := l.collect(included, path); err != nil {
+			return err
+		}
+		l.stack = l.stack[:len(l.stack)-1]
+	}
+	return nil
+}
+
+// resolveIncludePath returns the absolute path of an included manifest, a file or a folder with an okteto manifest
+func resolveIncludePath(dir, include string) (string, error) {
+	path := include
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if pathExistsAndDir(path) {
+		manifestPath, err := discovery.GetOktetoManifestPath(path)
+		if err != nil {
+			return "", fmt.Errorf("%w: the included folder '%s' has no okteto manifest", oktetoErrors.ErrInvalidManifest, include)
+		}
+		path = manifestPath
+	}
+	if !filesystem.FileExistsAndNotDir(path) {
+		return "", fmt.Errorf("%w: the included manifest '%s' doesn't exist", oktetoErrors.ErrInvalidManifest, include)
+	}
+	return filepath.Abs(path)
+}
+
+// read returns the included manifest at path, with its build contexts and commands relative to the root manifest
+func (l *includeLoader) read(path string) (*Manifest, error) {
+	m, err := readOktetoManifest(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the included manifest '%s': %w", l.relPath(path), err)
+	}
+	if !m.IsV2 {
+		return nil, fmt.Errorf("%w: the included manifest '%s' is read as a single development container, define it in the 'dev' section", oktetoErrors.ErrInvalidManifest, l.relPath(path))
+	}
+	if m.Deploy != nil && (m.Deploy.ComposeSection != nil || m.Deploy.Divert != nil || m.Deploy.Endpoints != nil || m.Deploy.Image != "" || m.Deploy.Remote) {
+		return nil, fmt.Errorf("%w: the included manifest '%s' can only define commands in the 'deploy' section", oktetoErrors.ErrInvalidManifest, l.relPath(path))
+	}
+
+	dir := l.relPath(filepath.Dir(path))
+	if dir == "." {
+		return m, nil
+	}
+	for _, b := range m.Build {
+		b.rebase(filepath.Dir(path), dir)
+	}
+	if m.Deploy != nil {
+		m.Deploy.Commands = commandsInDir(m.Deploy.Commands, dir)
+	}
+	if m.Destroy != nil {
+		m.Destroy.Commands = commandsInDir(m.Destroy.Commands, dir)
+	}
+	return m, nil
+}
+
+// rebase makes the context of an image of an included manifest relative to the folder of the root manifest
+func (b *BuildInfo) rebase(manifestDir, dir string) {
+	if _, err := url.ParseRequestURI(b.Context); err == nil {
+		return
+	}
+	context := b.Context
+	if !filepath.IsAbs(b.Context) {
+		b.Context = filepath.Join(dir, b.Context)
+	}
+	// dockerfiles are relative to the context, or to the folder of the manifest
+	if b.Dockerfile == "" || filepath.IsAbs(b.Dockerfile) {
+		return
+	}
+	if !filesystem.FileExistsAndNotDir(filepath.Join(manifestDir, context, b.Dockerfile)) && filesystem.FileExistsAndNotDir(filepath.Join(manifestDir, b.Dockerfile)) {
+		b.Dockerfile = filepath.Join(dir, b.Dockerfile)
+	}
+}
+
+// commandsInDir runs the commands of an included manifest from its folder
+func commandsInDir(commands []DeployCommand, dir string) []DeployCommand {
+	result := make([]DeployCommand, 0, len(commands))
+	for _, c := range commands {
+		result = append(result, DeployCommand{
+			Name:    c.Name,
+			Command: fmt.Sprintf("cd %q && %s", filepath.ToSlash(dir), c.Command),
+		})
+	}
+	return result
+}
+
+func (l *includeLoader) relPath(path string) string {
+	if rel, err := filepath.Rel(l.rootDir, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+func (l *includeLoader) cycle(path string) string {
+	var result []string
+	for _, p := range l.stack {
+		result = append(result, l.relPath(p))
+	}
+	return strings.Join(append(result, l.relPath(path)), " -> ")
+}
+
+// includeOrigins are the manifests that define each name of the merged manifest
+type includeOrigins map[string]string
+
+func newIncludeOrigins(m *Manifest, path string) includeOrigins {
+	origins := includeOrigins{}
+	for name := range m.Build {
+		origins[fmt.Sprintf("build '%s'", name)] = path
+	}
+	for name := range m.Dev {
+		origins[fmt.Sprintf("dev '%s'", name)] = path
+	}
+	for name := range m.Dependencies {
+		origins[fmt.Sprintf("dependency '%s'", name)] = path
+	}
+	for name := range m.External {
+		origins[fmt.Sprintf("external '%s'", name)] = path
+	}
+	for name := range m.Profiles {
+		origins[fmt.Sprintf("profile '%s'", name)] = path
+	}
+	for _, f := range m.GlobalForward {
+		origins[globalForwardKey(f)] = path
+	}
+	return origins
+}
+
+// add returns an error if the name is already defined by another manifest
+func (o includeOrigins) add(name, path string) error {
+	if previous, ok := o[name]; ok {
+		return fmt.Errorf("%w: %s is defined in both '%s' and '%s'", oktetoErrors.ErrInvalidManifest, name, previous, path)
+	}
+	o[name] = path
+	return nil
+}
+
+// merge adds the sections of an included manifest to the root manifest
+func (o includeOrigins) merge(m *Manifest, included includedManifest) error {
+	for _, name := range sortedNames(included.manifest.Build) {
+		b := included.manifest.Build[name]
+		if err := o.add(fmt.Sprintf("build '%s'", name), included.path); err != nil {
+			return err
+		}
+		if m.Build == nil {
+			m.Build = ManifestBuild{}
+		}
+		m.Build[name] = b
+	}
+	for _, name := range sortedNames(included.manifest.Dev) {
+		d := included.manifest.Dev[name]
+		if err := o.add(fmt.Sprintf("dev '%s'", name), included.path); err != nil {
+			return err
+		}
+		if m.Dev == nil {
+			m.Dev = ManifestDevs{}
+		}
+		m.Dev[name] = d
+	}
+	for _, name := range sortedNames(included.manifest.Dependencies) {
+		d := included.manifest.Dependencies[name]
+		if err := o.add(fmt.Sprintf("dependency '%s'", name), included.path); err != nil {
+			return err
+		}
+		if m.Dependencies == nil {
+			m.Dependencies = ManifestDependencies{}
+		}
+		m.Dependencies[name] = d
+	}
+	for _, name := range sortedNames(included.manifest.External) {
+		e := included.manifest.External[name]
+		if err := o.add(fmt.Sprintf("external '%s'", name), included.path); err != nil {
+			return err
+		}
+		if m.External == nil {
+			m.External = externalresource.ExternalResourceSection{}
+		}
+		m.External[name] = e
+	}
+	for _, name := range sortedNames(included.manifest.Profiles) {
+		if err := o.add(fmt.Sprintf("profile '%s'", name), included.path); err != nil {
+			return err
+		}
+		if m.Profiles == nil {
+			m.Profiles = ManifestProfiles{}
+		}
+		m.Profiles[name] = included.manifest.Profiles[name]
+	}
+	for _, f := range included.manifest.GlobalForward {
+		if err := o.add(globalForwardKey(f), included.path); err != nil {
+			return err
+		}
+		m.GlobalForward = append(m.GlobalForward, f)
+	}
+
+	if included.manifest.Deploy != nil && len(included.manifest.Deploy.Commands) > 0 {
+		if m.Deploy == nil {
+			m.Deploy = NewDeployInfo()
+		}
+		m.Deploy.Commands = append(m.Deploy.Commands, included.manifest.Deploy.Commands...)
+	}
+	if included.manifest.Destroy != nil && len(included.manifest.Destroy.Commands) > 0 {
+		if m.Destroy == nil {
+			m.Destroy = NewDestroyInfo()
+		}
+		m.Destroy.Commands = append(m.Destroy.Commands, included.manifest.Destroy.Commands...)
+	}
+	return nil
+}
+
+// sortedNames returns the names of a section sorted, so the conflicts are always reported in the same order
+func sortedNames(section interface{}) []string {
+	var result []string
+	for _, key := range reflect.ValueOf(section).MapKeys() {
+		result = append(result, key.String())
+	}
+	sort.Strings(result)
+	return result
+}
+
+// globalForwardKey identifies a global forward by its local port.
+// The local ports of the automatic forwards aren't allocated yet, so they are identified by their service and remote port
+func globalForwardKey(f forward.GlobalForward) string {
+	if f.IsAuto() {
+		service := f.ServiceName
+		if service == "" {
+			service = fmt.Sprintf("labels %v", f.Labels)
+		}
+		return fmt.Sprintf("the automatic forward of %s port %d", service, f.Remote)
+	}
+	return fmt.Sprintf("the forward of local port %d", f.Local)
+}
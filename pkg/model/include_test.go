@@ -0,0 +1,207 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifests(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	return dir
+}
+
+func TestManifestInclude(t *testing.T) {
+	dir := writeManifests(t, map[string]string{
+		"okteto.yml": `include:
+  - services/api
+  - services/web/okteto.yml
+deploy:
+  - helm upgrade --install db chart
+forward:
+  - 5432:db:5432
+`,
+		"services/api/okteto.yml": `build:
+  api:
+    context: .
+deploy:
+  - kubectl apply -f k8s.yml
+destroy:
+  - kubectl delete -f k8s.yml
+dev:
+  api:
+    command: bash
+    sync:
+      - .:/app
+external:
+  docs:
+    endpoints:
+      - name: api
+        url: https://api.example.com
+`,
+		"services/api/Dockerfile": "FROM alpine",
+		"services/web/okteto.yml": `include:
+  - ../api/okteto.yml
+build:
+  web:
+    context: src
+    dockerfile: Dockerfile
+deploy:
+  - helm upgrade --install web chart
+dev:
+  web:
+    command: yarn start
+    sync:
+      - src:/app
+forward:
+  - 8080:web:80
+`,
+		"services/web/Dockerfile": "FROM node",
+	})
+
+	m, err := getOktetoManifest(filepath.Join(dir, "okteto.yml"))
+	require.NoError(t, err)
+
+	require.Contains(t, m.Build, "api")
+	assert.Equal(t, filepath.Join("services", "api"), m.Build["api"].Context)
+	assert.Equal(t, "Dockerfile", m.Build["api"].Dockerfile)
+	require.Contains(t, m.Build, "web")
+	assert.Equal(t, filepath.Join("services", "web", "src"), m.Build["web"].Context)
+	assert.Equal(t, filepath.Join("services", "web", "Dockerfile"), m.Build["web"].Dockerfile)
+
+	require.Contains(t, m.Dev, "api")
+	assert.Equal(t, filepath.Join(dir, "services", "api"), m.Dev["api"].Sync.Folders[0].LocalPath)
+	require.Contains(t, m.Dev, "web")
+	assert.Equal(t, filepath.Join(dir, "services", "web", "src"), m.Dev["web"].Sync.Folders[0].LocalPath)
+
+	assert.Equal(t, []DeployCommand{
+		{Name: "helm upgrade --install db chart", Command: "helm upgrade --install db chart"},
+		{Name: "kubectl apply -f k8s.yml", Command: `cd "services/api" && kubectl apply -f k8s.yml`},
+		{Name: "helm upgrade --install web chart", Command: `cd "services/web" && helm upgrade --install web chart`},
+	}, m.Deploy.Commands)
+	assert.Equal(t, []DeployCommand{
+		{Name: "kubectl delete -f k8s.yml", Command: `cd "services/api" && kubectl delete -f k8s.yml`},
+	}, m.Destroy.Commands)
+	assert.Len(t, m.GlobalForward, 2)
+	assert.Contains(t, m.External, "docs")
+}
+
+func TestManifestIncludeAutoForwards(t *testing.T) {
+	dir := writeManifests(t, map[string]string{
+		"okteto.yml":     "include: [svc/okteto.yml]\nforward:\n  - auto:api:8080\ndeploy: [echo]\n",
+		"svc/okteto.yml": "deploy: [echo]\nforward:\n  - auto:db:5432\n",
+	})
+
+	m, err := getOktetoManifest(filepath.Join(dir, "okteto.yml"))
+	require.NoError(t, err)
+	require.Len(t, m.GlobalForward, 2)
+	assert.True(t, m.GlobalForward[0].IsAuto())
+	assert.True(t, m.GlobalForward[1].IsAuto())
+}
+
+func TestManifestIncludeErrors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{
+			name: "dev-conflict",
+			files: map[string]string{
+				"okteto.yml": "include: [api.yml]\ndev:\n  api:\n    command: bash\n",
+				"api.yml":    "dev:\n  api:\n    command: sh\n",
+			},
+			expected: "dev 'api' is defined in both 'okteto.yml' and 'api.yml'",
+		},
+		{
+			name: "build-conflict-between-includes",
+			files: map[string]string{
+				"okteto.yml":   "include: [a/okteto.yml, b/okteto.yml]\ndeploy: [echo]\n",
+				"a/okteto.yml": "build:\n  api:\n    context: .\n",
+				"b/okteto.yml": "build:\n  api:\n    context: .\n",
+			},
+			expected: "build 'api' is defined in both 'a/okteto.yml' and 'b/okteto.yml'",
+		},
+		{
+			name: "forward-conflict",
+			files: map[string]string{
+				"okteto.yml": "include: [db.yml]\nforward:\n  - 5432:db:5432\ndeploy: [echo]\n",
+				"db.yml":     "deploy: [echo]\nforward:\n  - 5432:postgres:5432\n",
+			},
+			expected: "the forward of local port 5432 is defined in both 'okteto.yml' and 'db.yml'",
+		},
+		{
+			name: "auto-forward-conflict",
+			files: map[string]string{
+				"okteto.yml": "include: [db.yml]\nforward:\n  - auto:db:5432\ndeploy: [echo]\n",
+				"db.yml":     "deploy: [echo]\nforward:\n  - auto:db:5432\n",
+			},
+			expected: "the automatic forward of db port 5432 is defined in both 'okteto.yml' and 'db.yml'",
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				"okteto.yml": "include: [a.yml]\ndeploy: [echo]\n",
+				"a.yml":      "include: [b.yml]\ndeploy: [echo]\n",
+				"b.yml":      "include: [okteto.yml]\ndeploy: [echo]\n",
+			},
+			expected: "'okteto.yml' is included by itself: okteto.yml -> a.yml -> b.yml -> okteto.yml",
+		},
+		{
+			name: "single-dev",
+			files: map[string]string{
+				"okteto.yml": "include: [api.yml]\ndeploy: [echo]\n",
+				"api.yml":    "name: api\ncommand: bash\n",
+			},
+			expected: "the included manifest 'api.yml' is read as a single development container",
+		},
+		{
+			name: "not-found",
+			files: map[string]string{
+				"okteto.yml": "include: [missing.yml]\ndeploy: [echo]\n",
+			},
+			expected: "the included manifest 'missing.yml' doesn't exist",
+		},
+		{
+			name: "compose",
+			files: map[string]string{
+				"okteto.yml": "include: [stack.yml]\ndeploy: [echo]\n",
+				"stack.yml":  "deploy:\n  compose: docker-compose.yml\n",
+			},
+			expected: "the included manifest 'stack.yml' can only define commands in the 'deploy' section",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeManifests(t, tt.files)
+			_, err := getOktetoManifest(filepath.Join(dir, "okteto.yml"))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, oktetoErrors.ErrInvalidManifest)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
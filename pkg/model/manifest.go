@@ -104,6 +104,8 @@ type Manifest struct {
 	Dependencies  ManifestDependencies                     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	GlobalForward []forward.GlobalForward                  `json:"forward,omitempty" yaml:"forward,omitempty"`
 	External      externalresource.ExternalResourceSection `json:"external,omitempty" yaml:"external,omitempty"`
+	Include       []string                                 `json:"include,omitempty" yaml:"include,omitempty"`
+	Profiles      ManifestProfiles                         `json:"profiles,omitempty" yaml:"profiles,omitempty"`
 
 	Type     Archetype `json:"-" yaml:"-"`
 	Manifest []byte    `json:"-" yaml:"-"`
@@ -610,8 +612,20 @@ func inferHelmTags(path string) string {
 	return result
 }
 
-// getOktetoManifest returns an okteto object from a given file
+// getOktetoManifest returns an okteto object from a given file, merged with the manifests it includes
 func getOktetoManifest(devPath string) (*Manifest, error) {
+	manifest, err := readOktetoManifest(devPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := manifest.loadIncludes(devPath); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// readOktetoManifest returns an okteto object from a given file, without the manifests it includes
+func readOktetoManifest(devPath string) (*Manifest, error) {
 	b, err := os.ReadFile(devPath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -626,6 +640,7 @@ func getOktetoManifest(devPath string) (*Manifest, error) {
 
 	manifest, err := Read(b)
 	if err != nil {
+		err = setYAMLErrorFile(err, devPath)
 		if errors.Is(err, oktetoErrors.ErrNotManifestContentDetected) {
 			return nil, err
 		}
@@ -679,6 +694,10 @@ func Read(bytes []byte) (*Manifest, error) {
 				}
 			}
 
+			if yamlErr := newYAMLError("", manifestDocsURL, bytes, ManifestSchema(), err); yamlErr != nil {
+				return nil, yamlErr
+			}
+
 			if strings.HasPrefix(err.Error(), "yaml: unmarshal errors:") {
 				var sb strings.Builder
 				l := strings.Split(err.Error(), "\n")
@@ -688,13 +707,21 @@ func Read(bytes []byte) (*Manifest, error) {
 					_, _ = sb.WriteString(fmt.Sprintf("    - %s\n", e))
 				}
 
-				_, _ = sb.WriteString(fmt.Sprintf("    See %s for details", "https://okteto.com/docs/reference/manifest/"))
+				_, _ = sb.WriteString(fmt.Sprintf("    See %s for details", manifestDocsURL))
 				return nil, fmt.Errorf("\n%s", sb.String())
 			}
 
 			msg := strings.TrimSuffix(err.Error(), "in type model.Manifest")
 			return nil, fmt.Errorf("\n%s", msg)
 		}
+
+		if manifest.Dev.hasExtends() {
+			resolved, err := readDevExtends(bytes)
+			if err != nil {
+				return nil, err
+			}
+			manifest = resolved
+		}
 	}
 
 	hasShownWarning := false
@@ -721,6 +748,9 @@ func (m *Manifest) validate() error {
 	if err := m.Build.validate(); err != nil {
 		return err
 	}
+	if err := m.validateDevExtends(); err != nil {
+		return err
+	}
 	return m.validateDivert()
 }
 
@@ -1164,6 +1194,23 @@ func (m *Manifest) InferFromStack(cwd string) (*Manifest, error) {
 
 // WriteToFile writes a manifest to a file with comments to make it easier to understand
 func (m *Manifest) WriteToFile(filePath string) error {
+	doc, err := m.toCommentedNode()
+	if err != nil {
+		return err
+	}
+	out, err := encodeCommentedNode(doc)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filePath, out, 0600); err != nil {
+		oktetoLog.Infof("failed to write stignore file: %s", err)
+		return err
+	}
+	return nil
+}
+
+// toCommentedNode returns the manifest as a yaml node with comments to make it easier to understand
+func (m *Manifest) toCommentedNode() (*yaml3.Node, error) {
 	if m.Deploy != nil {
 		if len(m.Deploy.Commands) == 0 && m.Deploy.ComposeSection == nil {
 			m.Deploy.Commands = []DeployCommand{
@@ -1206,11 +1253,11 @@ func (m *Manifest) WriteToFile(filePath string) error {
 	// Unmarshal with yamlv2 because we have the marshal with yaml v2
 	b, err := yaml.Marshal(m)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	doc := yaml3.Node{}
 	if err := yaml3.Unmarshal(b, &doc); err != nil {
-		return err
+		return nil, err
 	}
 	doc = *doc.Content[0]
 	currentSection := ""
@@ -1241,21 +1288,19 @@ func (m *Manifest) WriteToFile(filePath string) error {
 	}
 
 	m.reorderDocFields(&doc)
+	return &doc, nil
+}
 
+// encodeCommentedNode encodes a manifest node with an empty line before the comments of each section
+func encodeCommentedNode(doc *yaml3.Node) ([]byte, error) {
 	buffer := bytes.NewBuffer(nil)
 	encoder := yaml3.NewEncoder(buffer)
 	encoder.SetIndent(2)
 
-	err = encoder.Encode(doc)
-	if err != nil {
-		return err
-	}
-	out := addEmptyLineBetweenSections(buffer.Bytes())
-	if err := os.WriteFile(filePath, out, 0600); err != nil {
-		oktetoLog.Infof("failed to write stignore file: %s", err)
-		return err
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
 	}
-	return nil
+	return addEmptyLineBetweenSections(buffer.Bytes()), nil
 }
 
 // reorderDocFields orders the manifest to be: name -> build -> deploy -> dependencies -> dev
@@ -1274,7 +1319,7 @@ func (*Manifest) reorderDocFields(doc *yaml3.Node) {
 		nodes = append(nodes, buildDefinitionIdx, buildDefinitionIdx+1)
 	} else {
 		whereToInject := getDocIdxWithPrior(contentCopy, "name")
-		contentCopy[whereToInject].FootComment = fmt.Sprintf("%s\n%s", buildHeadComment, buildExample)
+		addFootComment(doc, contentCopy, whereToInject, fmt.Sprintf("%s\n%s", buildHeadComment, buildExample))
 	}
 
 	deployDefinitionIdx := getDocIdx(doc.Content, "deploy")
@@ -1287,11 +1332,7 @@ func (*Manifest) reorderDocFields(doc *yaml3.Node) {
 		if buildDefinitionIdx == -1 {
 			footComment = "\n" + footComment
 		}
-		if contentCopy[whereToInject].FootComment == "" {
-			contentCopy[whereToInject].FootComment = footComment
-		} else {
-			contentCopy[whereToInject].FootComment += footComment
-		}
+		addFootComment(doc, contentCopy, whereToInject, footComment)
 	}
 
 	dependenciesDefinitionIdx := getDocIdx(doc.Content, "dependencies")
@@ -1304,11 +1345,7 @@ func (*Manifest) reorderDocFields(doc *yaml3.Node) {
 		if deployDefinitionIdx == -1 {
 			footComment = "\n\n" + footComment
 		}
-		if contentCopy[whereToInject].FootComment == "" {
-			contentCopy[whereToInject].FootComment = footComment
-		} else {
-			contentCopy[whereToInject].FootComment += footComment
-		}
+		addFootComment(doc, contentCopy, whereToInject, footComment)
 	}
 
 	devDefinitionIdx := getDocIdx(doc.Content, "dev")
@@ -1321,11 +1358,7 @@ func (*Manifest) reorderDocFields(doc *yaml3.Node) {
 		if dependenciesDefinitionIdx == -1 {
 			footComment = "\n" + footComment
 		}
-		if contentCopy[whereToInject].FootComment == "" {
-			contentCopy[whereToInject].FootComment = footComment
-		} else {
-			contentCopy[whereToInject].FootComment += footComment
-		}
+		addFootComment(doc, contentCopy, whereToInject, footComment)
 	}
 
 	// We need to inject all the other fields remaining
@@ -1347,6 +1380,15 @@ func (*Manifest) reorderDocFields(doc *yaml3.Node) {
 	doc.Content = contentCopy
 }
 
+// addFootComment adds a comment after the node at idx, or before the fields of the document if there is no node before it
+func addFootComment(doc *yaml3.Node, contents []*yaml3.Node, idx int, comment string) {
+	if idx == -1 {
+		doc.HeadComment += comment
+		return
+	}
+	contents[idx].FootComment += comment
+}
+
 func getDocIdxWithPrior(contents []*yaml3.Node, value string) int {
 	for idx, node := range contents {
 		if node.Value == value {
@@ -1354,7 +1396,7 @@ func getDocIdxWithPrior(contents []*yaml3.Node, value string) int {
 		}
 	}
 	for idx, val := range priorityOrder {
-		if val == value {
+		if val == value && idx+1 < len(priorityOrder) {
 			return getDocIdx(contents, priorityOrder[idx+1])
 		}
 	}
@@ -0,0 +1,254 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/discovery"
+	"github.com/okteto/okteto/pkg/format"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrManifestAlreadyV2 is raised when migrating a manifest that is already a manifest v2
+	ErrManifestAlreadyV2 = errors.New("the manifest is already a manifest v2")
+)
+
+// devFieldsMovedToRoot are the fields of a manifest v1 defined at the root of a manifest v2
+var devFieldsMovedToRoot = []string{"namespace", "context"}
+
+// Migration is the result of migrating a manifest v1 to a manifest v2
+type Migration struct {
+	// Content is the manifest v2
+	Content []byte
+
+	// PipelinePath is the pipeline file whose deploy and destroy commands are in the manifest v2, if any
+	PipelinePath string
+
+	// ComposePath is the compose file referenced by the deploy section of the manifest v2, if any
+	ComposePath string
+
+	// IgnoredComposePath is the compose file of the folder not referenced because the deploy section comes from the pipeline file, if any
+	IgnoredComposePath string
+}
+
+// MigrateToV2 returns a manifest v2 with the development container of the manifest v1 at manifestPath.
+// The deploy section comes from the pipeline file of its folder or, if the pipeline file has no deploy commands, references its compose file.
+// Comments of the manifest v1 and the pipeline file are preserved
+func MigrateToV2(manifestPath string) (*Migration, error) {
+	b, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := Read(b)
+	if err != nil {
+		return nil, setYAMLErrorFile(err, manifestPath)
+	}
+	if manifest.IsV2 {
+		return nil, ErrManifestAlreadyV2
+	}
+	root, err := mappingRoot(b)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := filepath.Abs(filepath.Dir(manifestPath))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Migration{}
+	name := migratedDevName(root, dir)
+	v2 := NewManifest()
+	v2.Deploy = nil
+	v2.Dev[name] = NewDev()
+	for _, field := range devFieldsMovedToRoot {
+		if value := mappingNodeValue(root, field); value != nil {
+			switch field {
+			case "namespace":
+				v2.Namespace = value.Value
+			case "context":
+				v2.Context = value.Value
+			}
+		}
+	}
+
+	// images built with the 'image' extended syntax are moved to the build section
+	image := mappingNodeValue(root, "image")
+	buildImage := image != nil && image.Kind == yaml3.MappingNode && (mappingNodeValue(image, "context") != nil || mappingNodeValue(image, "dockerfile") != nil)
+	if buildImage {
+		v2.Build[name] = &BuildInfo{}
+	}
+
+	var pipeline *yaml3.Node
+	if pipelinePath, err := discovery.GetOktetoPipelinePath(dir); err == nil {
+		content, err := os.ReadFile(pipelinePath)
+		if err != nil {
+			return nil, err
+		}
+		if pipeline, err = mappingRoot(content); err != nil {
+			return nil, fmt.Errorf("error reading the pipeline file '%s': %w", pipelinePath, err)
+		}
+		if mappingNodeValue(pipeline, "deploy") != nil || mappingNodeValue(pipeline, "destroy") != nil {
+			result.PipelinePath = pipelinePath
+		}
+	}
+
+	// the deploy commands of the pipeline file replace this placeholder once the manifest v2 is a yaml node
+	pipelineDeploy := mappingNodeValue(pipeline, "deploy") != nil
+	if pipelineDeploy {
+		v2.Deploy = &DeployInfo{Commands: []DeployCommand{{Name: FakeCommand, Command: FakeCommand}}}
+	}
+	if composePath, err := discovery.GetComposePath(dir); err == nil {
+		if pipelineDeploy {
+			result.IgnoredComposePath = composePath
+		} else {
+			rel, err := filepath.Rel(dir, composePath)
+			if err != nil {
+				return nil, err
+			}
+			result.ComposePath = composePath
+			v2.Deploy = &DeployInfo{ComposeSection: &ComposeSectionInfo{ComposesInfo: []ComposeInfo{{File: filepath.ToSlash(rel)}}}}
+		}
+	}
+
+	doc, err := v2.toCommentedNode()
+	if err != nil {
+		return nil, err
+	}
+	moveToFront(doc, devFieldsMovedToRoot)
+	replaceMappingValue(mappingNodeValue(doc, "dev"), name, devNode(root, buildImage))
+	if buildImage {
+		replaceMappingValue(mappingNodeValue(doc, "build"), name, buildNode(image))
+	}
+	if pipeline != nil {
+		for _, section := range []string{"deploy", "destroy"} {
+			if value := mappingNodeValue(pipeline, section); value != nil {
+				if !replaceMappingValue(doc, section, value) {
+					doc.Content = append(doc.Content, &yaml3.Node{Kind: yaml3.ScalarNode, Value: section}, value)
+				}
+			}
+		}
+	}
+
+	if result.Content, err = encodeCommentedNode(doc); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mappingRoot returns the root mapping of a yaml document
+func mappingRoot(content []byte) (*yaml3.Node, error) {
+	doc := &yaml3.Node{}
+	if err := yaml3.Unmarshal(content, doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml3.MappingNode {
+		return nil, fmt.Errorf("the file must be a yaml object")
+	}
+	root := doc.Content[0]
+	// comments before the first field belong to the document
+	if doc.HeadComment != "" && len(root.Content) > 0 {
+		root.Content[0].HeadComment = strings.TrimSpace(doc.HeadComment + "\n" + root.Content[0].HeadComment)
+	}
+	return root, nil
+}
+
+// migratedDevName returns the name of the development container of a manifest v1, or the name of its folder
+func migratedDevName(root *yaml3.Node, dir string) string {
+	if name := mappingNodeValue(root, "name"); name != nil && name.Value != "" {
+		return name.Value
+	}
+	return format.ResourceK8sMetaString(filepath.Base(dir))
+}
+
+// devNode returns the fields of the manifest v1 that define the development container in the manifest v2
+func devNode(root *yaml3.Node, withoutImage bool) *yaml3.Node {
+	result := &yaml3.Node{Kind: yaml3.MappingNode, Tag: "!!map"}
+	removedComments := []string{}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i]
+		if key.Value == "name" || (withoutImage && key.Value == "image") || contains(devFieldsMovedToRoot, key.Value) {
+			if key.HeadComment != "" {
+				removedComments = append(removedComments, key.HeadComment)
+			}
+			continue
+		}
+		result.Content = append(result.Content, key, root.Content[i+1])
+	}
+	if len(removedComments) > 0 && len(result.Content) > 0 {
+		first := result.Content[0]
+		first.HeadComment = strings.TrimSpace(strings.Join(append(removedComments, first.HeadComment), "\n"))
+	}
+	return result
+}
+
+// buildNode returns the 'image' extended syntax of a manifest v1 as an entry of the build section
+func buildNode(image *yaml3.Node) *yaml3.Node {
+	result := &yaml3.Node{Kind: yaml3.MappingNode, Tag: "!!map"}
+	for i := 0; i+1 < len(image.Content); i += 2 {
+		key := *image.Content[i]
+		// the name of the image to build is the 'image' field of the build section
+		if key.Value == "name" {
+			key.Value = "image"
+		}
+		result.Content = append(result.Content, &key, image.Content[i+1])
+	}
+	return result
+}
+
+// mappingNodeValue returns the value of key in a mapping node
+func mappingNodeValue(m *yaml3.Node, key string) *yaml3.Node {
+	if m == nil || m.Kind != yaml3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// replaceMappingValue sets the value of key in a mapping node. It returns false if the key is not defined
+func replaceMappingValue(m *yaml3.Node, key string, value *yaml3.Node) bool {
+	if m == nil || m.Kind != yaml3.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return true
+		}
+	}
+	return false
+}
+
+// moveToFront moves the given keys of a mapping node before the rest of its keys
+func moveToFront(m *yaml3.Node, keys []string) {
+	front := []*yaml3.Node{}
+	rest := []*yaml3.Node{}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if contains(keys, m.Content[i].Value) {
+			front = append(front, m.Content[i], m.Content[i+1])
+			continue
+		}
+		rest = append(rest, m.Content[i], m.Content[i+1])
+	}
+	m.Content = append(front, rest...)
+}
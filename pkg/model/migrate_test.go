@@ -0,0 +1,183 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateToV2(t *testing.T) {
+	var tests = []struct {
+		name             string
+		manifest         string
+		pipeline         string
+		compose          string
+		expectedComments []string
+		// expectedIgnoredCompose is the name of the compose file not referenced from the deploy section
+		expectedIgnoredCompose string
+		check                  func(t *testing.T, m *Manifest)
+	}{
+		{
+			name: "pipeline",
+			manifest: `# Development container for the API
+name: api
+namespace: cindy
+image:
+  name: okteto.dev/api:dev
+  context: .
+command: ["bash"] # start a shell
+sync:
+  - .:/usr/src/app
+forward:
+  - 9229:9229 # debugger
+`,
+			pipeline: `deploy:
+  # deploy with helm
+  - helm upgrade --install api chart
+destroy:
+  - helm uninstall api
+`,
+			expectedComments: []string{"# Development container for the API", "# start a shell", "# debugger", "# deploy with helm"},
+			check: func(t *testing.T, m *Manifest) {
+				assert.Equal(t, "cindy", m.Namespace)
+				require.Contains(t, m.Build, "api")
+				assert.Equal(t, "okteto.dev/api:dev", m.Build["api"].Image)
+				require.Contains(t, m.Dev, "api")
+				assert.Equal(t, []string{"bash"}, m.Dev["api"].Command.Values)
+				// the image of the dev is the image of the build entry with its name
+				assert.Empty(t, m.Dev["api"].Image.Name)
+				require.Len(t, m.Deploy.Commands, 1)
+				assert.Equal(t, "helm upgrade --install api chart", m.Deploy.Commands[0].Command)
+				require.Len(t, m.Destroy.Commands, 1)
+				assert.Equal(t, "helm uninstall api", m.Destroy.Commands[0].Command)
+			},
+		},
+		{
+			name: "compose",
+			manifest: `image: okteto/golang:1
+command: bash
+`,
+			compose: `services:
+  compose:
+    image: okteto/golang:1
+`,
+			check: func(t *testing.T, m *Manifest) {
+				require.Contains(t, m.Dev, "compose")
+				assert.Equal(t, "okteto/golang:1", m.Dev["compose"].Image.Name)
+				assert.Empty(t, m.Build)
+				require.NotNil(t, m.Deploy.ComposeSection)
+				assert.Equal(t, "docker-compose.yml", m.Deploy.ComposeSection.ComposesInfo[0].File)
+			},
+		},
+		{
+			name: "pipeline-without-deploy",
+			manifest: `image: okteto/golang:1
+command: bash
+`,
+			pipeline: `destroy:
+  - helm uninstall api
+`,
+			compose: `services:
+  api:
+    image: okteto/golang:1
+`,
+			check: func(t *testing.T, m *Manifest) {
+				require.NotNil(t, m.Deploy.ComposeSection)
+				assert.Equal(t, "docker-compose.yml", m.Deploy.ComposeSection.ComposesInfo[0].File)
+				assert.Empty(t, m.Deploy.Commands)
+				require.Len(t, m.Destroy.Commands, 1)
+				assert.Equal(t, "helm uninstall api", m.Destroy.Commands[0].Command)
+			},
+		},
+		{
+			name: "pipeline-and-compose",
+			manifest: `image: okteto/golang:1
+command: bash
+`,
+			pipeline: `deploy:
+  - okteto deploy --build
+`,
+			compose: `services:
+  api:
+    image: okteto/golang:1
+`,
+			expectedIgnoredCompose: "docker-compose.yml",
+			check: func(t *testing.T, m *Manifest) {
+				require.Len(t, m.Deploy.Commands, 1)
+				assert.Equal(t, "okteto deploy --build", m.Deploy.Commands[0].Command)
+				assert.Nil(t, m.Deploy.ComposeSection)
+			},
+		},
+		{
+			name: "dev-only",
+			manifest: `name: web
+image: okteto/node:16
+command: bash
+`,
+			check: func(t *testing.T, m *Manifest) {
+				require.Contains(t, m.Dev, "web")
+				assert.Nil(t, m.Deploy)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), tt.name)
+			require.NoError(t, os.MkdirAll(dir, 0700))
+			manifestPath := filepath.Join(dir, "okteto.yml")
+			require.NoError(t, os.WriteFile(manifestPath, []byte(tt.manifest), 0600))
+			if tt.pipeline != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "okteto-pipeline.yml"), []byte(tt.pipeline), 0600))
+			}
+			if tt.compose != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "docker-compose.yml"), []byte(tt.compose), 0600))
+			}
+
+			migration, err := MigrateToV2(manifestPath)
+			require.NoError(t, err)
+			if tt.expectedIgnoredCompose != "" {
+				assert.Equal(t, filepath.Join(dir, tt.expectedIgnoredCompose), migration.IgnoredComposePath)
+			} else {
+				assert.Empty(t, migration.IgnoredComposePath)
+			}
+			for _, comment := range tt.expectedComments {
+				assert.Contains(t, string(migration.Content), comment)
+			}
+
+			m, err := Read(migration.Content)
+			require.NoError(t, err)
+			assert.True(t, m.IsV2)
+			if m.Deploy != nil {
+				for _, command := range m.Deploy.Commands {
+					assert.NotEqual(t, FakeCommand, command.Command)
+				}
+			}
+			tt.check(t, m)
+		})
+	}
+}
+
+func TestMigrateToV2AlreadyV2(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "okteto.yml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte("dev:\n  api:\n    command: bash\n"), 0600))
+
+	_, err := MigrateToV2(manifestPath)
+	assert.ErrorIs(t, err, ErrManifestAlreadyV2)
+}
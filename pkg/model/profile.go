@@ -0,0 +1,165 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ManifestProfiles defines all the profiles section
+type ManifestProfiles map[string]*Profile
+
+// Profile is a subset of the manifest to deploy and develop.
+// Sections not listed by the profile are kept entirely, an empty list skips the section
+type Profile struct {
+	Build        []string    `json:"build,omitempty" yaml:"build,omitempty"`
+	Deploy       []string    `json:"deploy,omitempty" yaml:"deploy,omitempty"`
+	Services     []string    `json:"services,omitempty" yaml:"services,omitempty"`
+	Dependencies []string    `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Dev          []string    `json:"dev,omitempty" yaml:"dev,omitempty"`
+	Variables    Environment `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+// GetProfile returns the profile with the given name
+func (m *Manifest) GetProfile(name string) (*Profile, error) {
+	if p, ok := m.Profiles[name]; ok && p != nil {
+		return p, nil
+	}
+	if len(m.Profiles) == 0 {
+		return nil, fmt.Errorf("profile '%s' not found: the manifest has no 'profiles' section", name)
+	}
+	names := make([]string, 0, len(m.Profiles))
+	for n := range m.Profiles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("profile '%s' not found. Available profiles: %s", name, strings.Join(names, ", "))
+}
+
+// SetVariables sets the variables of the profile as environment variables, except the ones set by the user as 'NAME=VALUE'.
+// It returns the variables set as 'NAME=VALUE'
+func (p *Profile) SetVariables(userVariables []string) ([]string, error) {
+	userNames := map[string]bool{}
+	for _, v := range userVariables {
+		name, _, _ := strings.Cut(v, "=")
+		userNames[name] = true
+	}
+
+	var result []string
+	for _, v := range p.Variables {
+		if userNames[v.Name] {
+			continue
+		}
+		value, err := ExpandEnv(v.Value, true)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Setenv(v.Name, value); err != nil {
+			return nil, err
+		}
+		result = append(result, fmt.Sprintf("%s=%s", v.Name, value))
+	}
+	return result, nil
+}
+
+// ApplyProfile keeps the build entries, deploy commands, compose services, dependencies and dev entries of the profile
+func (m *Manifest) ApplyProfile(name string) error {
+	p, err := m.GetProfile(name)
+	if err != nil {
+		return err
+	}
+
+	if p.Build != nil {
+		for _, b := range p.Build {
+			if _, ok := m.Build[b]; !ok {
+				return profileReferenceError(name, "build", b)
+			}
+		}
+		// images are built with the images they depend on
+		build := ManifestBuild{}
+		for _, b := range getDependentNodes(m.Build.toGraph(), append([]string{}, p.Build...)) {
+			build[b] = m.Build[b]
+		}
+		m.Build = build
+	}
+
+	if p.Deploy != nil {
+		if m.Deploy == nil {
+			return fmt.Errorf("profile '%s' lists deploy commands, but the manifest has no 'deploy' section", name)
+		}
+		var commands []DeployCommand
+		for _, c := range p.Deploy {
+			found := false
+			for _, command := range m.Deploy.Commands {
+				if command.Name == c || command.Command == c {
+					commands = append(commands, command)
+					found = true
+				}
+			}
+			if !found {
+				return profileReferenceError(name, "deploy command", c)
+			}
+		}
+		m.Deploy.Commands = commands
+	}
+
+	if p.Services != nil {
+		if m.Deploy == nil || m.Deploy.ComposeSection == nil || len(m.Deploy.ComposeSection.ComposesInfo) == 0 {
+			return fmt.Errorf("profile '%s' lists compose services, but the manifest has no compose section", name)
+		}
+		if stack := m.Deploy.ComposeSection.Stack; stack != nil {
+			for _, svc := range p.Services {
+				if _, ok := stack.Services[svc]; !ok {
+					return profileReferenceError(name, "service", svc)
+				}
+			}
+		}
+		for i := range m.Deploy.ComposeSection.ComposesInfo {
+			m.Deploy.ComposeSection.ComposesInfo[i].ServicesToDeploy = nil
+		}
+		m.Deploy.ComposeSection.ComposesInfo[0].ServicesToDeploy = p.Services
+	}
+
+	if p.Dependencies != nil {
+		dependencies := ManifestDependencies{}
+		for _, d := range p.Dependencies {
+			dependency, ok := m.Dependencies[d]
+			if !ok {
+				return profileReferenceError(name, "dependency", d)
+			}
+			dependencies[d] = dependency
+		}
+		m.Dependencies = dependencies
+	}
+
+	if p.Dev != nil {
+		devs := ManifestDevs{}
+		for _, d := range p.Dev {
+			dev, ok := m.Dev[d]
+			if !ok {
+				return profileReferenceError(name, "dev", d)
+			}
+			devs[d] = dev
+		}
+		m.Dev = devs
+	}
+	return nil
+}
+
+func profileReferenceError(profile, kind, name string) error {
+	return fmt.Errorf("profile '%s' references %s '%s', which is not defined in the manifest", profile, kind, name)
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profilesManifest = `build:
+  base:
+    context: base
+  web:
+    context: web
+    depends_on: base
+  api:
+    context: api
+deploy:
+  - name: web
+    command: helm upgrade --install web chart/web
+  - name: api
+    command: helm upgrade --install api chart/api
+dependencies:
+  db:
+    repository: https://github.com/okteto/db
+  queue:
+    repository: https://github.com/okteto/queue
+dev:
+  web:
+    command: yarn start
+  api:
+    command: go run main.go
+profiles:
+  frontend:
+    build: [web]
+    deploy: [web]
+    dependencies: []
+    dev: [web]
+    variables:
+      API_URL: https://api.staging.example.com
+  backend:
+    dev: [api]
+`
+
+func TestApplyProfile(t *testing.T) {
+	m, err := Read([]byte(profilesManifest))
+	require.NoError(t, err)
+
+	require.NoError(t, m.ApplyProfile("frontend"))
+	assert.Len(t, m.Build, 2)
+	assert.Contains(t, m.Build, "base")
+	require.Len(t, m.Deploy.Commands, 1)
+	assert.Equal(t, "web", m.Deploy.Commands[0].Name)
+	assert.Empty(t, m.Dependencies)
+	assert.Equal(t, []string{"web"}, m.Dev.GetDevs())
+
+	m, err = Read([]byte(profilesManifest))
+	require.NoError(t, err)
+	require.NoError(t, m.ApplyProfile("backend"))
+	assert.Len(t, m.Build, 3)
+	assert.Len(t, m.Deploy.Commands, 2)
+	assert.Len(t, m.Dependencies, 2)
+	assert.Equal(t, []string{"api"}, m.Dev.GetDevs())
+}
+
+func TestApplyProfileErrors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		profile  string
+		expected string
+	}{
+		{
+			name:     "unknown-profile",
+			profile:  "mobile",
+			expected: "profile 'mobile' not found. Available profiles: backend, frontend, wrong-dev",
+		},
+		{
+			name:     "unknown-dev",
+			profile:  "wrong-dev",
+			expected: "profile 'wrong-dev' references dev 'worker', which is not defined in the manifest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Read([]byte(profilesManifest + "  wrong-dev:\n    dev: [worker]\n"))
+			require.NoError(t, err)
+			assert.EqualError(t, m.ApplyProfile(tt.profile), tt.expected)
+		})
+	}
+}
+
+func TestProfileSetVariables(t *testing.T) {
+	t.Setenv("API_URL", "")
+	t.Setenv("LOG_LEVEL", "")
+	p := &Profile{
+		Variables: Environment{
+			{Name: "API_URL", Value: "https://api.example.com"},
+			{Name: "LOG_LEVEL", Value: "debug"},
+		},
+	}
+
+	variables, err := p.SetVariables([]string{"LOG_LEVEL=info"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"API_URL=https://api.example.com"}, variables)
+	assert.Equal(t, "https://api.example.com", os.Getenv("API_URL"))
+	assert.Equal(t, "", os.Getenv("LOG_LEVEL"))
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+
+	"github.com/okteto/okteto/pkg/cache"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/externalresource"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/schema"
+)
+
+const (
+	manifestSchemaID = "https://okteto.com/schemas/okteto-manifest.json"
+	stackSchemaID    = "https://okteto.com/schemas/okteto-compose.json"
+)
+
+// ManifestSchema returns the JSON Schema of the okteto manifest
+func ManifestSchema() *schema.Schema {
+	g := newSchemaGenerator()
+	s := g.Generate(reflect.TypeOf(Manifest{}), manifestSchemaID, "Okteto Manifest")
+
+	dev := s.Def("Dev")
+	dev.Property("mode").Enum = []string{constants.OktetoSyncModeFieldValue, constants.OktetoHybridModeFieldValue}
+	dev.Property("healthchecks").Deprecated = true
+	dev.Property("labels").Deprecated = true
+	s.Def("ManifestRaw").Property("devs").Deprecated = true
+	return s
+}
+
+// StackSchema returns the JSON Schema of the docker compose files supported by okteto
+func StackSchema() *schema.Schema {
+	g := newSchemaGenerator()
+	// docker compose extensions are fields starting with 'x-'
+	g.ExtensionsPattern = "^x-"
+	s := g.Generate(reflect.TypeOf(Stack{}), stackSchemaID, "Okteto Compose")
+	// warnings are collected while reading the file
+	delete(s.Def("StackRaw").Properties, "warnings")
+
+	s.Def("DependsOnConditionSpec").Property("condition").Enum = []string{
+		string(DependsOnServiceRunning),
+		string(DependsOnServiceHealthy),
+		string(DependsOnServiceCompleted),
+	}
+	return s
+}
+
+// devRCSchema returns the JSON Schema of the developer level manifest
+func devRCSchema() *schema.Schema {
+	g := newSchemaGenerator()
+	return g.Generate(reflect.TypeOf(DevRC{}), "", "Okteto Developer Level Manifest")
+}
+
+// newSchemaGenerator returns a generator with the syntaxes accepted by the custom yaml unmarshalers of the manifest and stack types
+func newSchemaGenerator() *schema.Generator {
+	g := schema.NewGenerator()
+
+	stringSchema := func(*schema.Generator) *schema.Schema { return schema.String() }
+	stringOrList := func(*schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.String(), schema.ArrayOf(schema.String()))
+	}
+	keyValues := func(*schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.ArrayOf(schema.String()), schema.MapOf(schema.String()))
+	}
+	anyValue := func(*schema.Generator) *schema.Schema { return schema.Bool(true) }
+
+	for _, t := range []interface{}{BuildArg{}, EnvVar{}, Secret{}, Reverse{}, Volume{}, ExternalVolume{}, StackVolume{}, PortRaw{}} {
+		g.Override(reflect.TypeOf(t), stringSchema)
+	}
+	for _, t := range []interface{}{Entrypoint{}, Command{}, Args{}, CommandStack{}, ArgsStack{}, HealtcheckTest{}, BuildDependsOn{}, EnvFiles{}, ServicesToDeploy{}, cache.CacheFrom{}, cache.ExportCache{}} {
+		g.Override(reflect.TypeOf(t), stringOrList)
+	}
+	for _, t := range []interface{}{Labels{}, Annotations{}, Environment{}, BuildArgs{}} {
+		g.Override(reflect.TypeOf(t), keyValues)
+	}
+	g.Override(reflect.TypeOf(RawMessage{}), anyValue)
+	g.Override(reflect.TypeOf(WarningType{}), func(*schema.Generator) *schema.Schema {
+		return &schema.Schema{Description: "Not supported by okteto, ignored with a warning"}
+	})
+
+	g.Override(reflect.TypeOf(Manifest{}), func(g *schema.Generator) *schema.Schema {
+		// manifests with a single development container at the root are still supported
+		return schema.OneOf(g.Reflect(reflect.TypeOf(manifestRaw{})), g.Reflect(reflect.TypeOf(Dev{})))
+	})
+	g.Override(reflect.TypeOf(ManifestDevs{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.ArrayOf(schema.String()), schema.MapOf(g.Reflect(reflect.TypeOf(Dev{}))))
+	})
+	g.Override(reflect.TypeOf(ManifestDependencies{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.ArrayOf(schema.String()), schema.MapOf(g.Reflect(reflect.TypeOf(Dependency{}))))
+	})
+	g.Override(reflect.TypeOf(Dependency{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.String(), g.Struct(reflect.TypeOf(Dependency{})))
+	})
+	g.Override(reflect.TypeOf(BuildInfo{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.String(), g.Reflect(reflect.TypeOf(buildInfoRaw{})))
+	})
+	g.Override(reflect.TypeOf(DeployCommand{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.String(), g.Struct(reflect.TypeOf(DeployCommand{})))
+	})
+	g.Override(reflect.TypeOf(DeployInfo{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.ArrayOf(g.Reflect(reflect.TypeOf(DeployCommand{}))), g.Struct(reflect.TypeOf(DeployInfo{})))
+	})
+	g.Override(reflect.TypeOf(DestroyInfo{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.ArrayOf(g.Reflect(reflect.TypeOf(DeployCommand{}))), g.Struct(reflect.TypeOf(DestroyInfo{})))
+	})
+	g.Override(reflect.TypeOf(ComposeSectionInfo{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(g.Reflect(reflect.TypeOf(ComposeInfoList{})), g.Struct(reflect.TypeOf(ComposeSectionInfo{})))
+	})
+	g.Override(reflect.TypeOf(ComposeInfoList{}), func(g *schema.Generator) *schema.Schema {
+		composeInfo := g.Reflect(reflect.TypeOf(ComposeInfo{}))
+		return schema.OneOf(composeInfo, schema.ArrayOf(composeInfo))
+	})
+	g.Override(reflect.TypeOf(ComposeInfo{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.String(), g.Struct(reflect.TypeOf(ComposeInfo{})))
+	})
+	g.Override(reflect.TypeOf(Sync{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.ArrayOf(g.Reflect(reflect.TypeOf(SyncFolder{}))), g.Reflect(reflect.TypeOf(syncRaw{})))
+	})
+	g.Override(reflect.TypeOf(SyncFolder{}), func(g *schema.Generator) *schema.Schema {
+		folder := g.Struct(reflect.TypeOf(syncFolderRaw{}))
+		folder.Required = []string{"localPath", "remotePath"}
+		return schema.OneOf(schema.String(), folder)
+	})
+	g.Override(reflect.TypeOf(Quantity{}), func(*schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.String(), &schema.Schema{Type: schema.TypeNumber})
+	})
+	g.Override(reflect.TypeOf(ResourceList{}), func(g *schema.Generator) *schema.Schema {
+		return schema.MapOf(g.Reflect(reflect.TypeOf(Quantity{})))
+	})
+	g.Override(reflect.TypeOf(StorageResource{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(g.Reflect(reflect.TypeOf(Quantity{})), g.Reflect(reflect.TypeOf(storageResourceRaw{})))
+	})
+	g.Override(reflect.TypeOf(Probes{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(&schema.Schema{Type: schema.TypeBoolean}, g.Reflect(reflect.TypeOf(probesRaw{})))
+	})
+	g.Override(reflect.TypeOf(Lifecycle{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(&schema.Schema{Type: schema.TypeBoolean}, g.Reflect(reflect.TypeOf(lifecycleRaw{})))
+	})
+	g.Override(reflect.TypeOf(Timeout{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(g.Reflect(reflect.TypeOf(Duration(0))), g.Struct(reflect.TypeOf(Timeout{})))
+	})
+	g.Override(reflect.TypeOf(Duration(0)), func(*schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.String(), &schema.Schema{Type: schema.TypeInteger})
+	})
+	g.Override(reflect.TypeOf(Affinity{}), func(g *schema.Generator) *schema.Schema {
+		return g.Reflect(reflect.TypeOf(AffinityRaw{}))
+	})
+	g.Override(reflect.TypeOf(Tolerations{}), func(g *schema.Generator) *schema.Schema {
+		return schema.ArrayOf(g.Reflect(reflect.TypeOf(TolerationRaw{})))
+	})
+	g.Override(reflect.TypeOf(forward.Forward{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.String(), g.Reflect(reflect.TypeOf(forward.ForwardRaw{})))
+	})
+	g.Override(reflect.TypeOf(forward.GlobalForward{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.String(), g.Reflect(reflect.TypeOf(forward.GlobalForwardRaw{})))
+	})
+	g.Override(reflect.TypeOf(forward.LocalPort(0)), func(*schema.Generator) *schema.Schema {
+		return schema.OneOf(&schema.Schema{Type: schema.TypeInteger}, &schema.Schema{Type: schema.TypeString, Enum: []string{forward.AutoPort}})
+	})
+	g.Override(reflect.TypeOf(externalresource.ExternalResource{}), func(*schema.Generator) *schema.Schema {
+		endpoint := &schema.Schema{
+			Type:                 schema.TypeObject,
+			Properties:           map[string]*schema.Schema{"name": schema.String(), "url": schema.String()},
+			AdditionalProperties: schema.Bool(false),
+		}
+		return &schema.Schema{
+			Type: schema.TypeObject,
+			Properties: map[string]*schema.Schema{
+				"icon":      schema.String(),
+				"notes":     schema.String(),
+				"endpoints": schema.ArrayOf(endpoint),
+			},
+			AdditionalProperties: schema.Bool(false),
+			Required:             []string{"endpoints"},
+		}
+	})
+
+	g.Override(reflect.TypeOf(Stack{}), func(g *schema.Generator) *schema.Schema {
+		return g.Reflect(reflect.TypeOf(StackRaw{}))
+	})
+	g.Override(reflect.TypeOf(composeBuildInfo{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.String(), g.Struct(reflect.TypeOf(composeBuildInfo{})))
+	})
+	g.Override(reflect.TypeOf(HealthCheck{}), func(g *schema.Generator) *schema.Schema {
+		return g.Reflect(reflect.TypeOf(healthCheckunmarshaller{}))
+	})
+	g.Override(reflect.TypeOf(StackSecurityContext{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.String(), g.Struct(reflect.TypeOf(StackSecurityContext{})))
+	})
+	g.Override(reflect.TypeOf(DependsOn{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.ArrayOf(schema.String()), schema.MapOf(g.Reflect(reflect.TypeOf(DependsOnConditionSpec{}))))
+	})
+	g.Override(reflect.TypeOf(Endpoint{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(schema.ArrayOf(g.Reflect(reflect.TypeOf(EndpointRule{}))), g.Struct(reflect.TypeOf(Endpoint{})))
+	})
+	g.Override(reflect.TypeOf(EndpointSpec{}), func(g *schema.Generator) *schema.Schema {
+		endpoint := g.Reflect(reflect.TypeOf(Endpoint{}))
+		return schema.OneOf(endpoint, schema.MapOf(endpoint))
+	})
+	g.Override(reflect.TypeOf(StackResources{}), func(g *schema.Generator) *schema.Schema {
+		return schema.OneOf(g.Struct(reflect.TypeOf(StackResources{})), g.Reflect(reflect.TypeOf(ServiceResources{})))
+	})
+	return g
+}
+
+// SchemaForFile returns the schema of the stack files and of the okteto manifests otherwise
+func SchemaForFile(path string) *schema.Schema {
+	if isPathAComposeFile(path) {
+		return StackSchema()
+	}
+	return ManifestSchema()
+}
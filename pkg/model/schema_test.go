@@ -0,0 +1,166 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func validateWithSchema(t *testing.T, s *schema.Schema, manifest string) []schema.Error {
+	t.Helper()
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(manifest), &doc))
+	return schema.Validate(s, &doc)
+}
+
+func TestManifestSchema(t *testing.T) {
+	var tests = []struct {
+		name     string
+		manifest string
+		expected []string
+	}{
+		{
+			name: "v2",
+			manifest: `build:
+  api:
+    context: api
+    args:
+      - VERSION=${VERSION}
+deploy:
+  - helm upgrade --install api chart
+dependencies:
+  - https://github.com/okteto/movies-frontend
+dev:
+  api:
+    command: bash
+    sync:
+      - .:/usr/src/app
+    forward:
+      - 8080:80
+      - localPort: auto
+        remotePort: 9229
+    resources:
+      limits:
+        cpu: 500m
+        memory: 1Gi
+    timeout: 1m
+    probes: true
+    mode: sync`,
+		},
+		{
+			name: "v1-dev",
+			manifest: `name: api
+image: okteto/golang:1
+command: ["bash"]
+sync:
+  - .:/app
+environment:
+  FOO: bar`,
+		},
+		{
+			name: "deploy-extended",
+			manifest: `deploy:
+  commands:
+    - name: deploy
+      command: make deploy
+  compose:
+    file: docker-compose.yml
+    services: [api]
+destroy:
+  - make destroy`,
+		},
+		{
+			name: "invalid",
+			manifest: `deploy:
+  - name: deploy
+    cmd: make deploy
+dev:
+  api:
+    mode: magic
+    replicas: two
+    sync:
+      - localPath: .
+    forward: 8080:80
+    unknown: true`,
+			expected: []string{
+				"3:5: deploy[0]: field 'cmd' is not allowed",
+				"6:11: dev.api.mode: must be one of 'sync', 'hybrid', found 'magic'",
+				"7:15: dev.api.replicas: must be an integer, found 'two'",
+				"9:9: dev.api.sync[0]: field 'remotePath' is required",
+				"10:14: dev.api.forward: must be a list, found '8080:80'",
+				"11:5: dev.api: field 'unknown' is not allowed",
+			},
+		},
+	}
+
+	s := ManifestSchema()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result []string
+			for _, err := range validateWithSchema(t, s, tt.manifest) {
+				result = append(result, err.Error())
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestStackSchema(t *testing.T) {
+	s := StackSchema()
+
+	errs := validateWithSchema(t, s, `services:
+  api:
+    build: .
+    ports:
+      - 8080:8080
+    depends_on:
+      db:
+        condition: service_healthy
+    x-okteto: true
+  db:
+    image: postgres
+    healthcheck:
+      test: pg_isready
+      interval: 10s
+x-common: true`)
+	assert.Empty(t, errs)
+
+	errs = validateWithSchema(t, s, `services:
+  api:
+    image: api
+    scale: many
+y-common: true`)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "4:12: services.api.scale: must be an integer, found 'many'", errs[0].Error())
+	assert.Equal(t, "5:1: field 'y-common' is not allowed", errs[1].Error())
+}
+
+func TestSchemaForFile(t *testing.T) {
+	assert.Equal(t, manifestSchemaID, SchemaForFile("okteto.yml").ID)
+	assert.Equal(t, stackSchemaID, SchemaForFile("docker-compose.yml").ID)
+}
+
+func TestManifestSchemaIsValidJSON(t *testing.T) {
+	b, err := json.Marshal(ManifestSchema())
+	require.NoError(t, err)
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &result))
+	assert.Equal(t, schema.Draft, result["$schema"])
+}
@@ -63,11 +63,19 @@ type syncRaw struct {
 	Compression    bool         `json:"compression" yaml:"compression"`
 	Verbose        bool         `json:"verbose" yaml:"verbose"`
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
+	Engine         string       `json:"engine,omitempty" yaml:"engine,omitempty"`
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
+	Hooks          []SyncHook   `json:"hooks,omitempty" yaml:"hooks,omitempty"`
 	LocalPath      string
 	RemotePath     string
 }
 
+type syncFolderRaw struct {
+	LocalPath  string `json:"localPath,omitempty" yaml:"localPath,omitempty"`
+	RemotePath string `json:"remotePath,omitempty" yaml:"remotePath,omitempty"`
+	Direction  string `json:"direction,omitempty" yaml:"direction,omitempty"`
+}
+
 type storageResourceRaw struct {
 	Size  Quantity `json:"size,omitempty" yaml:"size,omitempty"`
 	Class string   `json:"class,omitempty" yaml:"class,omitempty"`
@@ -92,6 +100,16 @@ type AffinityRaw struct {
 	PodAntiAffinity *PodAntiAffinity `yaml:"podAntiAffinity,omitempty" json:"podAntiAffinity,omitempty"`
 }
 
+// TolerationRaw represents a toleration with the field names of the kubernetes manifests
+type TolerationRaw struct {
+	Key                        string                   `yaml:"key,omitempty" json:"key,omitempty"`
+	Operator                   apiv1.TolerationOperator `yaml:"operator,omitempty" json:"operator,omitempty"`
+	Value                      string                   `yaml:"value,omitempty" json:"value,omitempty"`
+	Effect                     apiv1.TaintEffect        `yaml:"effect,omitempty" json:"effect,omitempty"`
+	TolerationSeconds          *int64                   `yaml:"tolerationSeconds,omitempty" json:"tolerationSeconds,omitempty"`
+	TolerationSecondsLowerCase *int64                   `yaml:"tolerationseconds,omitempty" json:"-"`
+}
+
 // NodeAffinity describes node affinity scheduling rules for the pod.
 type NodeAffinity struct {
 	RequiredDuringSchedulingIgnoredDuringExecution  *NodeSelector             `yaml:"requiredDuringSchedulingIgnoredDuringExecution,omitempty" json:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
@@ -175,6 +193,11 @@ func (e *BuildArg) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (e BuildArg) MarshalYAML() (interface{}, error) {
+	return e.Name + "=" + e.Value, nil
+}
+
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
 func (e *EnvVar) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
@@ -312,13 +335,15 @@ func (sync *Sync) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	sync.Compression = rawSync.Compression
 	sync.Verbose = rawSync.Verbose
 	sync.RescanInterval = rawSync.RescanInterval
+	sync.Engine = rawSync.Engine
 	sync.Folders = rawSync.Folders
+	sync.Hooks = rawSync.Hooks
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (sync Sync) MarshalYAML() (interface{}, error) {
-	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval {
+	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval && len(sync.Hooks) == 0 && sync.Engine == "" {
 		return sync.Folders, nil
 	}
 	return syncRaw(sync), nil
@@ -496,7 +521,28 @@ func (f *Reverse) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return err
 	}
 
-	parts := strings.SplitN(raw, ":", 2)
+	if parts := strings.Split(raw, ":"); len(parts) == 2 && forward.IsSocketDefinition(parts) {
+		remote, remoteSocket, err := forward.ParseEndpoint(parts[0])
+		if err != nil {
+			return fmt.Errorf("Wrong reverse '%s': %w", raw, err)
+		}
+		local, localSocket, err := forward.ParseEndpoint(parts[1])
+		if err != nil {
+			return fmt.Errorf("Wrong reverse '%s': %w", raw, err)
+		}
+		f.Remote = remote
+		f.RemoteSocket = remoteSocket
+		f.Local = local
+		f.LocalSocket = localSocket
+		return nil
+	}
+
+	value, protocol, err := forward.ParseProtocol(raw)
+	if err != nil {
+		return fmt.Errorf("Wrong reverse '%s': %w", raw, err)
+	}
+
+	parts := strings.SplitN(value, ":", 2)
 	if len(parts) != 2 {
 		return fmt.Errorf("Wrong port-forward syntax '%s', must be of the form 'localPort:RemotePort'", raw)
 	}
@@ -512,12 +558,18 @@ func (f *Reverse) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	f.Local = localPort
 	f.Remote = remotePort
+	if protocol == forward.UDPProtocol {
+		f.Protocol = protocol
+	}
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (f Reverse) MarshalYAML() (interface{}, error) {
-	return fmt.Sprintf("%d:%d", f.Remote, f.Local), nil
+	if f.IsSocket() {
+		return fmt.Sprintf("%s:%s", forward.Endpoint(f.Remote, f.RemoteSocket), forward.Endpoint(f.Local, f.LocalSocket)), nil
+	}
+	return fmt.Sprintf("%d:%d%s", f.Remote, f.Local, forward.ProtocolSuffix(f.Protocol)), nil
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
@@ -586,7 +638,23 @@ func (s *SyncFolder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
 	err := unmarshal(&raw)
 	if err != nil {
-		return err
+		var rawFolder syncFolderRaw
+		if err := unmarshal(&rawFolder); err != nil {
+			return err
+		}
+		if rawFolder.LocalPath == "" || rawFolder.RemotePath == "" {
+			return fmt.Errorf("each element in the 'sync' field must define 'localPath' and 'remotePath'")
+		}
+		s.LocalPath, err = ExpandEnv(rawFolder.LocalPath, true)
+		if err != nil {
+			return err
+		}
+		s.RemotePath, err = ExpandEnv(rawFolder.RemotePath, true)
+		if err != nil {
+			return err
+		}
+		s.Direction = rawFolder.Direction
+		return nil
 	}
 
 	parts := strings.Split(raw, ":")
@@ -618,15 +686,16 @@ func (s *SyncFolder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (s SyncFolder) MarshalYAML() (interface{}, error) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return s.LocalPath + ":" + s.RemotePath, nil
+	localPath := s.LocalPath
+	if cwd, err := os.Getwd(); err == nil {
+		if relPath, err := filepath.Rel(cwd, s.LocalPath); err == nil {
+			localPath = relPath
+		}
 	}
-	relPath, err := filepath.Rel(cwd, s.LocalPath)
-	if err != nil {
-		return s.LocalPath + ":" + s.RemotePath, nil
+	if s.Direction == "" {
+		return localPath + ":" + s.RemotePath, nil
 	}
-	return relPath + ":" + s.RemotePath, nil
+	return syncFolderRaw{LocalPath: localPath, RemotePath: s.RemotePath, Direction: s.Direction}, nil
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
@@ -888,6 +957,8 @@ type manifestRaw struct {
 	Dependencies  ManifestDependencies                     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	GlobalForward []forward.GlobalForward                  `json:"forward,omitempty" yaml:"forward,omitempty"`
 	External      externalresource.ExternalResourceSection `json:"external,omitempty" yaml:"external,omitempty"`
+	Include       []string                                 `json:"include,omitempty" yaml:"include,omitempty"`
+	Profiles      ManifestProfiles                         `json:"profiles,omitempty" yaml:"profiles,omitempty"`
 
 	DeprecatedDevs []string `yaml:"devs"`
 }
@@ -980,6 +1051,8 @@ func (m *Manifest) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	m.Name = manifest.Name
 	m.GlobalForward = manifest.GlobalForward
 	m.External = manifest.External
+	m.Include = manifest.Include
+	m.Profiles = manifest.Profiles
 
 	err = m.SanitizeSvcNames()
 	if err != nil {
@@ -1558,3 +1631,58 @@ func (a *Affinity) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	return nil
 }
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+// Marshal the apiv1.Affinity into json and unmarshal it into our yaml affinity, so the fields keep their kubernetes names.
+func (a *Affinity) MarshalYAML() (interface{}, error) {
+	bytes, err := json.Marshal(apiv1.Affinity(*a))
+	if err != nil {
+		return nil, err
+	}
+	var affinityRaw AffinityRaw
+	if err := json.Unmarshal(bytes, &affinityRaw); err != nil {
+		return nil, err
+	}
+	return affinityRaw, nil
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// 'tolerationseconds' is still accepted, as it was the only key supported for 'tolerationSeconds'.
+func (t *Tolerations) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tolerationsRaw []TolerationRaw
+	if err := unmarshal(&tolerationsRaw); err != nil {
+		return err
+	}
+
+	result := Tolerations{}
+	for _, raw := range tolerationsRaw {
+		toleration := apiv1.Toleration{
+			Key:               raw.Key,
+			Operator:          raw.Operator,
+			Value:             raw.Value,
+			Effect:            raw.Effect,
+			TolerationSeconds: raw.TolerationSeconds,
+		}
+		if raw.TolerationSecondsLowerCase != nil {
+			toleration.TolerationSeconds = raw.TolerationSecondsLowerCase
+		}
+		result = append(result, toleration)
+	}
+	*t = result
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (t Tolerations) MarshalYAML() (interface{}, error) {
+	result := []TolerationRaw{}
+	for _, toleration := range t {
+		result = append(result, TolerationRaw{
+			Key:               toleration.Key,
+			Operator:          toleration.Operator,
+			Value:             toleration.Value,
+			Effect:            toleration.Effect,
+			TolerationSeconds: toleration.TolerationSeconds,
+		})
+	}
+	return result, nil
+}
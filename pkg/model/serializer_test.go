@@ -50,6 +50,11 @@ func TestReverseMarshalling(t *testing.T) {
 			data:     "8080:8080",
 			expected: Reverse{Local: 8080, Remote: 8080},
 		},
+		{
+			name:      "udp",
+			data:      "8125:8125/udp",
+			expectErr: true,
+		},
 		{
 			name:      "missing-part",
 			data:      "8080",
@@ -60,6 +65,21 @@ func TestReverseMarshalling(t *testing.T) {
 			data:      "8080:svc",
 			expectErr: true,
 		},
+		{
+			name:     "sockets",
+			data:     "/tmp/agent.sock:./agent.sock",
+			expected: Reverse{RemoteSocket: "/tmp/agent.sock", LocalSocket: "./agent.sock"},
+		},
+		{
+			name:     "socket-to-port",
+			data:     "/tmp/app.sock:8080",
+			expected: Reverse{RemoteSocket: "/tmp/app.sock", Local: 8080},
+		},
+		{
+			name:      "unknown-protocol",
+			data:      "8080:8080/sctp",
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1012,6 +1032,71 @@ rescanInterval: 10`),
 				RescanInterval: 10,
 			},
 		},
+		{
+			name: "folders-with-direction",
+			data: []byte(`folders:
+  - .:/usr/src/app
+  - localPath: coverage
+    remotePath: /usr/src/app/coverage
+    direction: down`),
+			expected: Sync{
+				Folders: []SyncFolder{
+					{
+						LocalPath:  ".",
+						RemotePath: "/usr/src/app"},
+					{
+						LocalPath:  "coverage",
+						RemotePath: "/usr/src/app/coverage",
+						Direction:  SyncDirectionDown},
+				},
+			},
+		},
+		{
+			name: "engine",
+			data: []byte(`engine: ssh
+folders:
+  - .:/usr/src/app`),
+			expected: Sync{
+				Engine: SyncEngineSSH,
+				Folders: []SyncFolder{
+					{
+						LocalPath:  ".",
+						RemotePath: "/usr/src/app"},
+				},
+			},
+		},
+		{
+			name: "hooks",
+			data: []byte(`folders:
+  - .:/usr/src/app
+hooks:
+  - name: build
+    command: go build -o /usr/local/bin/app
+    paths:
+      - "**/*.go"
+    debounce: 2s
+  - command: ["/usr/local/bin/app"]
+    restart: true`),
+			expected: Sync{
+				Folders: []SyncFolder{
+					{
+						LocalPath:  ".",
+						RemotePath: "/usr/src/app"},
+				},
+				Hooks: []SyncHook{
+					{
+						Name:     "build",
+						Command:  Command{Values: []string{"sh", "-c", "go build -o /usr/local/bin/app"}},
+						Paths:    []string{"**/*.go"},
+						Debounce: 2 * time.Second,
+					},
+					{
+						Command: Command{Values: []string{"/usr/local/bin/app"}},
+						Restart: true,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -3032,3 +3117,54 @@ timeout: 15m`),
 		})
 	}
 }
+
+func TestTolerationsUnmarshalling(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected Tolerations
+	}{
+		{
+			name: "kubernetes-names",
+			data: []byte(`- key: dedicated
+  operator: Equal
+  value: dev
+  effect: NoExecute
+  tolerationSeconds: 30`),
+			expected: Tolerations{
+				{
+					Key:               "dedicated",
+					Operator:          v1.TolerationOpEqual,
+					Value:             "dev",
+					Effect:            v1.TaintEffectNoExecute,
+					TolerationSeconds: pointer.Int64(30),
+				},
+			},
+		},
+		{
+			name: "lowercase-toleration-seconds",
+			data: []byte(`- key: dedicated
+  operator: Exists
+  tolerationseconds: 30`),
+			expected: Tolerations{
+				{
+					Key:               "dedicated",
+					Operator:          v1.TolerationOpExists,
+					TolerationSeconds: pointer.Int64(30),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result Tolerations
+			require.NoError(t, yaml.UnmarshalStrict(tt.data, &result))
+			assert.Equal(t, tt.expected, result)
+
+			marshalled, err := yaml.Marshal(result)
+			require.NoError(t, err)
+			assert.Contains(t, string(marshalled), "tolerationSeconds: 30")
+		})
+	}
+}
@@ -0,0 +1,729 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/format"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model/forward"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// skaffoldConfig represents the fields of a skaffold.yaml file translated to an okteto manifest.
+// See https://skaffold.dev/docs/references/yaml/
+type skaffoldConfig struct {
+	Build struct {
+		Artifacts []skaffoldArtifact `yaml:"artifacts"`
+	} `yaml:"build"`
+	Manifests struct {
+		RawYaml   []string          `yaml:"rawYaml"`
+		Kustomize skaffoldKustomize `yaml:"kustomize"`
+		Helm      skaffoldHelm      `yaml:"helm"`
+	} `yaml:"manifests"`
+	Deploy struct {
+		Kubectl struct {
+			Manifests []string `yaml:"manifests"`
+		} `yaml:"kubectl"`
+		Kustomize skaffoldKustomize `yaml:"kustomize"`
+		Helm      skaffoldHelm      `yaml:"helm"`
+	} `yaml:"deploy"`
+	PortForward []skaffoldPortForward `yaml:"portForward"`
+}
+
+type skaffoldArtifact struct {
+	Image   string `yaml:"image"`
+	Context string `yaml:"context"`
+	Docker  struct {
+		Dockerfile string            `yaml:"dockerfile"`
+		Target     string            `yaml:"target"`
+		BuildArgs  map[string]string `yaml:"buildArgs"`
+		CacheFrom  []string          `yaml:"cacheFrom"`
+	} `yaml:"docker"`
+	Sync struct {
+		Manual []skaffoldSyncRule `yaml:"manual"`
+	} `yaml:"sync"`
+	Other map[string]interface{} `yaml:",inline"`
+}
+
+type skaffoldSyncRule struct {
+	Src   string `yaml:"src"`
+	Dest  string `yaml:"dest"`
+	Strip string `yaml:"strip"`
+}
+
+type skaffoldKustomize struct {
+	Paths []string `yaml:"paths"`
+}
+
+type skaffoldHelm struct {
+	Releases []skaffoldHelmRelease `yaml:"releases"`
+}
+
+type skaffoldHelmRelease struct {
+	Name              string            `yaml:"name"`
+	ChartPath         string            `yaml:"chartPath"`
+	RemoteChart       string            `yaml:"remoteChart"`
+	Version           string            `yaml:"version"`
+	Namespace         string            `yaml:"namespace"`
+	ValuesFiles       []string          `yaml:"valuesFiles"`
+	SetValues         map[string]string `yaml:"setValues"`
+	SetValueTemplates map[string]string `yaml:"setValueTemplates"`
+	ArtifactOverrides map[string]string `yaml:"artifactOverrides"`
+}
+
+type skaffoldPortForward struct {
+	ResourceType string `yaml:"resourceType"`
+	ResourceName string `yaml:"resourceName"`
+	Port         int    `yaml:"port"`
+	LocalPort    int    `yaml:"localPort"`
+}
+
+// skaffoldWorkload is a deployment or a statefulset of the kubectl manifests of a skaffold.yaml file
+type skaffoldWorkload struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Template struct {
+			Spec struct {
+				Containers []struct {
+					Name  string `yaml:"name"`
+					Image string `yaml:"image"`
+				} `yaml:"containers"`
+			} `yaml:"spec"`
+		} `yaml:"template"`
+	} `yaml:"spec"`
+}
+
+// skaffoldImages are the names of the build entries of the artifacts of a configuration, by image
+type skaffoldImages map[string]string
+
+// skaffoldOtherBuilders are the builders of a skaffold artifact with no equivalent in the okteto manifest
+var skaffoldOtherBuilders = []string{"jib", "kaniko", "buildpacks", "custom", "bazel", "ko"}
+
+// fieldSet are the supported fields of a yaml object and their supported fields. A nil fieldSet supports any field
+type fieldSet map[string]fieldSet
+
+// skaffoldFields are the fields of a skaffold.yaml file translated to the okteto manifest.
+// Image tags are managed by okteto, so 'build.tagPolicy' and 'build.local' are ignored
+var skaffoldFields = fieldSet{
+	"apiVersion": nil,
+	"kind":       nil,
+	"metadata":   nil,
+	"build": {
+		"tagPolicy": nil,
+		"local":     nil,
+		"artifacts": {
+			"image":   nil,
+			"context": nil,
+			"docker": {
+				"dockerfile": nil,
+				"target":     nil,
+				"buildArgs":  nil,
+				"cacheFrom":  nil,
+			},
+			"sync": {
+				"manual": nil,
+			},
+		},
+	},
+	"manifests": {
+		"rawYaml":   nil,
+		"kustomize": {"paths": nil},
+		"helm":      {"releases": skaffoldHelmReleaseFields},
+	},
+	"deploy": {
+		"kubectl":   {"manifests": nil},
+		"kustomize": {"paths": nil},
+		"helm":      {"releases": skaffoldHelmReleaseFields},
+	},
+	"portForward": {
+		"resourceType": nil,
+		"resourceName": nil,
+		"port":         nil,
+		"localPort":    nil,
+	},
+}
+
+var skaffoldHelmReleaseFields = fieldSet{
+	"name":              nil,
+	"chartPath":         nil,
+	"remoteChart":       nil,
+	"version":           nil,
+	"namespace":         nil,
+	"valuesFiles":       nil,
+	"setValues":         nil,
+	"setValueTemplates": nil,
+	"artifactOverrides": nil,
+}
+
+// skaffoldTemplateRegex matches the variables of the templates of 'setValueTemplates', like '{{.IMAGE_REPO_api}}'
+var skaffoldTemplateRegex = regexp.MustCompile(`{{\s*\.(\w+)\s*}}`)
+
+// skaffoldTagDigestRegex matches the tag and digest of an image in a template, the value okteto sets in 'OKTETO_BUILD_<NAME>_SHA'
+var skaffoldTagDigestRegex = regexp.MustCompile(`{{\s*\.IMAGE_TAG_(\w+)\s*}}@{{\s*\.IMAGE_DIGEST_(\w+)\s*}}`)
+
+// skaffoldImageVariables are the prefixes of the variables of the images in the templates of skaffold,
+// and the okteto build variables with the same value. The longest prefixes go first
+var skaffoldImageVariables = []struct {
+	prefix string
+	value  string
+}{
+	{prefix: "IMAGE_FULLY_QUALIFIED_", value: "${OKTETO_BUILD_%[1]s_IMAGE}"},
+	{prefix: "IMAGE_REPO_NO_DOMAIN_", value: "${OKTETO_BUILD_%[1]s_REPOSITORY}"},
+	{prefix: "IMAGE_REPO_", value: "${OKTETO_BUILD_%[1]s_REGISTRY}/${OKTETO_BUILD_%[1]s_REPOSITORY}"},
+	{prefix: "IMAGE_DOMAIN_", value: "${OKTETO_BUILD_%[1]s_REGISTRY}"},
+	{prefix: "IMAGE_TAG_", value: "${OKTETO_BUILD_%[1]s_SHA}"},
+}
+
+// skaffoldVariableNameRegex matches the characters skaffold replaces in the images of the names of the variables
+var skaffoldVariableNameRegex = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// ManifestFromSkaffold returns a manifest translated from the skaffold.yaml file at skaffoldPath.
+// Paths are relative to workdir, the folder of the okteto manifest.
+// It also returns the fields of the skaffold.yaml file that have no equivalent in the okteto manifest
+func ManifestFromSkaffold(skaffoldPath, workdir string) (*Manifest, []string, error) {
+	manifest, unsupported, warnings, err := translateSkaffold(skaffoldPath, workdir)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, warning := range warnings {
+		oktetoLog.Warning(warning)
+	}
+	return manifest, unsupported, nil
+}
+
+// translateSkaffold returns the manifest translated from a skaffold.yaml file, the fields with no equivalent,
+// and the warnings about the translated fields that need changes to work with okteto
+func translateSkaffold(skaffoldPath, workdir string) (*Manifest, []string, []string, error) {
+	b, err := os.ReadFile(skaffoldPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	workdir, err = filepath.Abs(workdir)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	dir, err := filepath.Abs(filepath.Dir(skaffoldPath))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	rebase := func(p string) (string, error) {
+		if p == "" {
+			p = "."
+		}
+		rel, err := filepath.Rel(workdir, filepath.Join(dir, p))
+		if err != nil {
+			return "", err
+		}
+		return filepath.ToSlash(rel), nil
+	}
+
+	manifest := NewManifest()
+	manifest.Deploy = nil
+	unsupported := []string{}
+	warnings := []string{}
+	decoder := yaml3.NewDecoder(bytes.NewReader(b))
+	for i := 0; ; i++ {
+		doc := &yaml3.Node{}
+		if err := decoder.Decode(doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, nil, nil, fmt.Errorf("error reading '%s': %w", skaffoldPath, err)
+		}
+		config := &skaffoldConfig{}
+		if err := doc.Decode(config); err != nil {
+			return nil, nil, nil, fmt.Errorf("error reading '%s': %w", skaffoldPath, err)
+		}
+		var fields interface{}
+		if err := doc.Decode(&fields); err != nil {
+			return nil, nil, nil, fmt.Errorf("error reading '%s': %w", skaffoldPath, err)
+		}
+		prefix := ""
+		if i > 0 {
+			prefix = fmt.Sprintf("config[%d].", i)
+		}
+		unsupported = append(unsupported, unsupportedFields(fields, prefix, skaffoldFields)...)
+
+		workloads := config.workloads(dir)
+		devWarnings, err := config.addBuildAndDev(manifest, rebase, workloads)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		images := config.images()
+		commands, injected, unsupportedValues, err := config.deployCommands(rebase, prefix, images, workloads)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		unsupported = append(unsupported, unsupportedValues...)
+		if len(commands) > 0 {
+			if manifest.Deploy == nil {
+				manifest.Deploy = &DeployInfo{}
+			}
+			manifest.Deploy.Commands = append(manifest.Deploy.Commands, commands...)
+		}
+		for _, artifact := range config.Build.Artifacts {
+			name, ok := images[skaffoldImageName(artifact.Image)]
+			if !ok || injected[name] {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("The image '%s' built by okteto isn't passed to your deploy commands: use '${OKTETO_BUILD_%s_IMAGE}' where your manifests reference '%s'", name, buildEnvVarName(name), artifact.Image))
+		}
+		warnings = append(warnings, devWarnings...)
+		for j, pf := range config.PortForward {
+			if pf.ResourceType != "service" {
+				unsupported = append(unsupported, fmt.Sprintf("%sportForward[%d] (only resources of type 'service' are supported)", prefix, j))
+				continue
+			}
+			local := pf.LocalPort
+			if local == 0 {
+				local = pf.Port
+			}
+			manifest.GlobalForward = append(manifest.GlobalForward, forward.GlobalForward{Local: local, Remote: pf.Port, ServiceName: pf.ResourceName})
+		}
+	}
+	return manifest, unsupported, warnings, nil
+}
+
+// addBuildAndDev adds a build entry for each artifact, and a dev entry for each artifact with sync rules.
+// Dev entries are named after the workload of the kubectl manifests running the image of the artifact.
+// It returns warnings for the dev entries with no workload
+func (c *skaffoldConfig) addBuildAndDev(manifest *Manifest, rebase func(string) (string, error), workloads []skaffoldWorkload) ([]string, error) {
+	warnings := []string{}
+	for _, artifact := range c.Build.Artifacts {
+		if artifact.hasOtherBuilder() {
+			continue
+		}
+		name := skaffoldArtifactName(artifact.Image)
+		context, err := rebase(artifact.Context)
+		if err != nil {
+			return nil, err
+		}
+		build := &BuildInfo{
+			Context:    context,
+			Dockerfile: artifact.Docker.Dockerfile,
+			Target:     artifact.Docker.Target,
+			CacheFrom:  artifact.Docker.CacheFrom,
+		}
+		argNames := make([]string, 0, len(artifact.Docker.BuildArgs))
+		for argName := range artifact.Docker.BuildArgs {
+			argNames = append(argNames, argName)
+		}
+		sort.Strings(argNames)
+		for _, argName := range argNames {
+			build.Args = append(build.Args, BuildArg{Name: argName, Value: artifact.Docker.BuildArgs[argName]})
+		}
+		manifest.Build[name] = build
+
+		if len(artifact.Sync.Manual) == 0 {
+			continue
+		}
+		dev := &Dev{Sync: Sync{RescanInterval: DefaultSyncthingRescanInterval}}
+		for _, rule := range artifact.Sync.Manual {
+			folder, err := skaffoldSyncFolder(rule)
+			if err != nil {
+				return nil, fmt.Errorf("artifact '%s': %w", artifact.Image, err)
+			}
+			if folder.LocalPath, err = rebase(path.Join(artifact.Context, folder.LocalPath)); err != nil {
+				return nil, err
+			}
+			if !containsSyncFolder(dev.Sync.Folders, folder) {
+				dev.Sync.Folders = append(dev.Sync.Folders, folder)
+			}
+		}
+
+		devName := name
+		if workload, container, ok := findSkaffoldWorkload(workloads, artifact.Image); ok {
+			devName = workload.Metadata.Name
+			if len(workload.Spec.Template.Spec.Containers) > 1 {
+				dev.Container = container
+			}
+		} else {
+			warnings = append(warnings, fmt.Sprintf("The dev container '%s' is named after the image '%s': rename it to the name of the deployment or statefulset running the image, or define its 'selector'", devName, artifact.Image))
+		}
+		manifest.Dev[devName] = dev
+	}
+	return warnings, nil
+}
+
+// hasOtherBuilder returns if the artifact is built by a builder other than docker, reported as unsupported
+func (a *skaffoldArtifact) hasOtherBuilder() bool {
+	for _, builder := range skaffoldOtherBuilders {
+		if _, ok := a.Other[builder]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// images returns the names of the build entries of the artifacts built by okteto, by image
+func (c *skaffoldConfig) images() skaffoldImages {
+	result := skaffoldImages{}
+	for _, artifact := range c.Build.Artifacts {
+		if artifact.hasOtherBuilder() {
+			continue
+		}
+		result[skaffoldImageName(artifact.Image)] = skaffoldArtifactName(artifact.Image)
+	}
+	return result
+}
+
+// byVariableName returns the name of the build entry of the image of a template variable, like the 'api' of '{{.IMAGE_REPO_api}}'
+func (images skaffoldImages) byVariableName(name string) (string, bool) {
+	for image, buildName := range images {
+		if skaffoldVariableNameRegex.ReplaceAllString(image, "_") == name {
+			return buildName, true
+		}
+	}
+	return "", false
+}
+
+// workloads returns the deployments and statefulsets of the kubectl manifests of the configuration.
+// Manifests that can't be read, like remote ones, are skipped
+func (c *skaffoldConfig) workloads(dir string) []skaffoldWorkload {
+	result := []skaffoldWorkload{}
+	for _, patterns := range [][]string{c.Manifests.RawYaml, c.Deploy.Kubectl.Manifests} {
+		for _, pattern := range patterns {
+			files, err := filepath.Glob(filepath.Join(dir, pattern))
+			if err != nil {
+				oktetoLog.Infof("invalid kubectl manifests '%s': %s", pattern, err)
+				continue
+			}
+			for _, file := range files {
+				b, err := os.ReadFile(file)
+				if err != nil {
+					oktetoLog.Infof("failed to read kubectl manifest '%s': %s", file, err)
+					continue
+				}
+				decoder := yaml3.NewDecoder(bytes.NewReader(b))
+				for {
+					workload := skaffoldWorkload{}
+					if err := decoder.Decode(&workload); err != nil {
+						if !errors.Is(err, io.EOF) {
+							oktetoLog.Infof("failed to read kubectl manifest '%s': %s", file, err)
+						}
+						break
+					}
+					if workload.Kind == "Deployment" || workload.Kind == "StatefulSet" {
+						result = append(result, workload)
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// findSkaffoldWorkload returns the first workload with a container running image, and the name of the container
+func findSkaffoldWorkload(workloads []skaffoldWorkload, image string) (skaffoldWorkload, string, bool) {
+	for _, workload := range workloads {
+		for _, container := range workload.Spec.Template.Spec.Containers {
+			if skaffoldImageName(container.Image) == skaffoldImageName(image) {
+				return workload, container.Name, true
+			}
+		}
+	}
+	return skaffoldWorkload{}, "", false
+}
+
+// deployCommands returns the commands that deploy the manifests of the configuration, with the images built by okteto.
+// It also returns the build entries whose image is passed to the commands, and the helm values that can't be translated
+func (c *skaffoldConfig) deployCommands(rebase func(string) (string, error), prefix string, images skaffoldImages, workloads []skaffoldWorkload) ([]DeployCommand, map[string]bool, []string, error) {
+	commands := []DeployCommand{}
+	injected := map[string]bool{}
+	unsupported := []string{}
+	add := func(command string) {
+		for _, c := range commands {
+			if c.Command == command {
+				return
+			}
+		}
+		commands = append(commands, DeployCommand{Name: command, Command: command})
+	}
+
+	for _, manifests := range [][]string{c.Manifests.RawYaml, c.Deploy.Kubectl.Manifests} {
+		for _, m := range manifests {
+			p, err := rebase(m)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			add(fmt.Sprintf("kubectl apply -f %s", p))
+		}
+	}
+	// skaffold replaces the images of the kubectl manifests, okteto sets them once the manifests are applied
+	for _, artifact := range c.Build.Artifacts {
+		name, ok := images[skaffoldImageName(artifact.Image)]
+		if !ok {
+			continue
+		}
+		for _, workload := range workloads {
+			for _, container := range workload.Spec.Template.Spec.Containers {
+				if skaffoldImageName(container.Image) != skaffoldImageName(artifact.Image) {
+					continue
+				}
+				command := fmt.Sprintf("kubectl set image %s/%s %s=\"${OKTETO_BUILD_%s_IMAGE}\"", strings.ToLower(workload.Kind), workload.Metadata.Name, container.Name, buildEnvVarName(name))
+				if workload.Metadata.Namespace != "" {
+					command = fmt.Sprintf("%s --namespace %s", command, workload.Metadata.Namespace)
+				}
+				add(command)
+				injected[name] = true
+			}
+		}
+	}
+	for _, paths := range [][]string{c.Manifests.Kustomize.Paths, c.Deploy.Kustomize.Paths} {
+		for _, k := range paths {
+			p, err := rebase(k)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			add(fmt.Sprintf("kubectl apply -k %s", p))
+		}
+	}
+	helm := []struct {
+		field    string
+		releases []skaffoldHelmRelease
+	}{
+		{field: "manifests.helm.releases", releases: c.Manifests.Helm.Releases},
+		{field: "deploy.helm.releases", releases: c.Deploy.Helm.Releases},
+	}
+	for _, h := range helm {
+		for i, r := range h.releases {
+			command, unsupportedKeys, err := r.command(rebase, images, injected)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			for _, key := range unsupportedKeys {
+				unsupported = append(unsupported, fmt.Sprintf("%s%s[%d].%s (only the images of the artifacts and environment variables are supported)", prefix, h.field, i, key))
+			}
+			add(command)
+		}
+	}
+	return commands, injected, unsupported, nil
+}
+
+// command returns the helm command of the release. The values of the images of the artifacts are set with the images built by okteto,
+// which are added to injected. It also returns the keys of the values that can't be translated
+func (r *skaffoldHelmRelease) command(rebase func(string) (string, error), images skaffoldImages, injected map[string]bool) (string, []string, error) {
+	chart := r.RemoteChart
+	if r.ChartPath != "" {
+		var err error
+		if chart, err = rebase(r.ChartPath); err != nil {
+			return "", nil, err
+		}
+	}
+	args := []string{"helm", "upgrade", "--install", r.Name, chart}
+	if r.Version != "" {
+		args = append(args, "--version", r.Version)
+	}
+	if r.Namespace != "" {
+		args = append(args, "--namespace", r.Namespace)
+	}
+	for _, v := range r.ValuesFiles {
+		p, err := rebase(v)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, "-f", p)
+	}
+	for _, key := range sortedKeys(r.SetValues) {
+		args = append(args, "--set", quoteShellArg(fmt.Sprintf("%s=%s", key, r.SetValues[key])))
+	}
+
+	unsupported := []string{}
+	for _, key := range sortedKeys(r.ArtifactOverrides) {
+		name, ok := images[skaffoldImageName(r.ArtifactOverrides[key])]
+		if !ok {
+			unsupported = append(unsupported, fmt.Sprintf("artifactOverrides.%s", key))
+			continue
+		}
+		args = append(args, "--set", fmt.Sprintf("\"%s=${OKTETO_BUILD_%s_IMAGE}\"", escapeDoubleQuotedShellArg(key), buildEnvVarName(name)))
+		injected[name] = true
+	}
+	for _, key := range sortedKeys(r.SetValueTemplates) {
+		value, names, ok := translateSkaffoldTemplate(r.SetValueTemplates[key], images)
+		if !ok {
+			unsupported = append(unsupported, fmt.Sprintf("setValueTemplates.%s", key))
+			continue
+		}
+		args = append(args, "--set", fmt.Sprintf("\"%s=%s\"", escapeDoubleQuotedShellArg(key), value))
+		for _, name := range names {
+			injected[name] = true
+		}
+	}
+	return strings.Join(args, " "), unsupported, nil
+}
+
+// translateSkaffoldTemplate returns a template of 'setValueTemplates' to be used between double quotes in a shell,
+// with its variables replaced by the okteto build variables or by environment variables.
+// It also returns the build entries of the images of the template
+func translateSkaffoldTemplate(template string, images skaffoldImages) (string, []string, bool) {
+	template = skaffoldTagDigestRegex.ReplaceAllStringFunc(template, func(match string) string {
+		groups := skaffoldTagDigestRegex.FindStringSubmatch(match)
+		if groups[1] != groups[2] {
+			return match
+		}
+		return fmt.Sprintf("{{.IMAGE_TAG_%s}}", groups[1])
+	})
+
+	result := ""
+	names := []string{}
+	last := 0
+	for _, match := range skaffoldTemplateRegex.FindAllStringSubmatchIndex(template, -1) {
+		literal := template[last:match[0]]
+		if strings.Contains(literal, "{{") {
+			return "", nil, false
+		}
+		result += escapeDoubleQuotedShellArg(literal)
+		last = match[1]
+
+		variable := template[match[2]:match[3]]
+		if strings.HasPrefix(variable, "IMAGE_DIGEST_") {
+			return "", nil, false
+		}
+		isImage := false
+		for _, v := range skaffoldImageVariables {
+			if !strings.HasPrefix(variable, v.prefix) {
+				continue
+			}
+			name, ok := images.byVariableName(strings.TrimPrefix(variable, v.prefix))
+			if !ok {
+				return "", nil, false
+			}
+			result += fmt.Sprintf(v.value, buildEnvVarName(name))
+			names = append(names, name)
+			isImage = true
+			break
+		}
+		if !isImage {
+			result += fmt.Sprintf("${%s}", variable)
+		}
+	}
+	literal := template[last:]
+	if strings.Contains(literal, "{{") {
+		return "", nil, false
+	}
+	result += escapeDoubleQuotedShellArg(literal)
+	return result, names, true
+}
+
+// escapeDoubleQuotedShellArg escapes the characters with a special meaning between double quotes in a shell
+func escapeDoubleQuotedShellArg(arg string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`").Replace(arg)
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// buildEnvVarName returns the name of a build entry in the okteto build variables, like the 'API' of 'OKTETO_BUILD_API_IMAGE'
+func buildEnvVarName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// skaffoldImageName returns an image without its tag or digest
+func skaffoldImageName(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+// skaffoldArtifactName returns the name of the build entry of an artifact: the last segment of its image without the tag
+func skaffoldArtifactName(image string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.Index(name, ":"); i >= 0 {
+		name = name[:i]
+	}
+	return format.ResourceK8sMetaString(name)
+}
+
+// skaffoldSyncFolder returns the folder synchronized by a manual sync rule: the folder of its 'src' pattern before any wildcard.
+// The path of the local folder is relative to the context of the artifact
+func skaffoldSyncFolder(rule skaffoldSyncRule) (SyncFolder, error) {
+	if !path.IsAbs(rule.Dest) {
+		return SyncFolder{}, fmt.Errorf("the destination '%s' of the sync rule '%s' must be an absolute path", rule.Dest, rule.Src)
+	}
+	local := rule.Src
+	if i := strings.IndexAny(local, "*?[{"); i >= 0 {
+		local = local[:i]
+		local = local[:strings.LastIndex(local, "/")+1]
+	} else {
+		local = path.Dir(local)
+	}
+	local = path.Clean("./" + local)
+	remote := path.Join(rule.Dest, strings.TrimPrefix(local, path.Clean("./"+rule.Strip)))
+	return SyncFolder{LocalPath: local, RemotePath: remote}, nil
+}
+
+func containsSyncFolder(folders []SyncFolder, folder SyncFolder) bool {
+	for _, f := range folders {
+		if f.LocalPath == folder.LocalPath && f.RemotePath == folder.RemotePath {
+			return true
+		}
+	}
+	return false
+}
+
+// unsupportedFields returns the paths of the fields of value not defined in supported
+func unsupportedFields(value interface{}, prefix string, supported fieldSet) []string {
+	if supported == nil {
+		return nil
+	}
+	result := []string{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fields, ok := supported[key]
+			if !ok {
+				result = append(result, prefix+key)
+				continue
+			}
+			result = append(result, unsupportedFields(v[key], prefix+key+".", fields)...)
+		}
+	case []interface{}:
+		// the fields of the items of a list are reported with their index
+		trimmed := strings.TrimSuffix(prefix, ".")
+		for i, item := range v {
+			result = append(result, unsupportedFields(item, fmt.Sprintf("%s[%d].", trimmed, i), supported)...)
+		}
+	}
+	return result
+}
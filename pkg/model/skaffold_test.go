@@ -0,0 +1,334 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestFromSkaffold(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "skaffold.yaml")
+	content := `apiVersion: skaffold/v4beta6
+kind: Config
+build:
+  tagPolicy:
+    gitCommit: {}
+  artifacts:
+    - image: gcr.io/acme/shop-api:dev
+      context: api
+      docker:
+        dockerfile: Dockerfile.dev
+        buildArgs:
+          GO_VERSION: "1.21"
+      sync:
+        manual:
+          - src: "cmd/**/*.go"
+            dest: /app
+          - src: "static/*.html"
+            dest: /srv/static
+            strip: static/
+    - image: shop-web
+      context: web
+      jib: {}
+manifests:
+  rawYaml:
+    - k8s/*.yaml
+  kustomize:
+    paths: [k8s/overlays/dev]
+deploy:
+  kubectl: {}
+  helm:
+    releases:
+      - name: redis
+        remoteChart: oci://registry/redis
+        version: 1.2.3
+        namespace: data
+        valuesFiles: [charts/redis-values.yaml]
+        setValues:
+          auth.password: "my secret"
+portForward:
+  - resourceType: service
+    resourceName: shop-api
+    port: 8080
+    localPort: 9000
+  - resourceType: deployment
+    resourceName: web
+    port: 3000
+profiles:
+  - name: prod
+---
+apiVersion: skaffold/v4beta6
+kind: Config
+requires:
+  - path: ../other
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	m, unsupported, err := ManifestFromSkaffold(path, dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"build.artifacts[1].jib",
+		"profiles",
+		"portForward[1] (only resources of type 'service' are supported)",
+		"config[1].requires",
+	}, unsupported)
+
+	assert.Equal(t, ManifestBuild{
+		"shop-api": {
+			Context:    "api",
+			Dockerfile: "Dockerfile.dev",
+			Args:       BuildArgs{{Name: "GO_VERSION", Value: "1.21"}},
+		},
+	}, m.Build)
+
+	require.NotNil(t, m.Deploy)
+	commands := []string{}
+	for _, c := range m.Deploy.Commands {
+		commands = append(commands, c.Command)
+	}
+	assert.Equal(t, []string{
+		"kubectl apply -f k8s/*.yaml",
+		"kubectl apply -k k8s/overlays/dev",
+		"helm upgrade --install redis oci://registry/redis --version 1.2.3 --namespace data -f charts/redis-values.yaml --set 'auth.password=my secret'",
+	}, commands)
+
+	require.Contains(t, m.Dev, "shop-api")
+	assert.Equal(t, []SyncFolder{
+		{LocalPath: "api/cmd", RemotePath: "/app/cmd"},
+		{LocalPath: "api/static", RemotePath: "/srv/static"},
+	}, m.Dev["shop-api"].Sync.Folders)
+
+	assert.Equal(t, []forward.GlobalForward{{Local: 9000, Remote: 8080, ServiceName: "shop-api"}}, m.GlobalForward)
+}
+
+func TestManifestFromSkaffoldImages(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the deploy commands are run with sh")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "skaffold.yaml")
+	content := `build:
+  artifacts:
+    - image: gcr.io/acme/api
+      context: api
+      sync:
+        manual:
+          - src: "src/**/*.go"
+            dest: /app
+    - image: web
+      context: web
+      sync:
+        manual:
+          - src: "*.js"
+            dest: /app
+    - image: worker
+      context: worker
+    - image: jobs
+      context: jobs
+manifests:
+  rawYaml:
+    - k8s/*.yaml
+deploy:
+  kubectl: {}
+  helm:
+    releases:
+      - name: app
+        chartPath: chart
+        artifactOverrides:
+          web.image: web
+          other.image: gcr.io/acme/other
+        setValueTemplates:
+          worker.repository: "{{.IMAGE_REPO_worker}}"
+          worker.tag: "{{.IMAGE_TAG_worker}}@{{.IMAGE_DIGEST_worker}}"
+          worker.env: "{{.ENVIRONMENT}}-$HOME"
+          worker.digest: "{{.IMAGE_DIGEST_worker}}"
+`
+	k8s := `apiVersion: v1
+kind: Service
+metadata:
+  name: api
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api-server
+  namespace: shop
+spec:
+  template:
+    spec:
+      containers:
+        - name: api
+          image: gcr.io/acme/api:v1
+        - name: proxy
+          image: envoy
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "k8s"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "k8s", "api.yaml"), []byte(k8s), 0600))
+
+	m, unsupported, warnings, err := translateSkaffold(path, dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"deploy.helm.releases[0].artifactOverrides.other.image (only the images of the artifacts and environment variables are supported)",
+		"deploy.helm.releases[0].setValueTemplates.worker.digest (only the images of the artifacts and environment variables are supported)",
+	}, unsupported)
+	assert.Equal(t, []string{
+		"The image 'jobs' built by okteto isn't passed to your deploy commands: use '${OKTETO_BUILD_JOBS_IMAGE}' where your manifests reference 'jobs'",
+		"The dev container 'web' is named after the image 'web': rename it to the name of the deployment or statefulset running the image, or define its 'selector'",
+	}, warnings)
+
+	require.Contains(t, m.Dev, "api-server")
+	assert.Equal(t, "api", m.Dev["api-server"].Container)
+	require.Contains(t, m.Dev, "web")
+
+	manifestPath := filepath.Join(dir, "okteto.yml")
+	require.NoError(t, m.WriteToFile(manifestPath))
+	loaded, err := GetManifestV2(manifestPath)
+	require.NoError(t, err)
+	require.NotNil(t, loaded.Deploy)
+
+	// helm and kubectl print their arguments, to check the values the shell expands
+	script := `helm() { echo "helm $*"; }
+kubectl() { echo "kubectl $*"; }
+`
+	for _, c := range loaded.Deploy.Commands {
+		script += c.Command + "\n"
+	}
+	cmd := exec.Command("sh", "-c", script)
+	cmd.Env = append(os.Environ(),
+		"ENVIRONMENT=dev",
+		"OKTETO_BUILD_API_IMAGE=okteto.dev/api@sha256:1",
+		"OKTETO_BUILD_WEB_IMAGE=okteto.dev/web@sha256:2",
+		"OKTETO_BUILD_WORKER_REGISTRY=okteto.dev",
+		"OKTETO_BUILD_WORKER_REPOSITORY=ns/worker",
+		"OKTETO_BUILD_WORKER_SHA=okteto@sha256:3",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	assert.Equal(t, []string{
+		"kubectl apply -f k8s/*.yaml",
+		"kubectl set image deployment/api-server api=okteto.dev/api@sha256:1 --namespace shop",
+		"helm upgrade --install app chart --set web.image=okteto.dev/web@sha256:2 --set worker.env=dev-$HOME --set worker.repository=okteto.dev/ns/worker --set worker.tag=okteto@sha256:3",
+	}, strings.Split(strings.TrimSpace(string(out)), "\n"))
+}
+
+func TestTranslateSkaffoldTemplate(t *testing.T) {
+	images := skaffoldImages{"gcr.io/acme/shop-api": "shop-api"}
+	var tests = []struct {
+		template string
+		expected string
+		ok       bool
+	}{
+		{
+			template: "{{.IMAGE_FULLY_QUALIFIED_gcr_io_acme_shop_api}}",
+			expected: "${OKTETO_BUILD_SHOP_API_IMAGE}",
+			ok:       true,
+		},
+		{
+			template: "{{ .IMAGE_DOMAIN_gcr_io_acme_shop_api }}/{{.IMAGE_REPO_NO_DOMAIN_gcr_io_acme_shop_api}}",
+			expected: "${OKTETO_BUILD_SHOP_API_REGISTRY}/${OKTETO_BUILD_SHOP_API_REPOSITORY}",
+			ok:       true,
+		},
+		{
+			template: "{{.IMAGE_TAG_gcr_io_acme_shop_api}}",
+			expected: "${OKTETO_BUILD_SHOP_API_SHA}",
+			ok:       true,
+		},
+		{
+			template: `"{{.USER}}"`,
+			expected: `\"${USER}\"`,
+			ok:       true,
+		},
+		{
+			template: "{{.IMAGE_REPO_other}}",
+			ok:       false,
+		},
+		{
+			template: `{{ default "x" .USER }}`,
+			ok:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			result, _, ok := translateSkaffoldTemplate(tt.template, images)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestManifestFromSkaffoldRelativeSyncDestination(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "skaffold.yaml")
+	content := `build:
+  artifacts:
+    - image: api
+      sync:
+        manual:
+          - src: "*.py"
+            dest: .
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	_, _, err := ManifestFromSkaffold(path, filepath.Dir(path))
+	assert.EqualError(t, err, "artifact 'api': the destination '.' of the sync rule '*.py' must be an absolute path")
+}
+
+func TestSkaffoldSyncFolder(t *testing.T) {
+	var tests = []struct {
+		rule     skaffoldSyncRule
+		expected SyncFolder
+	}{
+		{
+			rule:     skaffoldSyncRule{Src: "*.py", Dest: "/app"},
+			expected: SyncFolder{LocalPath: ".", RemotePath: "/app"},
+		},
+		{
+			rule:     skaffoldSyncRule{Src: "src/**/*.js", Dest: "/app"},
+			expected: SyncFolder{LocalPath: "src", RemotePath: "/app/src"},
+		},
+		{
+			rule:     skaffoldSyncRule{Src: "src/web/**/*.js", Dest: "/app", Strip: "src/"},
+			expected: SyncFolder{LocalPath: "src/web", RemotePath: "/app/web"},
+		},
+		{
+			rule:     skaffoldSyncRule{Src: "config/app.yaml", Dest: "/etc"},
+			expected: SyncFolder{LocalPath: "config", RemotePath: "/etc/config"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule.Src, func(t *testing.T) {
+			result, err := skaffoldSyncFolder(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSkaffoldArtifactName(t *testing.T) {
+	assert.Equal(t, "shop-api", skaffoldArtifactName("gcr.io/acme/shop-api:dev"))
+	assert.Equal(t, "web", skaffoldArtifactName("web"))
+	assert.Equal(t, "my-app", skaffoldArtifactName("registry:5000/my_app"))
+}
@@ -253,7 +253,7 @@ func GetStackFromPath(name, stackPath string, isCompose bool) (*Stack, error) {
 
 	s, err := ReadStack(b, isCompose)
 	if err != nil {
-		return nil, err
+		return nil, setYAMLErrorFile(err, stackPath)
 	}
 	s.Paths = []string{stackPath}
 	s.Name, err = getStackName(name, stackPath, s.Name)
@@ -342,6 +342,9 @@ func ReadStack(bytes []byte, isCompose bool) (*Stack, error) {
 	}
 
 	if err := yaml.UnmarshalStrict(expandedManifest, s); err != nil {
+		if yamlErr := newYAMLError("Invalid compose manifest:", composeDocsURL, expandedManifest, StackSchema(), err); yamlErr != nil {
+			return nil, yamlErr
+		}
 		if strings.HasPrefix(err.Error(), "yaml: unmarshal errors:") {
 			var sb strings.Builder
 			_, _ = sb.WriteString("Invalid compose manifest:\n")
@@ -352,7 +355,7 @@ func ReadStack(bytes []byte, isCompose bool) (*Stack, error) {
 				_, _ = sb.WriteString(fmt.Sprintf("    - %s\n", e))
 			}
 
-			_, _ = sb.WriteString("    See https://okteto.com/docs/reference/compose/ for details")
+			_, _ = sb.WriteString(fmt.Sprintf("    See %s for details", composeDocsURL))
 			return nil, errors.New(sb.String())
 		}
 		if errors.Is(err, oktetoErrors.ErrServiceEmpty) {
@@ -430,7 +433,7 @@ func (svc *Service) ToDev(svcName string) (*Dev, error) {
 	}
 	for _, v := range svc.VolumeMounts {
 		if pathExistsAndDir(v.LocalPath) {
-			d.Sync.Folders = append(d.Sync.Folders, SyncFolder(v))
+			d.Sync.Folders = append(d.Sync.Folders, SyncFolder{LocalPath: v.LocalPath, RemotePath: v.RemotePath})
 		}
 	}
 	d.Command = svc.Command
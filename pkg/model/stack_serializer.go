@@ -1640,12 +1640,22 @@ func validateExtensions(stack StackRaw) error {
 			}
 		}
 	}
-	if len(nonValidFields) == 1 {
-		return fmt.Errorf("Invalid compose manifest: Field '%s' is not supported.\n    More information is available here: https://okteto.com/docs/reference/compose/", nonValidFields[0])
-	} else if len(nonValidFields) > 1 {
-		return fmt.Errorf(`Invalid compose manifest: The following fields are not supported.
-    - %s
-    More information is available here: https://okteto.com/docs/reference/compose/`, strings.Join(nonValidFields, "\n    - "))
+	if len(nonValidFields) > 0 {
+		return unsupportedFieldsError{fields: nonValidFields}
 	}
 	return nil
 }
+
+// unsupportedFieldsError is the error of the fields of a compose file not supported by okteto
+type unsupportedFieldsError struct {
+	fields []string
+}
+
+func (e unsupportedFieldsError) Error() string {
+	if len(e.fields) == 1 {
+		return fmt.Sprintf("Invalid compose manifest: Field '%s' is not supported.\n    More information is available here: https://okteto.com/docs/reference/compose/", e.fields[0])
+	}
+	return fmt.Sprintf(`Invalid compose manifest: The following fields are not supported.
+    - %s
+    More information is available here: https://okteto.com/docs/reference/compose/`, strings.Join(e.fields, "\n    - "))
+}
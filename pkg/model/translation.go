@@ -33,6 +33,7 @@ type TranslationRule struct {
 	WorkDir           string               `json:"workdir"`
 	Healthchecks      bool                 `json:"healthchecks" yaml:"healthchecks"`
 	PersistentVolume  bool                 `json:"persistentVolume" yaml:"persistentVolume"`
+	SyncEngine        string               `json:"syncEngine,omitempty" yaml:"syncEngine,omitempty"`
 	Volumes           []VolumeMount        `json:"volumes,omitempty"`
 	SecurityContext   *SecurityContext     `json:"securityContext,omitempty"`
 	ServiceAccount    string               `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
@@ -459,6 +459,30 @@ func TestSSHServerPortTranslationRule(t *testing.T) {
 	}
 }
 
+func TestSSHSyncEngineTranslationRule(t *testing.T) {
+	dev := &Dev{
+		Name:      "web",
+		Namespace: "n",
+		Image:     &BuildInfo{},
+		Sync: Sync{
+			Engine:  SyncEngineSSH,
+			Folders: []SyncFolder{{LocalPath: ".", RemotePath: "/app"}},
+		},
+		Secrets: []Secret{
+			{LocalPath: "/tmp/.stignore-1", RemotePath: "/app/.stignore"},
+			{LocalPath: "/home/.ssh/id_rsa.pub", RemotePath: authorizedKeysPath},
+		},
+	}
+
+	rule := dev.ToTranslationRule(dev, false)
+	assert.Equal(t, SyncEngineSSH, rule.SyncEngine)
+	assert.Equal(t, []string{"sh", "-c", "set -e\nmkdir -p /var/okteto/remote && cp /var/okteto/secret/authorized_keys /var/okteto/remote/authorized_keys\nexec /var/okteto/bin/remote"}, rule.Command)
+	assert.Empty(t, rule.Args)
+	for _, v := range rule.Volumes {
+		assert.False(t, v.IsSyncthing(), "the syncthing volume is mounted")
+	}
+}
+
 func TestDevToTranslationRuleRunAsNonRoot(t *testing.T) {
 	var falseBoolean = false
 	var trueBoolean = true
@@ -69,7 +69,7 @@ func (dev *Dev) translateDeprecatedVolumes() {
 			volumes = append(volumes, v)
 			continue
 		}
-		dev.Sync.Folders = append(dev.Sync.Folders, SyncFolder(v))
+		dev.Sync.Folders = append(dev.Sync.Folders, SyncFolder{LocalPath: v.LocalPath, RemotePath: v.RemotePath})
 	}
 	dev.Volumes = volumes
 }
@@ -294,8 +294,35 @@ func (dev *Dev) validateDuplicatedSyncFolders() error {
 	return nil
 }
 
+// validateSyncFolderDirections checks the directions of the sync folders. Sync folders inside another sync folder
+// are synchronized as part of their parent folder, so they must have the same direction
+func (dev *Dev) validateSyncFolderDirections() error {
+	for _, sync := range dev.Sync.Folders {
+		switch sync.Direction {
+		case "", SyncDirectionBoth, SyncDirectionUp, SyncDirectionDown:
+		default:
+			return fmt.Errorf("the direction of the sync folder '%s' must be '%s', '%s' or '%s'", sync.LocalPath, SyncDirectionBoth, SyncDirectionUp, SyncDirectionDown)
+		}
+	}
+	for _, sync := range dev.Sync.Folders {
+		for _, parent := range dev.Sync.Folders {
+			rel, err := filepath.Rel(parent.LocalPath, sync.LocalPath)
+			if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			if sync.GetDirection() != parent.GetDirection() {
+				return fmt.Errorf("the sync folder '%s' is inside the sync folder '%s' and must have the same direction", sync.LocalPath, parent.LocalPath)
+			}
+		}
+	}
+	return nil
+}
+
 func (dev *Dev) validateServiceSyncFolders(main *Dev) error {
 	for _, sync := range dev.Sync.Folders {
+		if sync.Direction != "" {
+			return fmt.Errorf("'direction' is not supported in the sync folders of services. The direction of the main development container is applied")
+		}
 		_, err := main.IsSubPathFolder(sync.LocalPath)
 		if err != nil {
 			if err == oktetoErrors.ErrNotFound {
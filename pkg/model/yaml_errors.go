@@ -0,0 +1,249 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/schema"
+	yaml "gopkg.in/yaml.v2"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+const (
+	manifestDocsURL = "https://okteto.com/docs/reference/manifest/"
+	composeDocsURL  = "https://okteto.com/docs/reference/compose/"
+
+	// snippetContextLines is the number of lines shown before and after the line of an error
+	snippetContextLines = 1
+)
+
+var (
+	// yamlErrorLineRegex matches the errors of gopkg.in/yaml.v2 with a line number
+	yamlErrorLineRegex = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+	// yamlUnknownFieldRegex matches the errors of gopkg.in/yaml.v2 for unknown fields
+	yamlUnknownFieldRegex = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+
+	// yamlGoTypeRegex matches the go types of gopkg.in/yaml.v2 errors, meaningless for users
+	yamlGoTypeRegex = regexp.MustCompile(`\s+(?:in type|into) [\w\[\]*]*\w+\.\w+$`)
+)
+
+// YAMLError is an error parsing an okteto yaml file, with the position of each error in the file
+type YAMLError struct {
+	// File is the path of the file, if known
+	File string
+	// Errors are the errors found in the file, sorted by position
+	Errors []YAMLErrorDetail
+
+	header  string
+	docsURL string
+	lines   []string
+}
+
+// YAMLErrorDetail is an error in a position of a yaml file
+type YAMLErrorDetail struct {
+	Line int
+	// Column is 0 when the error only has a line
+	Column  int
+	Path    string
+	Message string
+	// Suggestion is the closest allowed field to an unknown field
+	Suggestion string
+}
+
+// Error returns the errors with their position and a snippet of the yaml around them
+func (e *YAMLError) Error() string {
+	var sb strings.Builder
+	_, _ = sb.WriteString(e.header)
+	_, _ = sb.WriteString("\n")
+	for _, detail := range e.Errors {
+		_, _ = sb.WriteString(fmt.Sprintf("    - %s\n", e.describe(detail)))
+		_, _ = sb.WriteString(e.snippet(detail))
+	}
+	_, _ = sb.WriteString(fmt.Sprintf("    See %s for details", e.docsURL))
+	return sb.String()
+}
+
+func (e *YAMLError) describe(detail YAMLErrorDetail) string {
+	position := strconv.Itoa(detail.Line)
+	if detail.Column > 0 {
+		position = fmt.Sprintf("%s:%d", position, detail.Column)
+	}
+	if e.File != "" {
+		position = fmt.Sprintf("%s:%s", e.File, position)
+	} else {
+		position = fmt.Sprintf("line %s", position)
+	}
+
+	msg := detail.Message
+	if detail.Suggestion != "" {
+		msg = fmt.Sprintf("%s, did you mean '%s'?", msg, detail.Suggestion)
+	}
+	if detail.Path != "" {
+		return fmt.Sprintf("%s: %s: %s", position, detail.Path, msg)
+	}
+	return fmt.Sprintf("%s: %s", position, msg)
+}
+
+// snippet returns the lines around the error, with a caret under its column
+func (e *YAMLError) snippet(detail YAMLErrorDetail) string {
+	if detail.Line < 1 || detail.Line > len(e.lines) {
+		return ""
+	}
+	first := detail.Line - snippetContextLines
+	if first < 1 {
+		first = 1
+	}
+	last := detail.Line + snippetContextLines
+	if last > len(e.lines) {
+		last = len(e.lines)
+	}
+	width := len(strconv.Itoa(last))
+
+	var sb strings.Builder
+	for i := first; i <= last; i++ {
+		_, _ = sb.WriteString(fmt.Sprintf("        %*d | %s\n", width, i, e.lines[i-1]))
+		if i == detail.Line && detail.Column > 0 {
+			_, _ = sb.WriteString(fmt.Sprintf("        %*s | %s^\n", width, "", caretIndent(e.lines[i-1], detail.Column)))
+		}
+	}
+	return sb.String()
+}
+
+// caretIndent returns the whitespace before the column of the line, keeping its tabs
+func caretIndent(line string, column int) string {
+	var sb strings.Builder
+	for i, r := range []rune(line) {
+		if i >= column-1 {
+			break
+		}
+		if r == '\t' {
+			_, _ = sb.WriteRune('\t')
+		} else {
+			_, _ = sb.WriteRune(' ')
+		}
+	}
+	return sb.String()
+}
+
+// newYAMLError returns the error of unmarshalling content with the position of each error.
+// The schema is used to get the column, the path and the suggestions of the errors.
+// It returns nil if err has no position, so the caller can handle it as any other error
+func newYAMLError(header, docsURL string, content []byte, s *schema.Schema, err error) *YAMLError {
+	var schemaErrors []schema.Error
+	doc := &yaml3.Node{}
+	if yaml3.Unmarshal(content, doc) == nil {
+		schemaErrors = schema.Validate(s, doc)
+	}
+
+	var details []YAMLErrorDetail
+	var typeErr *yaml.TypeError
+	var unsupportedErr unsupportedFieldsError
+	switch {
+	case errors.As(err, &typeErr):
+		for _, msg := range typeErr.Errors {
+			if detail, ok := yamlErrorDetail(msg, content, schemaErrors); ok {
+				details = append(details, detail)
+			}
+		}
+	case errors.As(err, &unsupportedErr):
+		for _, schemaErr := range schemaErrors {
+			if strings.HasSuffix(schemaErr.Message, "is not allowed") {
+				details = append(details, detailFromSchema(schemaErr))
+			}
+		}
+	default:
+		if detail, ok := yamlErrorDetail(err.Error(), content, nil); ok {
+			details = append(details, detail)
+		}
+	}
+	if len(details) == 0 {
+		return nil
+	}
+
+	return &YAMLError{
+		Errors:  details,
+		header:  header,
+		docsURL: docsURL,
+		lines:   strings.Split(strings.TrimSuffix(string(content), "\n"), "\n"),
+	}
+}
+
+// yamlErrorDetail parses an error of gopkg.in/yaml.v2 like "line 4: field enviroment not found in type model.devType".
+// The schema error in the same line gives a better description of the error when it's available
+func yamlErrorDetail(msg string, content []byte, schemaErrors []schema.Error) (YAMLErrorDetail, bool) {
+	match := yamlErrorLineRegex.FindStringSubmatch(strings.TrimSpace(msg))
+	if match == nil {
+		return YAMLErrorDetail{}, false
+	}
+	line, err := strconv.Atoi(match[1])
+	if err != nil {
+		return YAMLErrorDetail{}, false
+	}
+	msg = match[2]
+
+	if field := yamlUnknownFieldRegex.FindStringSubmatch(msg); field != nil {
+		notAllowed := fmt.Sprintf("field '%s' is not allowed", field[1])
+		for _, schemaErr := range schemaErrors {
+			if schemaErr.Line == line && schemaErr.Message == notAllowed {
+				return detailFromSchema(schemaErr), true
+			}
+		}
+		return YAMLErrorDetail{Line: line, Column: keyColumn(content, line, field[1]), Message: notAllowed}, true
+	}
+
+	for _, schemaErr := range schemaErrors {
+		if schemaErr.Line == line && !strings.HasPrefix(schemaErr.Message, "field ") {
+			return detailFromSchema(schemaErr), true
+		}
+	}
+	return YAMLErrorDetail{Line: line, Message: yamlGoTypeRegex.ReplaceAllString(msg, "")}, true
+}
+
+func detailFromSchema(err schema.Error) YAMLErrorDetail {
+	return YAMLErrorDetail{
+		Line:       err.Line,
+		Column:     err.Column,
+		Path:       err.Path,
+		Message:    err.Message,
+		Suggestion: err.Suggestion,
+	}
+}
+
+// keyColumn returns the column of the key in the line of the content, or 0 if it isn't found
+func keyColumn(content []byte, line int, key string) int {
+	lines := strings.Split(string(content), "\n")
+	if line < 1 || line > len(lines) {
+		return 0
+	}
+	idx := strings.Index(lines[line-1], key)
+	if idx < 0 {
+		return 0
+	}
+	return len([]rune(lines[line-1][:idx])) + 1
+}
+
+// setYAMLErrorFile sets the path of the file to the yaml errors of err
+func setYAMLErrorFile(err error, path string) error {
+	var yamlErr *YAMLError
+	if errors.As(err, &yamlErr) {
+		yamlErr.File = path
+	}
+	return err
+}
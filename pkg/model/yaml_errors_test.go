@@ -0,0 +1,149 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadYAMLError(t *testing.T) {
+	_, err := Read([]byte(`dev:
+  api:
+    image: python
+    enviroment:
+      - A=1
+`))
+	require.Error(t, err)
+	assert.Equal(t, `
+    - line 4:5: dev.api: field 'enviroment' is not allowed, did you mean 'environment'?
+        3 |     image: python
+        4 |     enviroment:
+          |     ^
+        5 |       - A=1
+    See https://okteto.com/docs/reference/manifest/ for details`, err.Error())
+}
+
+func TestReadYAMLErrorWrongType(t *testing.T) {
+	_, err := Read([]byte(`dev:
+  api:
+    sync: 3
+`))
+	require.Error(t, err)
+	var yamlErr *YAMLError
+	require.ErrorAs(t, err, &yamlErr)
+	assert.Equal(t, []YAMLErrorDetail{{Line: 3, Column: 11, Path: "dev.api.sync", Message: "must be a list or a map, found the integer '3'"}}, yamlErr.Errors)
+}
+
+func TestReadYAMLErrorSyntax(t *testing.T) {
+	_, err := Read([]byte(`dev:
+  api:
+    sync: [
+`))
+	require.Error(t, err)
+	var yamlErr *YAMLError
+	require.ErrorAs(t, err, &yamlErr)
+	require.Len(t, yamlErr.Errors, 1)
+	assert.Equal(t, 0, yamlErr.Errors[0].Column)
+	assert.NotContains(t, yamlErr.Error(), "^")
+}
+
+func TestGetYAMLErrorWithFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "okteto.yml")
+	require.NoError(t, os.WriteFile(path, []byte("build:\n  api:\n    contxt: .\n"), 0600))
+
+	_, err := Get(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path+":3:5: build.api: field 'contxt' is not allowed, did you mean 'context'?")
+}
+
+func TestReadRCYAMLError(t *testing.T) {
+	_, err := ReadRC([]byte(`resources:
+  limit:
+    cpu: 1
+`))
+	require.Error(t, err)
+	assert.Equal(t, `Invalid developer level manifest:
+    - line 2:3: resources: field 'limit' is not allowed, did you mean 'limits'?
+        1 | resources:
+        2 |   limit:
+          |   ^
+        3 |     cpu: 1
+    See https://okteto.com/docs/reference/manifest/ for details`, err.Error())
+}
+
+func TestReadStackYAMLErrorUnsupportedFields(t *testing.T) {
+	_, err := ReadStack([]byte(`services:
+  api:
+    imag: python
+foo: bar
+`), true)
+	require.Error(t, err)
+	var yamlErr *YAMLError
+	require.ErrorAs(t, err, &yamlErr)
+	assert.Equal(t, []YAMLErrorDetail{
+		{Line: 3, Column: 5, Path: "services.api", Message: "field 'imag' is not allowed", Suggestion: "image"},
+		{Line: 4, Column: 1, Message: "field 'foo' is not allowed"},
+	}, yamlErr.Errors)
+}
+
+func TestYAMLErrorDetail(t *testing.T) {
+	content := []byte("dev:\n  api:\n    sync: 3\n")
+	var tests = []struct {
+		name     string
+		msg      string
+		expected YAMLErrorDetail
+		ok       bool
+	}{
+		{
+			name:     "unknown-field",
+			msg:      "line 2: field api not found in type model.devType",
+			expected: YAMLErrorDetail{Line: 2, Column: 3, Message: "field 'api' is not allowed"},
+			ok:       true,
+		},
+		{
+			name:     "go-type",
+			msg:      "line 3: cannot unmarshal !!int `3` into model.syncRaw",
+			expected: YAMLErrorDetail{Line: 3, Message: "cannot unmarshal !!int `3`"},
+			ok:       true,
+		},
+		{
+			name:     "syntax",
+			msg:      "yaml: line 3: did not find expected node content",
+			expected: YAMLErrorDetail{Line: 3, Message: "did not find expected node content"},
+			ok:       true,
+		},
+		{
+			name: "no-line",
+			msg:  "yaml: control characters are not allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail, ok := yamlErrorDetail(tt.msg, content, nil)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, detail)
+		})
+	}
+}
+
+func TestYAMLErrorSnippetTabs(t *testing.T) {
+	e := &YAMLError{lines: []string{"key:", "\t  value: x"}}
+	assert.Equal(t, "        1 | key:\n        2 | \t  value: x\n          | \t  ^\n", e.snippet(YAMLErrorDetail{Line: 2, Column: 4}))
+}
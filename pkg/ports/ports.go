@@ -0,0 +1,210 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ports allocates the local ports of the forwards defined with 'localPort: auto'
+package ports
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// portsFile is the file of the state folder of a development container where the allocated ports are recorded
+	portsFile = "okteto.ports"
+
+	// EnvFile is the file generated in the folder of the okteto manifest with the allocated ports
+	EnvFile = ".okteto/ports.env"
+
+	// minPort and maxPort are the range of the allocated ports, below the ephemeral ports of the OS
+	minPort = 20000
+	maxPort = 29999
+)
+
+var envVarRegex = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// Allocation is a local port allocated to a forward
+type Allocation struct {
+	Service string `yaml:"service,omitempty"`
+	Remote  int    `yaml:"remote"`
+	Local   int    `yaml:"local"`
+	Global  bool   `yaml:"global,omitempty"`
+}
+
+// Allocator allocates deterministic local ports: the same forward of the same development container
+// gets the same local port every time, unless it is in use by another process
+type Allocator struct {
+	seed        string
+	iface       string
+	previous    map[string]int
+	reserved    map[int]bool
+	allocations []Allocation
+	isAvailable func(iface string, port int) bool
+}
+
+// NewAllocator returns an allocator for the forwards of a development container.
+// Previous allocations are reused when their ports are still available, and reserved ports are never allocated
+func NewAllocator(namespace, name, iface string, previous []Allocation, reserved []int) *Allocator {
+	a := &Allocator{
+		seed:        fmt.Sprintf("%s/%s", namespace, name),
+		iface:       iface,
+		previous:    map[string]int{},
+		reserved:    map[int]bool{},
+		isAvailable: model.IsPortAvailable,
+	}
+	for _, p := range previous {
+		a.previous[p.key()] = p.Local
+	}
+	for _, p := range reserved {
+		a.reserved[p] = true
+	}
+	return a
+}
+
+// Allocate returns the local port of the forward to the remote port of a service, or of the development container if service is empty
+func (a *Allocator) Allocate(service string, remote int, global bool) (int, error) {
+	allocation := Allocation{Service: service, Remote: remote, Global: global}
+	key := allocation.key()
+
+	if port, ok := a.previous[key]; ok && !a.reserved[port] && a.isAvailable(a.iface, port) {
+		return a.add(allocation, port), nil
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(fmt.Sprintf("%s/%s", a.seed, key)))
+	size := maxPort - minPort + 1
+	start := int(h.Sum32() % uint32(size))
+	for i := 0; i < size; i++ {
+		port := minPort + (start+i)%size
+		if a.reserved[port] || !a.isAvailable(a.iface, port) {
+			continue
+		}
+		return a.add(allocation, port), nil
+	}
+	return 0, fmt.Errorf("there are no local ports available for the forward to port %d", remote)
+}
+
+func (a *Allocator) add(allocation Allocation, port int) int {
+	allocation.Local = port
+	a.reserved[port] = true
+	a.allocations = append(a.allocations, allocation)
+	return port
+}
+
+// Allocations returns the ports allocated by the allocator
+func (a *Allocator) Allocations() []Allocation {
+	return a.allocations
+}
+
+func (a Allocation) key() string {
+	key := strconv.Itoa(a.Remote)
+	if a.Service != "" {
+		key = fmt.Sprintf("%s:%d", a.Service, a.Remote)
+	}
+	if a.Global {
+		return fmt.Sprintf("global:%s", key)
+	}
+	return key
+}
+
+// String returns the mapping of the allocated port
+func (a Allocation) String() string {
+	if a.Service == "" {
+		return fmt.Sprintf("%d -> %d", a.Local, a.Remote)
+	}
+	return fmt.Sprintf("%d -> %s:%d", a.Local, a.Service, a.Remote)
+}
+
+// EnvVar returns the name of the environment variable with the allocated port,
+// 'OKTETO_PORT_<SERVICE>_<REMOTE>' or 'OKTETO_PORT_<REMOTE>' for the development container
+func (a Allocation) EnvVar() string {
+	if a.Service == "" {
+		return fmt.Sprintf("OKTETO_PORT_%d", a.Remote)
+	}
+	service := strings.Trim(envVarRegex.ReplaceAllString(strings.ToUpper(a.Service), "_"), "_")
+	return fmt.Sprintf("OKTETO_PORT_%s_%d", service, a.Remote)
+}
+
+// EnvVars returns the environment variables with the allocated ports
+func EnvVars(allocations []Allocation) []string {
+	envs := make([]string, 0, len(allocations))
+	for _, a := range allocations {
+		envs = append(envs, fmt.Sprintf("%s=%d", a.EnvVar(), a.Local))
+	}
+	sort.Strings(envs)
+	return envs
+}
+
+func getPath(namespace, name string) string {
+	return filepath.Join(config.GetAppHome(namespace, name), portsFile)
+}
+
+// Load returns the ports allocated to the forwards of a development container
+func Load(namespace, name string) ([]Allocation, error) {
+	b, err := os.ReadFile(getPath(namespace, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var allocations []Allocation
+	if err := yaml.Unmarshal(b, &allocations); err != nil {
+		return nil, fmt.Errorf("failed to read the allocated ports: %w", err)
+	}
+	return allocations, nil
+}
+
+// Save records the ports allocated to the forwards of a development container
+func Save(namespace, name string, allocations []Allocation) error {
+	b, err := yaml.Marshal(allocations)
+	if err != nil {
+		return err
+	}
+
+	p := getPath(namespace, name)
+	if err := os.WriteFile(p, b, 0600); err != nil {
+		return fmt.Errorf("failed to record the allocated ports: %w", err)
+	}
+	oktetoLog.Infof("allocated ports recorded in '%s'", p)
+	return nil
+}
+
+// WriteEnvFile writes the environment variables with the allocated ports in the file of the given path
+func WriteEnvFile(path string, allocations []Allocation) error {
+	var buf bytes.Buffer
+	buf.WriteString("# Generated by okteto, do not edit\n")
+	for _, env := range EnvVars(allocations) {
+		buf.WriteString(env)
+		buf.WriteString("\n")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0600)
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ports
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAllocator(previous []Allocation, reserved []int, taken map[int]bool) *Allocator {
+	a := NewAllocator("ns", "api", "localhost", previous, reserved)
+	a.isAvailable = func(_ string, port int) bool {
+		return !taken[port]
+	}
+	return a
+}
+
+func TestAllocateIsDeterministic(t *testing.T) {
+	first := newTestAllocator(nil, nil, nil)
+	port, err := first.Allocate("db", 5432, false)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, port, minPort)
+	assert.LessOrEqual(t, port, maxPort)
+
+	second := newTestAllocator(nil, nil, nil)
+	again, err := second.Allocate("db", 5432, false)
+	require.NoError(t, err)
+	assert.Equal(t, port, again)
+
+	other := NewAllocator("other-ns", "api", "localhost", nil, nil)
+	other.isAvailable = func(string, int) bool { return true }
+	otherPort, err := other.Allocate("db", 5432, false)
+	require.NoError(t, err)
+	assert.NotEqual(t, port, otherPort)
+}
+
+func TestAllocateSkipsUnavailablePorts(t *testing.T) {
+	port, err := newTestAllocator(nil, nil, nil).Allocate("", 8080, false)
+	require.NoError(t, err)
+
+	next, err := newTestAllocator(nil, []int{port}, nil).Allocate("", 8080, false)
+	require.NoError(t, err)
+	assert.NotEqual(t, port, next)
+
+	taken, err := newTestAllocator(nil, nil, map[int]bool{port: true}).Allocate("", 8080, false)
+	require.NoError(t, err)
+	assert.Equal(t, next, taken)
+
+	a := newTestAllocator(nil, nil, nil)
+	p1, err := a.Allocate("", 8080, false)
+	require.NoError(t, err)
+	p2, err := a.Allocate("", 8080, true)
+	require.NoError(t, err)
+	assert.NotEqual(t, p1, p2)
+	assert.Len(t, a.Allocations(), 2)
+}
+
+func TestAllocateReusesPreviousPorts(t *testing.T) {
+	previous := []Allocation{{Service: "db", Remote: 5432, Local: 21000}}
+
+	port, err := newTestAllocator(previous, nil, nil).Allocate("db", 5432, false)
+	require.NoError(t, err)
+	assert.Equal(t, 21000, port)
+
+	port, err = newTestAllocator(previous, nil, map[int]bool{21000: true}).Allocate("db", 5432, false)
+	require.NoError(t, err)
+	assert.NotEqual(t, 21000, port)
+
+	port, err = newTestAllocator(previous, nil, nil).Allocate("db", 5432, true)
+	require.NoError(t, err)
+	assert.NotEqual(t, 21000, port)
+}
+
+func TestEnvVars(t *testing.T) {
+	allocations := []Allocation{
+		{Service: "redis-cache", Remote: 6379, Local: 21001},
+		{Remote: 8080, Local: 21000},
+	}
+	assert.Equal(t, []string{"OKTETO_PORT_8080=21000", "OKTETO_PORT_REDIS_CACHE_6379=21001"}, EnvVars(allocations))
+	assert.Equal(t, "21001 -> redis-cache:6379", allocations[0].String())
+	assert.Equal(t, "21000 -> 8080", allocations[1].String())
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+
+	allocations, err := Load("ns", "api")
+	require.NoError(t, err)
+	assert.Empty(t, allocations)
+
+	expected := []Allocation{{Service: "db", Remote: 5432, Local: 21000, Global: true}, {Remote: 8080, Local: 21001}}
+	require.NoError(t, Save("ns", "api", expected))
+
+	allocations, err = Load("ns", "api")
+	require.NoError(t, err)
+	assert.Equal(t, expected, allocations)
+}
+
+func TestWriteEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), EnvFile)
+	require.NoError(t, WriteEnvFile(path, []Allocation{{Service: "db", Remote: 5432, Local: 21000}}))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Generated by okteto, do not edit\nOKTETO_PORT_DB_5432=21000\n", string(b))
+}
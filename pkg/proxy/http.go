@@ -0,0 +1,78 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+// hopHeaders are the headers meant for the proxy, removed before sending a request to the remote address
+var hopHeaders = []string{"Proxy-Connection", "Proxy-Authorization", "Connection", "Keep-Alive", "Upgrade"}
+
+// handleHTTP tunnels CONNECT requests, and sends plain HTTP requests to the host of their absolute URL.
+// Plain HTTP connections are closed after the first response
+func (p *Proxy) handleHTTP(ctx context.Context, conn net.Conn, br *bufio.Reader) error {
+	req, err := http.ReadRequest(br)
+	if err != nil {
+		return fmt.Errorf("failed to read http request: %w", err)
+	}
+
+	if req.Method == http.MethodConnect {
+		remote, err := p.dialer.DialContext(ctx, "tcp", req.Host)
+		if err != nil {
+			writeHTTPError(conn, http.StatusBadGateway)
+			return fmt.Errorf("failed to connect to %s: %w", req.Host, err)
+		}
+		if _, err := io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n"); err != nil {
+			return err
+		}
+		pipe(&bufferedConn{Reader: br, Writer: conn}, remote)
+		return nil
+	}
+
+	if req.URL.Scheme != "http" || req.URL.Host == "" {
+		writeHTTPError(conn, http.StatusBadRequest)
+		return fmt.Errorf("unsupported http proxy request for '%s'", req.URL.String())
+	}
+
+	address := req.URL.Host
+	if req.URL.Port() == "" {
+		address = net.JoinHostPort(req.URL.Hostname(), "80")
+	}
+	remote, err := p.dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		writeHTTPError(conn, http.StatusBadGateway)
+		return fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+
+	for _, h := range hopHeaders {
+		req.Header.Del(h)
+	}
+	req.Close = true
+	if err := req.Write(remote); err != nil {
+		writeHTTPError(conn, http.StatusBadGateway)
+		return fmt.Errorf("failed to send request to %s: %w", address, err)
+	}
+	pipe(conn, remote)
+	return nil
+}
+
+func writeHTTPError(w io.Writer, code int) {
+	_, _ = fmt.Fprintf(w, "HTTP/1.1 %d %s\r\nConnection: close\r\nContent-Length: 0\r\n\r\n", code, http.StatusText(code))
+}
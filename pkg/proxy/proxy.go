@@ -0,0 +1,125 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"sync"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// Dialer opens the connections to the addresses requested by the clients of the proxy
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// Proxy is a SOCKS5 and HTTP proxy listening on a single local address.
+// The protocol is detected from the first byte sent by each client
+type Proxy struct {
+	address string
+	dialer  Dialer
+}
+
+// New returns a proxy listening on address that opens the connections with dialer
+func New(address string, dialer Dialer) *Proxy {
+	return &Proxy{
+		address: address,
+		dialer:  dialer,
+	}
+}
+
+// ListenAndServe accepts connections on the address of the proxy until the context is cancelled
+func (p *Proxy) ListenAndServe(ctx context.Context) error {
+	l, err := net.Listen("tcp", p.address)
+	if err != nil {
+		return err
+	}
+	return p.Serve(ctx, l)
+}
+
+// Serve accepts connections on the listener until the context is cancelled
+func (p *Proxy) Serve(ctx context.Context, l net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		if err := l.Close(); err != nil {
+			oktetoLog.Infof("proxy %s -> failed to close: %s", p.address, err)
+		}
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if oktetoErrors.IsClosedNetwork(err) {
+				return nil
+			}
+			oktetoLog.Infof("proxy %s -> failed to accept connection: %s", p.address, err)
+			continue
+		}
+		go p.handle(ctx, conn)
+	}
+}
+
+func (p *Proxy) handle(ctx context.Context, conn net.Conn) {
+	defer func() {
+		if err := conn.Close(); err != nil && !oktetoErrors.IsClosedNetwork(err) {
+			oktetoLog.Debugf("Error closing proxy connection: %s", err)
+		}
+	}()
+
+	br := bufio.NewReader(conn)
+	version, err := br.Peek(1)
+	if err != nil {
+		return
+	}
+
+	if version[0] == socks5Version {
+		err = p.handleSOCKS5(ctx, conn, br)
+	} else {
+		err = p.handleHTTP(ctx, conn, br)
+	}
+	if err != nil {
+		oktetoLog.Infof("proxy %s -> %s", p.address, err)
+	}
+}
+
+// pipe copies data in both directions until one of the connections is closed
+func pipe(client io.ReadWriter, remote net.Conn) {
+	var once sync.Once
+	done := make(chan struct{})
+	closeDone := func() {
+		once.Do(func() { close(done) })
+	}
+
+	go func() {
+		_, _ = io.Copy(remote, client)
+		closeDone()
+	}()
+	go func() {
+		_, _ = io.Copy(client, remote)
+		closeDone()
+	}()
+
+	<-done
+	if err := remote.Close(); err != nil && !oktetoErrors.IsClosedNetwork(err) {
+		oktetoLog.Debugf("Error closing remote connection: %s", err)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testServiceHost = "api.test.svc.cluster.local"
+
+// fakeDialer resolves the addresses of the test service to a local server
+type fakeDialer struct {
+	addresses map[string]string
+}
+
+func (d *fakeDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	target, ok := d.addresses[address]
+	if !ok {
+		return nil, fmt.Errorf("unknown address %s", address)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, target)
+}
+
+func startTestProxy(t *testing.T) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "hello from %s%s", r.Host, r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	serverAddress := server.Listener.Addr().String()
+	dialer := &fakeDialer{
+		addresses: map[string]string{
+			net.JoinHostPort(testServiceHost, "80"): serverAddress,
+		},
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	p := New(l.Addr().String(), dialer)
+	go func() {
+		_ = p.Serve(ctx, l)
+	}()
+	return l.Addr().String()
+}
+
+func getWithProxy(t *testing.T, proxyURL, target string) (int, string) {
+	u, err := url.Parse(proxyURL)
+	require.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(u)}}
+	resp, err := client.Get(target)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestProxySOCKS5(t *testing.T) {
+	address := startTestProxy(t)
+
+	code, body := getWithProxy(t, "socks5://"+address, "http://"+testServiceHost+"/users")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "hello from "+testServiceHost+"/users", body)
+
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(&url.URL{Scheme: "socks5", Host: address})}}
+	_, err := client.Get("http://unknown.test.svc.cluster.local/")
+	assert.Error(t, err)
+}
+
+func TestProxyHTTP(t *testing.T) {
+	address := startTestProxy(t)
+
+	code, body := getWithProxy(t, "http://"+address, "http://"+testServiceHost+"/users")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "hello from "+testServiceHost+"/users", body)
+
+	code, _ = getWithProxy(t, "http://"+address, "http://unknown.test.svc.cluster.local/")
+	assert.Equal(t, http.StatusBadGateway, code)
+}
+
+func TestProxyHTTPConnect(t *testing.T) {
+	address := startTestProxy(t)
+
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	target := net.JoinHostPort(testServiceHost, "80")
+	_, err = fmt.Fprintf(conn, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", target, target)
+	require.NoError(t, err)
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+testServiceHost+"/tunnel", nil)
+	require.NoError(t, err)
+	require.NoError(t, req.Write(conn))
+	resp, err = http.ReadResponse(br, req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "hello from "+testServiceHost+"/tunnel", string(body))
+}
+
+func TestReadSOCKS5Request(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  []byte
+		expected string
+		reply    byte
+		wantErr  bool
+	}{
+		{
+			name:     "ipv4",
+			request:  []byte{socks5Version, socks5Connect, 0, socks5IPv4, 10, 0, 0, 1, 0x1f, 0x90},
+			expected: "10.0.0.1:8080",
+		},
+		{
+			name:     "domain",
+			request:  append(append([]byte{socks5Version, socks5Connect, 0, socks5Domain, 3}, []byte("svc")...), 0, 80),
+			expected: "svc:80",
+		},
+		{
+			name:     "ipv6",
+			request:  append(append([]byte{socks5Version, socks5Connect, 0, socks5IPv6}, net.ParseIP("::1")...), 0, 80),
+			expected: "[::1]:80",
+		},
+		{
+			name:    "bind",
+			request: []byte{socks5Version, 0x02, 0, socks5IPv4, 10, 0, 0, 1, 0, 80},
+			reply:   socks5CommandNotSupported,
+			wantErr: true,
+		},
+		{
+			name:    "unknown-address-type",
+			request: []byte{socks5Version, socks5Connect, 0, 0x09},
+			reply:   socks5AddressNotSupported,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, reply, err := readSOCKS5Request(bufio.NewReader(bytes.NewReader(tt.request)))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, address)
+			assert.Equal(t, tt.reply, reply)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+// SOCKS5 constants as defined in RFC 1928. Only the CONNECT command without authentication is supported
+const (
+	socks5Version = 0x05
+
+	socks5NoAuth       = 0x00
+	socks5NoAcceptable = 0xff
+
+	socks5Connect = 0x01
+
+	socks5IPv4   = 0x01
+	socks5Domain = 0x03
+	socks5IPv6   = 0x04
+
+	socks5Succeeded           = 0x00
+	socks5HostUnreachable     = 0x04
+	socks5CommandNotSupported = 0x07
+	socks5AddressNotSupported = 0x08
+)
+
+func (p *Proxy) handleSOCKS5(ctx context.Context, conn net.Conn, br *bufio.Reader) error {
+	if err := negotiateSOCKS5(conn, br); err != nil {
+		return err
+	}
+
+	address, reply, err := readSOCKS5Request(br)
+	if err != nil {
+		if reply != 0 {
+			writeSOCKS5Reply(conn, reply)
+		}
+		return err
+	}
+
+	remote, err := p.dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		writeSOCKS5Reply(conn, socks5HostUnreachable)
+		return fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+
+	writeSOCKS5Reply(conn, socks5Succeeded)
+	pipe(&bufferedConn{Reader: br, Writer: conn}, remote)
+	return nil
+}
+
+// negotiateSOCKS5 reads the methods supported by the client and selects 'no authentication'
+func negotiateSOCKS5(conn net.Conn, br *bufio.Reader) error {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return err
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(br, methods); err != nil {
+		return err
+	}
+	for _, m := range methods {
+		if m == socks5NoAuth {
+			_, err := conn.Write([]byte{socks5Version, socks5NoAuth})
+			return err
+		}
+	}
+	if _, err := conn.Write([]byte{socks5Version, socks5NoAcceptable}); err != nil {
+		return err
+	}
+	return fmt.Errorf("socks5 client doesn't support connections without authentication")
+}
+
+// readSOCKS5Request returns the address requested by the client, or the reply to send if the request is not supported
+func readSOCKS5Request(br *bufio.Reader) (string, byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return "", 0, err
+	}
+	if header[1] != socks5Connect {
+		return "", socks5CommandNotSupported, fmt.Errorf("socks5 command %d is not supported", header[1])
+	}
+
+	var host string
+	switch header[3] {
+	case socks5IPv4, socks5IPv6:
+		size := net.IPv4len
+		if header[3] == socks5IPv6 {
+			size = net.IPv6len
+		}
+		ip := make([]byte, size)
+		if _, err := io.ReadFull(br, ip); err != nil {
+			return "", 0, err
+		}
+		host = net.IP(ip).String()
+	case socks5Domain:
+		size, err := br.ReadByte()
+		if err != nil {
+			return "", 0, err
+		}
+		domain := make([]byte, size)
+		if _, err := io.ReadFull(br, domain); err != nil {
+			return "", 0, err
+		}
+		host = string(domain)
+	default:
+		return "", socks5AddressNotSupported, fmt.Errorf("socks5 address type %d is not supported", header[3])
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(br, port); err != nil {
+		return "", 0, err
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), 0, nil
+}
+
+// writeSOCKS5Reply sends the reply to a request. The bound address is not relevant for CONNECT, so it is always 0.0.0.0:0
+func writeSOCKS5Reply(w io.Writer, reply byte) {
+	_, _ = w.Write([]byte{socks5Version, reply, 0x00, socks5IPv4, 0, 0, 0, 0, 0, 0})
+}
+
+// bufferedConn reads from the buffered reader of a connection, since it can hold data sent by the client after the request
+type bufferedConn struct {
+	io.Reader
+	io.Writer
+}
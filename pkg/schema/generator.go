@@ -0,0 +1,193 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// OverrideFunc returns the schema of a type with a custom yaml unmarshaler
+type OverrideFunc func(g *Generator) *Schema
+
+// Generator generates JSON Schemas from Go types, following the field rules of gopkg.in/yaml.v2:
+// fields are named by their 'yaml' tag or their lowercased name, and structs don't accept unknown fields as with yaml.UnmarshalStrict.
+// Named structs and overridden types are added to the definitions of the schema
+type Generator struct {
+	// ExtensionsPattern is the pattern of the fields accepted by inline maps, any field is accepted when empty
+	ExtensionsPattern string
+
+	overrides map[reflect.Type]OverrideFunc
+	names     map[reflect.Type]string
+	defs      map[string]*Schema
+}
+
+// NewGenerator returns a generator without overrides
+func NewGenerator() *Generator {
+	g := &Generator{
+		overrides: map[reflect.Type]OverrideFunc{},
+		names:     map[reflect.Type]string{},
+		defs:      map[string]*Schema{},
+	}
+	// yaml.v2 decodes durations from strings like '1m30s'
+	g.Override(reflect.TypeOf(time.Duration(0)), func(*Generator) *Schema {
+		return OneOf(String(), &Schema{Type: TypeInteger})
+	})
+	return g
+}
+
+// Override replaces the schema of t, for types which accept other syntaxes than their fields
+func (g *Generator) Override(t reflect.Type, fn OverrideFunc) {
+	g.overrides[t] = fn
+}
+
+// Generate returns the schema of t with the definitions of all the types it refers to
+func (g *Generator) Generate(t reflect.Type, id, title string) *Schema {
+	root := g.Reflect(t)
+	root.Schema = Draft
+	root.ID = id
+	root.Title = title
+	root.Defs = g.defs
+	return root
+}
+
+// Reflect returns the schema of t, a reference for named structs and overridden types
+func (g *Generator) Reflect(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	fn, overridden := g.overrides[t]
+	if overridden || (t.Kind() == reflect.Struct && t.Name() != "") {
+		if _, ok := g.names[t]; !ok {
+			name := g.defName(t)
+			g.names[t] = name
+			// placeholder for recursive types
+			g.defs[name] = &Schema{}
+			if overridden {
+				g.defs[name] = fn(g)
+			} else {
+				g.defs[name] = g.Struct(t)
+			}
+		}
+		return &Schema{Ref: defsPrefix + g.names[t]}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return String()
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: TypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: TypeNumber}
+	case reflect.Slice, reflect.Array:
+		return ArrayOf(g.Reflect(t.Elem()))
+	case reflect.Map:
+		return MapOf(g.Reflect(t.Elem()))
+	case reflect.Struct:
+		return g.Struct(t)
+	default:
+		return Bool(true)
+	}
+}
+
+// Struct returns the object schema of the fields of t, ignoring its override
+func (g *Generator) Struct(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	s := &Schema{
+		Type:                 TypeObject,
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: Bool(false),
+	}
+	g.addFields(s, t)
+	return s
+}
+
+func (g *Generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") {
+			g.addInline(s, f.Type)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		s.Properties[name] = g.Reflect(f.Type)
+	}
+}
+
+func (g *Generator) addInline(s *Schema, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		g.addFields(s, t)
+	case reflect.Map:
+		values := g.Reflect(t.Elem())
+		if g.ExtensionsPattern == "" {
+			s.AdditionalProperties = values
+			return
+		}
+		s.PatternProperties = map[string]*Schema{g.ExtensionsPattern: values}
+	}
+}
+
+// defName returns a unique definition name for t
+func (g *Generator) defName(t reflect.Type) string {
+	name := exported(t.Name())
+	if name == "" {
+		name = exported(t.Kind().String())
+	}
+	if _, taken := g.defs[name]; !taken {
+		return name
+	}
+
+	name = exported(path.Base(t.PkgPath())) + name
+	candidate := name
+	for i := 2; ; i++ {
+		if _, taken := g.defs[candidate]; !taken {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+}
+
+func exported(name string) string {
+	if name == "" {
+		return ""
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
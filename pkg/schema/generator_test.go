@@ -0,0 +1,100 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNode struct {
+	Name       string            `yaml:"name"`
+	Children   []*testNode       `yaml:"children,omitempty"`
+	Timeout    time.Duration     `yaml:"timeout,omitempty"`
+	Labels     map[string]string `yaml:"labels,omitempty"`
+	Replicas   int
+	Ignored    string `yaml:"-"`
+	internal   string
+	testInline `yaml:",inline"`
+}
+
+type testInline struct {
+	Public bool `yaml:"public"`
+}
+
+type testExtensions struct {
+	Name       string                 `yaml:"name"`
+	Extensions map[string]interface{} `yaml:",inline"`
+}
+
+type testCommand struct {
+	Values []string
+}
+
+func TestGenerate(t *testing.T) {
+	s := NewGenerator().Generate(reflect.TypeOf(testNode{}), "https://okteto.com/test.json", "Test")
+	assert.Equal(t, Draft, s.Schema)
+	assert.Equal(t, "https://okteto.com/test.json", s.ID)
+	assert.Equal(t, "#/$defs/TestNode", s.Ref)
+
+	node := s.Def("TestNode")
+	require.NotNil(t, node)
+	assert.Equal(t, TypeObject, node.Type)
+	assert.Equal(t, String(), node.Property("name"))
+	assert.Equal(t, ArrayOf(&Schema{Ref: "#/$defs/TestNode"}), node.Property("children"))
+	assert.Equal(t, MapOf(String()), node.Property("labels"))
+	assert.Equal(t, &Schema{Type: TypeInteger}, node.Property("replicas"))
+	assert.Equal(t, &Schema{Type: TypeBoolean}, node.Property("public"))
+	assert.Equal(t, &Schema{Ref: "#/$defs/Duration"}, node.Property("timeout"))
+	assert.Nil(t, node.Property("ignored"))
+	assert.Nil(t, node.Property("internal"))
+	assert.Equal(t, Bool(false), node.AdditionalProperties)
+}
+
+func TestGenerateOverride(t *testing.T) {
+	g := NewGenerator()
+	g.Override(reflect.TypeOf(testCommand{}), func(*Generator) *Schema {
+		return OneOf(String(), ArrayOf(String()))
+	})
+	s := g.Generate(reflect.TypeOf(testCommand{}), "", "")
+	assert.Equal(t, OneOf(String(), ArrayOf(String())), s.Def("TestCommand"))
+}
+
+func TestGenerateExtensions(t *testing.T) {
+	g := NewGenerator()
+	s := g.Generate(reflect.TypeOf(testExtensions{}), "", "")
+	assert.Equal(t, Bool(true), s.Def("TestExtensions").AdditionalProperties)
+
+	g = NewGenerator()
+	g.ExtensionsPattern = "^x-"
+	s = g.Generate(reflect.TypeOf(testExtensions{}), "", "")
+	assert.Equal(t, map[string]*Schema{"^x-": Bool(true)}, s.Def("TestExtensions").PatternProperties)
+	assert.Equal(t, Bool(false), s.Def("TestExtensions").AdditionalProperties)
+}
+
+func TestMarshalJSON(t *testing.T) {
+	s := &Schema{
+		Type:                 TypeObject,
+		Properties:           map[string]*Schema{"any": Bool(true)},
+		AdditionalProperties: Bool(false),
+	}
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","properties":{"any":true},"additionalProperties":false}`, string(b))
+}
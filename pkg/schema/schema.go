@@ -0,0 +1,116 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema generates JSON Schemas from Go types and validates YAML documents against them
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	// Draft is the JSON Schema version of the generated schemas
+	Draft = "https://json-schema.org/draft/2020-12/schema"
+
+	// TypeObject is the type of the YAML maps
+	TypeObject = "object"
+
+	// TypeArray is the type of the YAML lists
+	TypeArray = "array"
+
+	// TypeString is the type of the YAML strings
+	TypeString = "string"
+
+	// TypeInteger is the type of the YAML integers
+	TypeInteger = "integer"
+
+	// TypeNumber is the type of the YAML numbers
+	TypeNumber = "number"
+
+	// TypeBoolean is the type of the YAML booleans
+	TypeBoolean = "boolean"
+
+	defsPrefix = "#/$defs/"
+)
+
+// Schema is a JSON Schema, limited to the keywords needed to describe okteto files
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Deprecated           bool               `json:"deprecated,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+
+	// boolean is set for the schemas 'true' and 'false', which accept any value or none
+	boolean *bool
+}
+
+// Bool returns the schema that accepts any value when b is true, and no value otherwise
+func Bool(b bool) *Schema {
+	return &Schema{boolean: &b}
+}
+
+// OneOf returns a schema that accepts the values of any of the schemas
+func OneOf(schemas ...*Schema) *Schema {
+	return &Schema{AnyOf: schemas}
+}
+
+// String returns the schema of a string
+func String() *Schema {
+	return &Schema{Type: TypeString}
+}
+
+// ArrayOf returns the schema of a list of items
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: TypeArray, Items: items}
+}
+
+// MapOf returns the schema of a map with values of the given schema
+func MapOf(values *Schema) *Schema {
+	return &Schema{Type: TypeObject, AdditionalProperties: values}
+}
+
+// Def returns the definition called name, or nil if it doesn't exist
+func (s *Schema) Def(name string) *Schema {
+	return s.Defs[name]
+}
+
+// Property returns the schema of the property called name, or nil if it doesn't exist
+func (s *Schema) Property(name string) *Schema {
+	return s.Properties[name]
+}
+
+// MarshalJSON encodes the boolean schemas as JSON booleans
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.boolean != nil {
+		return json.Marshal(*s.boolean)
+	}
+	type schema Schema // prevent recursion
+	return json.Marshal((*schema)(s))
+}
+
+// resolve returns the definition referenced by ref
+func (s *Schema) resolve(ref string) *Schema {
+	return s.Defs[strings.TrimPrefix(ref, defsPrefix)]
+}
@@ -0,0 +1,446 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	nullTag  = "!!null"
+	intTag   = "!!int"
+	floatTag = "!!float"
+	boolTag  = "!!bool"
+	mergeKey = "<<"
+)
+
+// yaml11Booleans are the booleans of YAML 1.1, decoded by gopkg.in/yaml.v2 but not tagged as booleans by gopkg.in/yaml.v3
+var yaml11Booleans = map[string]bool{
+	"y": true, "Y": true, "yes": true, "Yes": true, "YES": true, "on": true, "On": true, "ON": true,
+	"n": true, "N": true, "no": true, "No": true, "NO": true, "off": true, "Off": true, "OFF": true,
+}
+
+// Error is an error of a YAML document that doesn't match a schema
+type Error struct {
+	Line    int
+	Column  int
+	Path    string
+	Message string
+	// Suggestion is the closest allowed field to an unknown field
+	Suggestion string
+}
+
+func (e Error) Error() string {
+	msg := e.Message
+	if e.Suggestion != "" {
+		msg = fmt.Sprintf("%s, did you mean '%s'?", msg, e.Suggestion)
+	}
+	if e.Path == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, msg)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Column, e.Path, msg)
+}
+
+// Validate returns the errors of the YAML document against the schema, sorted by position
+func Validate(s *Schema, doc *yaml.Node) []Error {
+	v := &validator{root: s}
+	errs := v.validate(s, doc, "")
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+		return errs[i].Column < errs[j].Column
+	})
+	return errs
+}
+
+type validator struct {
+	root *Schema
+}
+
+func (v *validator) validate(s *Schema, n *yaml.Node, path string) []Error {
+	n = resolveNode(n)
+	if n == nil {
+		return nil
+	}
+	s = v.deref(s)
+	if s.boolean != nil {
+		if *s.boolean {
+			return nil
+		}
+		return []Error{newError(n, path, "is not allowed")}
+	}
+	if n.Kind == yaml.ScalarNode && n.Tag == nullTag {
+		return nil
+	}
+
+	if len(s.AnyOf) > 0 {
+		return v.validateAnyOf(s, n, path)
+	}
+
+	if !v.matchesType(s, n) {
+		return []Error{newError(n, path, fmt.Sprintf("must be %s, found %s", describe(s.Type), describeNode(n)))}
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		return v.validateMapping(s, n, path)
+	case yaml.SequenceNode:
+		var errs []Error
+		if s.Items != nil {
+			for i, item := range n.Content {
+				errs = append(errs, v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+		return errs
+	case yaml.ScalarNode:
+		if len(s.Enum) > 0 && !isTemplate(n) && !contains(s.Enum, n.Value) {
+			return []Error{newError(n, path, fmt.Sprintf("must be one of '%s', found '%s'", strings.Join(s.Enum, "', '"), n.Value))}
+		}
+	}
+	return nil
+}
+
+// validateAnyOf validates the node against the alternatives of its type.
+// When none of them is valid, the errors of the alternative that recognizes more fields of the node are returned,
+// or the ones of the alternative with less errors
+func (v *validator) validateAnyOf(s *Schema, n *yaml.Node, path string) []Error {
+	var candidates []*Schema
+	for _, alternative := range s.AnyOf {
+		if v.matchesType(alternative, n) {
+			candidates = append(candidates, alternative)
+		}
+	}
+	if len(candidates) == 0 {
+		return []Error{newError(n, path, fmt.Sprintf("must be %s, found %s", v.describeAnyOf(s), describeNode(n)))}
+	}
+
+	var best []Error
+	bestUnknown := 0
+	for i, alternative := range candidates {
+		errs := v.validate(alternative, n, path)
+		if len(errs) == 0 {
+			return nil
+		}
+		unknown := v.unknownFields(alternative, n)
+		if i == 0 || unknown < bestUnknown || (unknown == bestUnknown && len(errs) < len(best)) {
+			best = errs
+			bestUnknown = unknown
+		}
+	}
+	return best
+}
+
+// unknownFields returns the number of fields of a mapping node not allowed by the schema
+func (v *validator) unknownFields(s *Schema, n *yaml.Node) int {
+	s = v.deref(s)
+	if n.Kind != yaml.MappingNode || len(s.AnyOf) > 0 {
+		return 0
+	}
+	unknown := 0
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value != mergeKey && v.propertySchema(s, n.Content[i].Value) == nil {
+			unknown++
+		}
+	}
+	return unknown
+}
+
+func (v *validator) validateMapping(s *Schema, n *yaml.Node, path string) []Error {
+	var errs []Error
+	found := map[string]bool{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if key.Value == mergeKey {
+			for _, merged := range mergedMappings(value) {
+				errs = append(errs, v.validateMapping(s, merged, path)...)
+			}
+			continue
+		}
+
+		found[key.Value] = true
+		fieldPath := joinPath(path, key.Value)
+		property := v.propertySchema(s, key.Value)
+		if property == nil {
+			err := newError(key, path, fmt.Sprintf("field '%s' is not allowed", key.Value))
+			err.Suggestion = closestProperty(s, key.Value)
+			errs = append(errs, err)
+			continue
+		}
+		errs = append(errs, v.validate(property, value, fieldPath)...)
+	}
+
+	for _, required := range s.Required {
+		if !found[required] {
+			errs = append(errs, newError(n, path, fmt.Sprintf("field '%s' is required", required)))
+		}
+	}
+	return errs
+}
+
+// propertySchema returns the schema of the property called name, or nil if it isn't allowed
+func (v *validator) propertySchema(s *Schema, name string) *Schema {
+	if property, ok := s.Properties[name]; ok {
+		return property
+	}
+	for pattern, property := range s.PatternProperties {
+		if matched, err := regexp.MatchString(pattern, name); err == nil && matched {
+			return property
+		}
+	}
+	if s.AdditionalProperties == nil {
+		return Bool(true)
+	}
+	additional := v.deref(s.AdditionalProperties)
+	if additional.boolean != nil && !*additional.boolean {
+		return nil
+	}
+	return additional
+}
+
+// matchesType returns if the kind of the node is one of the types of the schema, without validating its content
+func (v *validator) matchesType(s *Schema, n *yaml.Node) bool {
+	s = v.deref(s)
+	if s.boolean != nil {
+		return *s.boolean
+	}
+	if n.Kind == yaml.ScalarNode && n.Tag == nullTag {
+		return true
+	}
+	if len(s.AnyOf) > 0 {
+		for _, alternative := range s.AnyOf {
+			if v.matchesType(alternative, n) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch s.Type {
+	case "":
+		return true
+	case TypeObject:
+		return n.Kind == yaml.MappingNode
+	case TypeArray:
+		return n.Kind == yaml.SequenceNode
+	}
+
+	if n.Kind != yaml.ScalarNode {
+		return false
+	}
+	// variables are expanded before the file is decoded
+	if isTemplate(n) {
+		return true
+	}
+	switch s.Type {
+	case TypeInteger:
+		return n.Tag == intTag
+	case TypeNumber:
+		return n.Tag == intTag || n.Tag == floatTag
+	case TypeBoolean:
+		return n.Tag == boolTag || yaml11Booleans[n.Value]
+	default:
+		// yaml.v2 decodes any scalar into a string
+		return true
+	}
+}
+
+func (v *validator) deref(s *Schema) *Schema {
+	for s.Ref != "" {
+		resolved := v.root.resolve(s.Ref)
+		if resolved == nil {
+			return Bool(true)
+		}
+		s = resolved
+	}
+	return s
+}
+
+func (v *validator) describeAnyOf(s *Schema) string {
+	var types []string
+	for _, alternative := range s.AnyOf {
+		alternative = v.deref(alternative)
+		if len(alternative.AnyOf) > 0 {
+			types = append(types, v.describeAnyOf(alternative))
+			continue
+		}
+		description := describe(alternative.Type)
+		if !contains(types, description) {
+			types = append(types, description)
+		}
+	}
+	if len(types) == 1 {
+		return types[0]
+	}
+	return strings.Join(types[:len(types)-1], ", ") + " or " + types[len(types)-1]
+}
+
+func describe(schemaType string) string {
+	switch schemaType {
+	case TypeObject:
+		return "a map"
+	case TypeArray:
+		return "a list"
+	case TypeInteger:
+		return "an integer"
+	case TypeNumber:
+		return "a number"
+	case TypeBoolean:
+		return "a boolean"
+	case TypeString:
+		return "a string"
+	default:
+		return "a value"
+	}
+}
+
+func describeNode(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a map"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	switch n.Tag {
+	case intTag:
+		return fmt.Sprintf("the integer '%s'", n.Value)
+	case floatTag:
+		return fmt.Sprintf("the number '%s'", n.Value)
+	case boolTag:
+		return fmt.Sprintf("the boolean '%s'", n.Value)
+	default:
+		return fmt.Sprintf("'%s'", n.Value)
+	}
+}
+
+// resolveNode returns the content of documents and the target of aliases
+func resolveNode(n *yaml.Node) *yaml.Node {
+	for n != nil {
+		switch n.Kind {
+		case yaml.DocumentNode:
+			if len(n.Content) == 0 {
+				return nil
+			}
+			n = n.Content[0]
+		case yaml.AliasNode:
+			n = n.Alias
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// mergedMappings returns the mappings merged with the '<<' key
+func mergedMappings(n *yaml.Node) []*yaml.Node {
+	n = resolveNode(n)
+	if n == nil {
+		return nil
+	}
+	if n.Kind == yaml.SequenceNode {
+		var result []*yaml.Node
+		for _, item := range n.Content {
+			result = append(result, mergedMappings(item)...)
+		}
+		return result
+	}
+	if n.Kind == yaml.MappingNode {
+		return []*yaml.Node{n}
+	}
+	return nil
+}
+
+// closestProperty returns the property of the schema with the smallest edit distance to name,
+// or an empty string if none of them is close enough to be a typo
+func closestProperty(s *Schema, name string) string {
+	properties := make([]string, 0, len(s.Properties))
+	for property := range s.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	maxDistance := len(name) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+	closest := ""
+	for _, property := range properties {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(property)); d <= maxDistance {
+			closest = property
+			maxDistance = d - 1
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func isTemplate(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && strings.Contains(n.Value, "$")
+}
+
+func newError(n *yaml.Node, path, msg string) Error {
+	return Error{Line: n.Line, Column: n.Column, Path: path, Message: msg}
+}
+
+func joinPath(path, key string) string {
+	if strings.ContainsAny(key, ". ") {
+		key = strconv.Quote(key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
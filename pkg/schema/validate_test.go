@@ -0,0 +1,146 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func validate(t *testing.T, s *Schema, manifest string) []Error {
+	t.Helper()
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(manifest), &doc))
+	return Validate(s, &doc)
+}
+
+func TestValidate(t *testing.T) {
+	g := NewGenerator()
+	g.Override(reflect.TypeOf(testCommand{}), func(*Generator) *Schema {
+		return OneOf(String(), ArrayOf(String()))
+	})
+	s := g.Generate(reflect.TypeOf(struct {
+		Node    testNode    `yaml:"node"`
+		Command testCommand `yaml:"command"`
+		Mode    string      `yaml:"mode"`
+	}{}), "", "")
+	s.Property("mode").Enum = []string{"sync", "hybrid"}
+
+	var tests = []struct {
+		name     string
+		manifest string
+		expected []Error
+	}{
+		{
+			name: "valid",
+			manifest: `node:
+  name: api
+  timeout: 1m
+  replicas: 2
+  public: yes
+  children:
+    - name: worker
+      replicas: ${REPLICAS}
+command: ["bash", "-c"]
+mode: hybrid`,
+		},
+		{
+			name: "unknown-field",
+			manifest: `node:
+  name: api
+  replica: 2`,
+			expected: []Error{{Line: 3, Column: 3, Path: "node", Message: "field 'replica' is not allowed", Suggestion: "replicas"}},
+		},
+		{
+			name: "wrong-types",
+			manifest: `node:
+  replicas: two
+  children:
+    - labels: [a]
+command:
+  a: b`,
+			expected: []Error{
+				{Line: 2, Column: 13, Path: "node.replicas", Message: "must be an integer, found 'two'"},
+				{Line: 4, Column: 15, Path: "node.children[0].labels", Message: "must be a map, found a list"},
+				{Line: 6, Column: 3, Path: "command", Message: "must be a string or a list, found a map"},
+			},
+		},
+		{
+			name:     "enum",
+			manifest: `mode: magic`,
+			expected: []Error{{Line: 1, Column: 7, Path: "mode", Message: "must be one of 'sync', 'hybrid', found 'magic'"}},
+		},
+		{
+			name: "merge-keys",
+			manifest: `base: &base
+  name: api
+  foo: bar
+node:
+  <<: *base`,
+			expected: []Error{
+				{Line: 1, Column: 1, Message: "field 'base' is not allowed"},
+				{Line: 3, Column: 3, Path: "node", Message: "field 'foo' is not allowed"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validate(t, s, tt.manifest))
+		})
+	}
+}
+
+func TestValidateAnyOfReportsClosestAlternative(t *testing.T) {
+	type short struct {
+		Image string `yaml:"image"`
+	}
+	type long struct {
+		Build  string `yaml:"build"`
+		Deploy []string
+	}
+	g := NewGenerator()
+	s := OneOf(g.Reflect(reflect.TypeOf(short{})), g.Reflect(reflect.TypeOf(long{})))
+	s.Defs = g.defs
+
+	errs := validate(t, s, `build: api
+deploy: kubectl apply`)
+	assert.Equal(t, []Error{{Line: 2, Column: 9, Path: "deploy", Message: "must be a list, found 'kubectl apply'"}}, errs)
+}
+
+func TestErrorString(t *testing.T) {
+	assert.Equal(t, "2:3: dev.api: field 'foo' is not allowed", Error{Line: 2, Column: 3, Path: "dev.api", Message: "field 'foo' is not allowed"}.Error())
+	assert.Equal(t, "1:1: field 'foo' is not allowed", Error{Line: 1, Column: 1, Message: "field 'foo' is not allowed"}.Error())
+	assert.Equal(t, "3:5: dev.api: field 'enviroment' is not allowed, did you mean 'environment'?", Error{Line: 3, Column: 5, Path: "dev.api", Message: "field 'enviroment' is not allowed", Suggestion: "environment"}.Error())
+}
+
+func TestClosestProperty(t *testing.T) {
+	s := &Schema{Properties: map[string]*Schema{"environment": String(), "image": String(), "command": String(), "name": String()}}
+	assert.Equal(t, "environment", closestProperty(s, "enviroment"))
+	assert.Equal(t, "image", closestProperty(s, "Imag"))
+	assert.Equal(t, "name", closestProperty(s, "nme"))
+	assert.Empty(t, closestProperty(s, "cmd"))
+	assert.Empty(t, closestProperty(s, "volumes"))
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("sync", "sync"))
+	assert.Equal(t, 1, editDistance("enviroment", "environment"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 4, editDistance("", "port"))
+}
@@ -0,0 +1,33 @@
+//go:build !windows
+// +build !windows
+
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"net"
+	"os"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// agentAddress returns the address of the local SSH agent, or an empty string if there is none
+func agentAddress() string {
+	return os.Getenv(model.SshAuthSockEnvVar)
+}
+
+func dialAgent(address string) (net.Conn, error) {
+	return net.Dial(unixNetwork, address)
+}
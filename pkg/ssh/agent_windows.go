@@ -0,0 +1,51 @@
+//go:build windows
+// +build windows
+
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"net"
+	"os"
+	"strings"
+
+	"github.com/Microsoft/go-winio"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const (
+	pipePrefix = `\\.\pipe\`
+
+	// openSSHAgentPipe is the named pipe of the OpenSSH agent service of Windows, which doesn't set SSH_AUTH_SOCK
+	openSSHAgentPipe = pipePrefix + "openssh-ssh-agent"
+)
+
+// agentAddress returns the address of the local SSH agent, or an empty string if there is none
+func agentAddress() string {
+	if sock := os.Getenv(model.SshAuthSockEnvVar); sock != "" {
+		return sock
+	}
+	if _, err := os.Stat(openSSHAgentPipe); err == nil {
+		return openSSHAgentPipe
+	}
+	return ""
+}
+
+func dialAgent(address string) (net.Conn, error) {
+	if strings.HasPrefix(address, pipePrefix) {
+		return winio.DialPipe(address, nil)
+	}
+	return net.Dial(unixNetwork, address)
+}
@@ -30,6 +30,29 @@ var tOnce sync.Once
 
 func getPrivateKey() (ssh.Signer, error) {
 	_, private := getKeyPaths()
+	return readPrivateKey(private)
+}
+
+// getPrivateKeys returns the okteto private key, and the previous one while it's still authorized in some namespaces
+func getPrivateKeys() ([]ssh.Signer, error) {
+	key, err := getPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	keys := []ssh.Signer{key}
+
+	if _, private, ok := getPreviousKeyPaths(); ok {
+		previous, err := readPrivateKey(private)
+		if err != nil {
+			oktetoLog.Infof("ignoring previous key: %s", err)
+		} else {
+			keys = append(keys, previous)
+		}
+	}
+	return keys, nil
+}
+
+func readPrivateKey(private string) (ssh.Signer, error) {
 	buf, err := os.ReadFile(private)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load private key: %s", err)
@@ -69,7 +92,7 @@ func getSSHClientConfig() (*ssh.ClientConfig, error) {
 		return clientConfig, nil
 	}
 
-	keys, err := getPrivateKey()
+	keys, err := getPrivateKeys()
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +103,7 @@ func getSSHClientConfig() (*ssh.ClientConfig, error) {
 		// port-forward tunnel to the kubernetes cluster.
 		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 		Auth: []ssh.AuthMethod{
-			ssh.PublicKeys(keys),
+			ssh.PublicKeys(keys...),
 		},
 		Timeout: getOktetoSSHTimeout(),
 	}
@@ -26,14 +26,17 @@ import (
 	dockerterm "github.com/moby/term"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
-	"github.com/okteto/okteto/pkg/model"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/term"
 )
 
-// Exec executes the command over SSH
-func Exec(ctx context.Context, iface string, remotePort int, tty bool, inR io.Reader, outW, errW io.Writer, command []string) error {
+// agentChannelType is the type of the channels the server opens to reach the forwarded agent
+const agentChannelType = "auth-agent@openssh.com"
+
+// Exec executes the command over SSH. The stdin of the session is not attached when inR is nil.
+// The local SSH agent is forwarded to the session only when forwardAgent is true
+func Exec(ctx context.Context, iface string, remotePort int, tty, forwardAgent bool, inR io.Reader, outW, errW io.Writer, command []string) error {
 	sshConfig, err := getSSHClientConfig()
 	if err != nil {
 		return fmt.Errorf("failed to get SSH configuration: %s", err)
@@ -125,23 +128,17 @@ func Exec(ctx context.Context, iface string, remotePort int, tty bool, inR io.Re
 		}
 	}
 
-	sockEnvVar, ok := os.LookupEnv(model.SshAuthSockEnvVar)
-	if !ok {
-		oktetoLog.Info("SSH_AUTH_SOCK is not set, not forwarding socket")
-	} else {
-		if err := agent.ForwardToRemote(connection, sockEnvVar); err != nil {
-			oktetoLog.Infof("failed to existing SSH_AUTH_SOCK('%s'): %s", sockEnvVar, err)
-		}
-		if err := agent.RequestAgentForwarding(session); err != nil {
-			oktetoLog.Infof("failed to forward ssh agent to remote: %s", err)
-		}
+	if forwardAgent {
+		forwardSSHAgent(connection, session)
 	}
 
-	stdin, err := session.StdinPipe()
-	if err != nil {
-		return fmt.Errorf("unable to setup stdin for session: %v", err)
+	if inR != nil {
+		stdin, err := session.StdinPipe()
+		if err != nil {
+			return fmt.Errorf("unable to setup stdin for session: %v", err)
+		}
+		Copy(inR, stdin)
 	}
-	Copy(inR, stdin)
 
 	stdout, err := session.StdoutPipe()
 	if err != nil {
@@ -186,6 +183,85 @@ More information is available here: https://okteto.com/docs/reference/manifest/#
 	return err
 }
 
+// IsAgentAvailable returns true if there is a local SSH agent to forward to the development containers
+func IsAgentAvailable() bool {
+	return agentAddress() != ""
+}
+
+// forwardSSHAgent forwards the local SSH agent to the session, so the server sets SSH_AUTH_SOCK in the development container.
+// Forwarding isn't requested if the agent can't be reached, to not leave a SSH_AUTH_SOCK that doesn't work in the container
+func forwardSSHAgent(connection *ssh.Client, session *ssh.Session) {
+	address := agentAddress()
+	if address == "" {
+		oktetoLog.Info("SSH agent not found, not forwarding socket")
+		return
+	}
+
+	if err := forwardToAgent(connection, address); err != nil {
+		oktetoLog.Infof("failed to forward SSH agent('%s'): %s", address, err)
+		return
+	}
+	if err := agent.RequestAgentForwarding(session); err != nil {
+		oktetoLog.Infof("failed to forward ssh agent to remote: %s", err)
+	}
+}
+
+// forwardToAgent routes the agent channels opened by the server to the agent of address.
+// It works like agent.ForwardToRemote, which only dials unix sockets
+func forwardToAgent(client *ssh.Client, address string) error {
+	conn, err := dialAgent(address)
+	if err != nil {
+		return err
+	}
+	if err := conn.Close(); err != nil {
+		oktetoLog.Debugf("Error closing agent connection: %s", err)
+	}
+
+	channels := client.HandleChannelOpen(agentChannelType)
+	if channels == nil {
+		return fmt.Errorf("the agent is already forwarded")
+	}
+
+	go func() {
+		for ch := range channels {
+			channel, reqs, err := ch.Accept()
+			if err != nil {
+				oktetoLog.Infof("failed to accept agent channel: %s", err)
+				continue
+			}
+			go ssh.DiscardRequests(reqs)
+			go forwardAgentChannel(channel, address)
+		}
+	}()
+	return nil
+}
+
+func forwardAgentChannel(channel ssh.Channel, address string) {
+	defer channel.Close()
+
+	conn, err := dialAgent(address)
+	if err != nil {
+		oktetoLog.Infof("failed to connect to SSH agent('%s'): %s", address, err)
+		return
+	}
+	defer conn.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		if _, err := io.Copy(conn, channel); err != nil {
+			oktetoLog.Infof("error while writing to SSH agent: %s", err)
+		}
+		done <- struct{}{}
+	}()
+	go func() {
+		if _, err := io.Copy(channel, conn); err != nil {
+			oktetoLog.Infof("error while reading from SSH agent: %s", err)
+		}
+		done <- struct{}{}
+	}()
+	<-done
+}
+
 func isTerminal(r io.Reader) (int, bool) {
 	switch v := r.(type) {
 	case *os.File:
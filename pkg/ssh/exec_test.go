@@ -0,0 +1,171 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gliderlabs/ssh"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// listenAndServeAgent starts a ssh server that writes the number of keys of the forwarded agent
+func listenAndServeAgent(address string) {
+	server := &ssh.Server{
+		Addr: address,
+		Handler: func(s ssh.Session) {
+			if !ssh.AgentRequested(s) {
+				_, _ = io.WriteString(s, "no agent")
+				return
+			}
+
+			l, err := ssh.NewAgentListener()
+			if err != nil {
+				_, _ = io.WriteString(s, err.Error())
+				return
+			}
+			defer l.Close()
+			go ssh.ForwardAgentConnections(l, s)
+
+			conn, err := net.Dial(l.Addr().Network(), l.Addr().String())
+			if err != nil {
+				_, _ = io.WriteString(s, err.Error())
+				return
+			}
+			defer conn.Close()
+
+			keys, err := agent.NewClient(conn).List()
+			if err != nil {
+				_, _ = io.WriteString(s, err.Error())
+				return
+			}
+			_, _ = io.WriteString(s, fmt.Sprintf("%d keys", len(keys)))
+		},
+	}
+
+	if err := server.ListenAndServe(); err != nil {
+		oktetoLog.Fatalf(err.Error())
+	}
+}
+
+// startLocalAgent serves an agent with a key on an Unix socket
+func startLocalAgent(t *testing.T) string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyring := agent.NewKeyring()
+	require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: key}))
+
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	l, err := net.Listen(unixNetwork, path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_ = agent.ServeAgent(keyring, conn)
+			}()
+		}
+	}()
+	return path
+}
+
+func TestExecForwardAgent(t *testing.T) {
+	setUpClientKeys(t)
+	agentSock := startLocalAgent(t)
+
+	port, err := model.GetAvailablePort(model.Localhost)
+	require.NoError(t, err)
+	go listenAndServeAgent(net.JoinHostPort(model.Localhost, strconv.Itoa(port)))
+
+	var tests = []struct {
+		name         string
+		authSock     string
+		forwardAgent bool
+		expected     string
+	}{
+		{
+			name:         "agent",
+			authSock:     agentSock,
+			forwardAgent: true,
+			expected:     "1 keys",
+		},
+		{
+			name:         "agent-not-forwarded",
+			authSock:     agentSock,
+			forwardAgent: false,
+			expected:     "no agent",
+		},
+		{
+			name:         "no-agent",
+			authSock:     "",
+			forwardAgent: true,
+			expected:     "no agent",
+		},
+		{
+			name:         "agent-not-running",
+			authSock:     filepath.Join(t.TempDir(), "missing.sock"),
+			forwardAgent: true,
+			expected:     "no agent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(model.SshAuthSockEnvVar, tt.authSock)
+			out := &lockedBuffer{}
+			require.NoError(t, Exec(context.Background(), model.Localhost, port, false, tt.forwardAgent, nil, out, out, []string{"ssh-add", "-l"}))
+			assert.Eventually(t, func() bool { return out.String() == tt.expected }, 5*time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+// lockedBuffer is a buffer safe to write while its content is read, since the output of Exec is copied in the background
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -28,6 +28,8 @@ import (
 type forward struct {
 	localAddress  string
 	remoteAddress string
+	localNetwork  string
+	remoteNetwork string
 	c             bool
 	lock          sync.Mutex
 	pool          *pool
@@ -52,7 +54,14 @@ func (f *forward) setDisconnected() {
 }
 
 func (f *forward) start(ctx context.Context) {
-	localListener, err := net.Listen("tcp", f.localAddress)
+	if f.getLocalNetwork() == unixNetwork {
+		if err := prepareLocalSocket(f.localAddress); err != nil {
+			oktetoLog.Infof("%s -> failed to create local socket: %s", f.String(), err)
+			return
+		}
+	}
+
+	localListener, err := net.Listen(f.getLocalNetwork(), f.localAddress)
 	if err != nil {
 		oktetoLog.Infof("%s -> failed to listen: %s", f.String(), err)
 		return
@@ -93,7 +102,7 @@ func (f *forward) handle(local net.Conn) {
 		}
 	}()
 
-	remote, err := f.pool.get(f.remoteAddress)
+	remote, err := f.pool.get(f.getRemoteNetwork(), f.remoteAddress)
 	if err != nil {
 		oktetoLog.Infof("%s -> failed to dial remote connection: %s", f.String(), err)
 		return
@@ -112,6 +121,20 @@ func (f *forward) handle(local net.Conn) {
 	<-quit
 }
 
+func (f *forward) getLocalNetwork() string {
+	if f.localNetwork == "" {
+		return tcpNetwork
+	}
+	return f.localNetwork
+}
+
+func (f *forward) getRemoteNetwork() string {
+	if f.remoteNetwork == "" {
+		return tcpNetwork
+	}
+	return f.remoteNetwork
+}
+
 func (f *forward) String() string {
 	return fmt.Sprintf("ssh forward %s->%s", f.localAddress, f.remoteAddress)
 }
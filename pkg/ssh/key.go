@@ -14,9 +14,12 @@
 package ssh
 
 import (
+	"crypto"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/binary"
 	"encoding/pem"
 	"fmt"
 	"os"
@@ -25,18 +28,40 @@ import (
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/filesystem"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
 	"golang.org/x/crypto/ssh"
 )
 
 const (
-	privateKeyFile = "id_rsa_okteto"
-	publicKeyFile  = "id_rsa_okteto.pub"
-	bitSize        = 4096
+	// KeyTypeED25519 is the default type of the keys generated by okteto
+	KeyTypeED25519 = "ed25519"
+
+	// KeyTypeRSA is the type of the keys generated by previous versions of okteto
+	KeyTypeRSA = "rsa"
+
+	rsaPrivateKeyFile     = "id_rsa_okteto"
+	rsaPublicKeyFile      = "id_rsa_okteto.pub"
+	ed25519PrivateKeyFile = "id_ed25519_okteto"
+	ed25519PublicKeyFile  = "id_ed25519_okteto.pub"
+	bitSize               = 4096
+
+	opensshKeyMagic = "openssh-key-v1\x00"
 )
 
+// KeyPair is a SSH key pair encoded to be written to disk
+type KeyPair struct {
+	Type    string
+	Public  []byte
+	Private []byte
+}
+
 // KeyExists returns true if the okteto key pair exists
 func KeyExists() bool {
 	public, private := getKeyPaths()
+	return keyPairExists(public, private)
+}
+
+func keyPairExists(public, private string) bool {
 	if !filesystem.FileExists(public) {
 		oktetoLog.Infof("%s doesn't exist", public)
 		return false
@@ -53,30 +78,115 @@ func KeyExists() bool {
 	return true
 }
 
+// GetKeyType returns the type of the keys generated by okteto, defined by OKTETO_SSH_KEY_TYPE
+func GetKeyType() (string, error) {
+	keyType := os.Getenv(model.OktetoSSHKeyTypeEnvVar)
+	if keyType == "" {
+		return KeyTypeED25519, nil
+	}
+	if err := ValidateKeyType(keyType); err != nil {
+		return "", err
+	}
+	return keyType, nil
+}
+
+// ValidateKeyType returns an error if okteto can't generate keys of the given type
+func ValidateKeyType(keyType string) error {
+	switch keyType {
+	case KeyTypeED25519, KeyTypeRSA:
+		return nil
+	default:
+		return fmt.Errorf("SSH key type '%s' is not supported, must be one of '%s' or '%s'", keyType, KeyTypeED25519, KeyTypeRSA)
+	}
+}
+
 // GenerateKeys generates a SSH key pair on path
 func GenerateKeys() error {
-	publicKeyPath, privateKeyPath := getKeyPaths()
-	return generate(publicKeyPath, privateKeyPath, bitSize)
+	keyType, err := GetKeyType()
+	if err != nil {
+		return err
+	}
+	publicKeyPath, privateKeyPath := getKeyPathsByType(keyType)
+	return generate(publicKeyPath, privateKeyPath, keyType, bitSize)
 }
 
-func generate(public, private string, bitSize int) error {
-	privateKey, err := generatePrivateKey(bitSize)
+func generate(public, private, keyType string, bitSize int) error {
+	kp, err := newKeyPair(keyType, bitSize)
 	if err != nil {
-		return fmt.Errorf("failed to generate private SSH key: %s", err)
+		return err
+	}
+	return kp.write(public, private)
+}
+
+// NewKeyPair generates a SSH key pair of the given type
+func NewKeyPair(keyType string) (*KeyPair, error) {
+	if err := ValidateKeyType(keyType); err != nil {
+		return nil, err
+	}
+	return newKeyPair(keyType, bitSize)
+}
+
+func newKeyPair(keyType string, bitSize int) (*KeyPair, error) {
+	var publicKey crypto.PublicKey
+	var privateKeyBytes []byte
+	switch keyType {
+	case KeyTypeRSA:
+		privateKey, err := generatePrivateKey(bitSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate private SSH key: %s", err)
+		}
+		publicKey = &privateKey.PublicKey
+		privateKeyBytes = encodePrivateKeyToPEM(privateKey)
+	default:
+		pub, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate private SSH key: %s", err)
+		}
+		publicKey = pub
+		privateKeyBytes, err = encodeED25519PrivateKeyToPEM(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode private SSH key: %s", err)
+		}
 	}
 
-	publicKeyBytes, err := generatePublicKey(&privateKey.PublicKey)
+	publicKeyBytes, err := generatePublicKey(publicKey)
 	if err != nil {
-		return fmt.Errorf("failed to generate public SSH key: %s", err)
+		return nil, fmt.Errorf("failed to generate public SSH key: %s", err)
 	}
 
-	privateKeyBytes := encodePrivateKeyToPEM(privateKey)
+	return &KeyPair{Type: keyType, Public: publicKeyBytes, Private: privateKeyBytes}, nil
+}
+
+// Save replaces the okteto key pair by the key pair, removing the previous keys
+func (kp *KeyPair) Save() error {
+	previousPublic, previousPrivate := getKeyPaths()
+	public, private := getKeyPathsByType(kp.Type)
+	if err := kp.write(public, private); err != nil {
+		return err
+	}
+
+	if previousPrivate == private {
+		return nil
+	}
+	for _, p := range []string{previousPublic, previousPrivate} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove previous SSH key: %s", err)
+		}
+	}
+	oktetoLog.Infof("removed previous ssh keypair at %s and %s", previousPublic, previousPrivate)
+
+	if err := updateIdentityFile(getSSHConfigPath(), previousPrivate, private); err != nil {
+		oktetoLog.Infof("failed to update the key of your SSH config file: %s", err)
+	}
+	return nil
+}
 
-	if err := os.WriteFile(public, publicKeyBytes, 0600); err != nil {
+func (kp *KeyPair) write(public, private string) error {
+	if err := os.WriteFile(public, kp.Public, 0600); err != nil {
 		return fmt.Errorf("failed to write public SSH key: %s", err)
 	}
 
-	if err := os.WriteFile(private, privateKeyBytes, 0600); err != nil {
+	if err := os.WriteFile(private, kp.Private, 0600); err != nil {
 		return fmt.Errorf("failed to write private SSH key: %s", err)
 	}
 
@@ -114,22 +224,102 @@ func encodePrivateKeyToPEM(privateKey *rsa.PrivateKey) []byte {
 	return privatePEM
 }
 
-func generatePublicKey(privatekey *rsa.PublicKey) ([]byte, error) {
-	publicRsaKey, err := ssh.NewPublicKey(privatekey)
+// encodeED25519PrivateKeyToPEM encodes the key in the OpenSSH format, the only one supported by ssh for ed25519 keys
+func encodeED25519PrivateKeyToPEM(privateKey ed25519.PrivateKey) ([]byte, error) {
+	publicKey, err := ssh.NewPublicKey(privateKey.Public())
 	if err != nil {
 		return nil, err
 	}
 
-	pubKeyBytes := ssh.MarshalAuthorizedKey(publicRsaKey)
+	var check [4]byte
+	if _, err := rand.Read(check[:]); err != nil {
+		return nil, err
+	}
+	checkInt := binary.BigEndian.Uint32(check[:])
+
+	key := struct {
+		Check1  uint32
+		Check2  uint32
+		Keytype string
+		Pub     []byte
+		Priv    []byte
+		Comment string
+		Pad     []byte `ssh:"rest"`
+	}{
+		Check1:  checkInt,
+		Check2:  checkInt,
+		Keytype: ssh.KeyAlgoED25519,
+		Pub:     privateKey.Public().(ed25519.PublicKey),
+		Priv:    privateKey,
+	}
+
+	// the private section is padded to the block size of the 'none' cipher
+	const blockSize = 8
+	padding := blockSize - len(ssh.Marshal(key))%blockSize
+	for i := 1; i <= padding%blockSize; i++ {
+		key.Pad = append(key.Pad, byte(i))
+	}
+
+	envelope := struct {
+		CipherName   string
+		KdfName      string
+		KdfOpts      string
+		NumKeys      uint32
+		PubKey       []byte
+		PrivKeyBlock []byte
+	}{
+		CipherName:   "none",
+		KdfName:      "none",
+		NumKeys:      1,
+		PubKey:       publicKey.Marshal(),
+		PrivKeyBlock: ssh.Marshal(key),
+	}
+
+	privBlock := pem.Block{
+		Type:  "OPENSSH PRIVATE KEY",
+		Bytes: append([]byte(opensshKeyMagic), ssh.Marshal(envelope)...),
+	}
+	return pem.EncodeToMemory(&privBlock), nil
+}
+
+func generatePublicKey(publicKey crypto.PublicKey) ([]byte, error) {
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeyBytes := ssh.MarshalAuthorizedKey(sshPublicKey)
 
 	return pubKeyBytes, nil
 }
 
+// getKeyPaths returns the paths of the okteto key pair.
+// Existing keys are used, even if they were generated with a different type, to keep them working
 func getKeyPaths() (string, string) {
+	public, private := getKeyPathsByType(KeyTypeED25519)
+	if keyPairExists(public, private) {
+		return public, private
+	}
+
+	public, private = getKeyPathsByType(KeyTypeRSA)
+	if keyPairExists(public, private) {
+		return public, private
+	}
+
+	keyType, err := GetKeyType()
+	if err != nil {
+		oktetoLog.Infof("%s, using '%s' keys", err, KeyTypeED25519)
+		keyType = KeyTypeED25519
+	}
+	return getKeyPathsByType(keyType)
+}
+
+func getKeyPathsByType(keyType string) (string, string) {
 	dir := config.GetOktetoHome()
-	public := filepath.Join(dir, publicKeyFile)
-	private := filepath.Join(dir, privateKeyFile)
-	return public, private
+	if keyType == KeyTypeRSA {
+		return filepath.Join(dir, rsaPublicKeyFile), filepath.Join(dir, rsaPrivateKeyFile)
+	}
+	return filepath.Join(dir, ed25519PublicKeyFile), filepath.Join(dir, ed25519PrivateKeyFile)
 }
 
 // GetPublicKey returns the path to the public key
@@ -137,3 +327,11 @@ func GetPublicKey() string {
 	pub, _ := getKeyPaths()
 	return pub
 }
+
+// ReadPublicKey returns the content of the public key, or nil if the okteto key pair doesn't exist
+func ReadPublicKey() ([]byte, error) {
+	if !KeyExists() {
+		return nil, nil
+	}
+	return os.ReadFile(GetPublicKey())
+}
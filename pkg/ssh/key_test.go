@@ -19,6 +19,10 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestKeyExists(t *testing.T) {
@@ -30,7 +34,7 @@ func TestKeyExists(t *testing.T) {
 		t.Error("keys shouldn't exist in an empty directory")
 	}
 
-	f1, err := os.Create(filepath.Join(dir, publicKeyFile))
+	f1, err := os.Create(filepath.Join(dir, rsaPublicKeyFile))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -44,7 +48,7 @@ func TestKeyExists(t *testing.T) {
 		t.Error("keys shouldn't exist when private key is missing")
 	}
 
-	f2, err := os.Create(filepath.Join(dir, privateKeyFile))
+	f2, err := os.Create(filepath.Join(dir, rsaPrivateKeyFile))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -63,16 +67,72 @@ func Test_generate(t *testing.T) {
 	dir := t.TempDir()
 
 	t.Setenv(constants.OktetoFolderEnvVar, dir)
-	public, private := getKeyPaths()
-	if err := generate(public, private, 128); err != nil {
-		t.Error(err)
-	}
+	for _, keyType := range []string{KeyTypeRSA, KeyTypeED25519} {
+		t.Run(keyType, func(t *testing.T) {
+			t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+			public, private := getKeyPathsByType(keyType)
+			if err := generate(public, private, keyType, 128); err != nil {
+				t.Error(err)
+			}
 
-	if !KeyExists() {
-		t.Error("keys don't exist after creation")
-	}
+			if !KeyExists() {
+				t.Error("keys don't exist after creation")
+			}
+
+			if _, err := getSSHClientConfig(); err != nil {
+				t.Errorf("failed to get ssh client configuration: %s", err)
+			}
 
-	if _, err := getSSHClientConfig(); err != nil {
-		t.Errorf("failed to get ssh client configuration: %s", err)
+			signer, err := getPrivateKey()
+			require.NoError(t, err)
+			publicKey, err := os.ReadFile(public)
+			require.NoError(t, err)
+			assert.Equal(t, string(publicKey), string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+		})
 	}
 }
+
+func TestGenerateKeysDefaultsToED25519(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	t.Setenv(model.OktetoSSHKeyTypeEnvVar, "")
+
+	require.NoError(t, GenerateKeys())
+	public, private := getKeyPaths()
+	assert.Equal(t, ed25519PublicKeyFile, filepath.Base(public))
+	assert.Equal(t, ed25519PrivateKeyFile, filepath.Base(private))
+
+	t.Setenv(model.OktetoSSHKeyTypeEnvVar, "dsa")
+	_, err := GetKeyType()
+	assert.Error(t, err)
+}
+
+func TestKeyPairSave(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(constants.OktetoFolderEnvVar, dir)
+	t.Setenv(constants.OktetoHomeEnvVar, dir)
+
+	rsaPublic, rsaPrivate := getKeyPathsByType(KeyTypeRSA)
+	require.NoError(t, generate(rsaPublic, rsaPrivate, KeyTypeRSA, 128))
+	require.NoError(t, add(getSSHConfigPath(), "api.okteto", "localhost", 2222))
+	assert.Equal(t, rsaPublic, GetPublicKey())
+
+	kp, err := NewKeyPair(KeyTypeED25519)
+	require.NoError(t, err)
+	require.NoError(t, kp.Save())
+
+	public, private := getKeyPathsByType(KeyTypeED25519)
+	assert.Equal(t, public, GetPublicKey())
+	assert.NoFileExists(t, rsaPublic)
+	assert.NoFileExists(t, rsaPrivate)
+
+	b, err := os.ReadFile(public)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Public, b)
+
+	cfg, err := getConfig(getSSHConfigPath())
+	require.NoError(t, err)
+	assert.Equal(t, "\""+private+"\"", cfg.getHost("api.okteto").getParam(identityFile).value())
+
+	_, err = NewKeyPair("dsa")
+	assert.Error(t, err)
+}
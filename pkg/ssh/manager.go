@@ -35,6 +35,9 @@ type ForwardManager struct {
 	forwards        map[int]*forward
 	globalForwards  map[int]*forward
 	reverses        map[int]*reverse
+	socketForwards  map[string]*forward
+	socketReverses  map[string]*reverse
+	proxyPort       int
 	ctx             context.Context
 	sshAddr         string
 	pf              *k8sForward.PortForwardManager
@@ -51,6 +54,8 @@ func NewForwardManager(ctx context.Context, sshAddr, localInterface, remoteInter
 		forwards:        make(map[int]*forward),
 		globalForwards:  make(map[int]*forward),
 		reverses:        make(map[int]*reverse),
+		socketForwards:  make(map[string]*forward),
+		socketReverses:  make(map[string]*reverse),
 		sshAddr:         sshAddr,
 		pf:              pf,
 		namespace:       namespace,
@@ -70,6 +75,10 @@ func (fm *ForwardManager) canAdd(localPort int, checkAvailable bool) error {
 		return fmt.Errorf("port %d is listed multiple times, please check your global forwards configuration", localPort)
 	}
 
+	if fm.proxyPort != 0 && fm.proxyPort == localPort {
+		return fmt.Errorf("port %d is listed multiple times, please check your proxy configuration", localPort)
+	}
+
 	if !checkAvailable {
 		return nil
 	}
@@ -96,6 +105,9 @@ func (fm *ForwardManager) canAdd(localPort int, checkAvailable bool) error {
 
 // Add initializes a remote forward
 func (fm *ForwardManager) Add(f forwardModel.Forward) error {
+	if f.LocalSocket != "" {
+		return fm.addSocketForward(f)
+	}
 
 	forwardsToUpdate := fm.forwards
 	if f.IsGlobal {
@@ -108,7 +120,8 @@ func (fm *ForwardManager) Add(f forwardModel.Forward) error {
 
 	forwardsToUpdate[f.Local] = &forward{
 		localAddress:  net.JoinHostPort(fm.localInterface, strconv.Itoa(f.Local)),
-		remoteAddress: net.JoinHostPort(fm.remoteInterface, strconv.Itoa(f.Remote)),
+		remoteAddress: fm.getRemoteAddress(f.Remote, f.RemoteSocket),
+		remoteNetwork: getNetwork(f.RemoteSocket),
 	}
 
 	if f.Service {
@@ -167,6 +180,12 @@ func (fm *ForwardManager) Start(devPod, namespace string) error {
 
 	}
 
+	// the Unix socket support is checked before starting any forward, so the manager isn't left half-started
+	if err := fm.prepareSockets(); err != nil {
+		fm.Stop()
+		return err
+	}
+
 	for _, ff := range fm.forwards {
 		ff.pool = fm.pool
 		go ff.start(fm.ctx)
@@ -177,6 +196,20 @@ func (fm *ForwardManager) Start(devPod, namespace string) error {
 		go rt.start(fm.ctx)
 	}
 
+	for _, sf := range fm.socketForwards {
+		sf.pool = fm.pool
+		go sf.start(fm.ctx)
+	}
+
+	for _, sr := range fm.socketReverses {
+		sr.pool = fm.pool
+		go sr.start(fm.ctx)
+	}
+
+	if fm.proxyPort != 0 {
+		go fm.startProxy()
+	}
+
 	return nil
 }
 
@@ -191,6 +224,8 @@ func (fm *ForwardManager) Stop() {
 		fm.pf.Stop()
 	}
 
+	fm.removeLocalSockets()
+
 	oktetoLog.Info("stopped SSH forward manager")
 }
 
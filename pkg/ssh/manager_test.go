@@ -25,11 +25,19 @@ import (
 	"time"
 
 	"github.com/gliderlabs/ssh"
+	"github.com/okteto/okteto/pkg/constants"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	forwardModel "github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/require"
 )
 
+// setUpClientKeys generates the ssh keys of the client in a temporary okteto folder
+func setUpClientKeys(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	require.NoError(t, GenerateKeys())
+}
+
 type testHTTPHandler struct {
 	message string
 }
@@ -26,6 +26,9 @@ import (
 
 const (
 	defaultRetries = 5
+
+	tcpNetwork  = "tcp"
+	unixNetwork = "unix"
 )
 
 type pool struct {
@@ -98,13 +101,13 @@ func (p *pool) keepAlive(ctx context.Context) {
 	}
 }
 
-func (p *pool) get(address string) (net.Conn, error) {
-	c, err := p.client.Dial("tcp", address)
+func (p *pool) get(network, address string) (net.Conn, error) {
+	c, err := p.client.Dial(network, address)
 	return c, err
 }
 
-func (p *pool) getListener(address string) (net.Listener, error) {
-	l, err := p.client.Listen("tcp", address)
+func (p *pool) getListener(network, address string) (net.Listener, error) {
+	l, err := p.client.Listen(network, address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start ssh listener on %s: %w", address, err)
 	}
@@ -113,7 +116,7 @@ func (p *pool) getListener(address string) (net.Listener, error) {
 }
 
 func getTCPConnection(ctx context.Context, serverAddr string, keepAlive time.Duration) (net.Conn, error) {
-	c, err := getConn(ctx, serverAddr, defaultRetries)
+	c, err := getConn(ctx, tcpNetwork, serverAddr, defaultRetries)
 	if err != nil {
 		return nil, err
 	}
@@ -129,12 +132,12 @@ func getTCPConnection(ctx context.Context, serverAddr string, keepAlive time.Dur
 	return c, nil
 }
 
-func getConn(ctx context.Context, serverAddr string, retries int) (net.Conn, error) {
+func getConn(ctx context.Context, network, serverAddr string, retries int) (net.Conn, error) {
 	var lastErr error
 	t := time.NewTicker(100 * time.Millisecond)
 	for i := 0; i < retries; i++ {
 		d := net.Dialer{}
-		c, err := d.DialContext(ctx, "tcp", serverAddr)
+		c, err := d.DialContext(ctx, network, serverAddr)
 		if err == nil {
 			return c, nil
 		}
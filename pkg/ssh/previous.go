@@ -0,0 +1,207 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/k8s/secrets"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// previousKeySuffix is the suffix of the key pair replaced by a new key pair while it's still authorized in some namespaces
+	previousKeySuffix = ".previous"
+
+	previousKeyNamespacesFile = "okteto_previous_key_namespaces"
+)
+
+// SaveKeepingPrevious replaces the okteto key pair by the key pair, keeping the previous keys
+// until the development containers of the namespaces authorize the new key pair.
+// The modification time of the previous keys is the time of the rotation
+func (kp *KeyPair) SaveKeepingPrevious(namespaces []string) error {
+	previousPublic, previousPrivate := getKeyPaths()
+	now := time.Now()
+	for _, p := range []string{previousPublic, previousPrivate} {
+		if err := os.Rename(p, p+previousKeySuffix); err != nil {
+			return fmt.Errorf("failed to keep previous SSH key: %s", err)
+		}
+		if err := os.Chtimes(p+previousKeySuffix, now, now); err != nil {
+			return fmt.Errorf("failed to keep previous SSH key: %s", err)
+		}
+	}
+	oktetoLog.Infof("kept previous ssh keypair at %s and %s", previousPublic+previousKeySuffix, previousPrivate+previousKeySuffix)
+
+	if err := writePreviousKeyNamespaces(namespaces); err != nil {
+		return err
+	}
+
+	public, private := getKeyPathsByType(kp.Type)
+	if err := kp.write(public, private); err != nil {
+		return err
+	}
+
+	if previousPrivate == private {
+		return nil
+	}
+	if err := updateIdentityFile(getSSHConfigPath(), previousPrivate, private); err != nil {
+		oktetoLog.Infof("failed to update the key of your SSH config file: %s", err)
+	}
+	return nil
+}
+
+// PreviousKeyNamespaces returns the namespaces with development containers still using the previous key pair
+func PreviousKeyNamespaces() ([]string, error) {
+	if _, _, ok := getPreviousKeyPaths(); !ok {
+		return nil, nil
+	}
+
+	b, err := os.ReadFile(getPreviousKeyNamespacesPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the namespaces of the previous SSH key: %s", err)
+	}
+	return strings.Fields(string(b)), nil
+}
+
+// ReplacePreviousKey authorizes the okteto key pair in the development containers of the namespace
+// still using the previous key pair, removing the previous keys once no namespace uses them.
+// The running development containers copy their authorized keys when they start, so the namespace
+// keeps using the previous key pair until all of them have restarted after the rotation
+func ReplacePreviousKey(ctx context.Context, namespace string, c kubernetes.Interface) error {
+	namespaces, err := PreviousKeyNamespaces()
+	if err != nil {
+		return err
+	}
+
+	pending := []string{}
+	for _, ns := range namespaces {
+		if ns != namespace {
+			pending = append(pending, ns)
+		}
+	}
+	if len(pending) == len(namespaces) {
+		return nil
+	}
+
+	previousPublic, _, _ := getPreviousKeyPaths()
+	previous, err := os.ReadFile(previousPublic)
+	if err != nil {
+		return fmt.Errorf("failed to read previous SSH key: %s", err)
+	}
+	current, err := ReadPublicKey()
+	if err != nil {
+		return err
+	}
+
+	if _, err := secrets.UpdateAuthorizedKey(ctx, namespace, previous, current, c); err != nil {
+		return err
+	}
+
+	rotation, err := getPreviousKeyRotationTime()
+	if err != nil {
+		return err
+	}
+	running, err := HasDevPodsStartedBefore(ctx, namespace, rotation, c)
+	if err != nil {
+		return err
+	}
+	if running {
+		oktetoLog.Infof("namespace '%s' has development containers started before the rotation of the ssh keys", namespace)
+		return nil
+	}
+
+	if len(pending) > 0 {
+		return writePreviousKeyNamespaces(pending)
+	}
+	return removePreviousKey()
+}
+
+// HasDevPodsStartedBefore returns true if some development container of the namespace started before t,
+// so it still authorizes the key of its secret at that time
+func HasDevPodsStartedBefore(ctx context.Context, namespace string, t time.Time, c kubernetes.Interface) (bool, error) {
+	for _, label := range []string{model.InteractiveDevLabel, model.DetachedDevLabel} {
+		pList, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: label})
+		if err != nil {
+			return false, fmt.Errorf("failed to list the development containers of namespace '%s': %w", namespace, err)
+		}
+		for i := range pList.Items {
+			pod := &pList.Items[i]
+			if pod.DeletionTimestamp == nil && pod.CreationTimestamp.Time.Before(t) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// getPreviousKeyRotationTime returns the time when the previous key pair was replaced
+func getPreviousKeyRotationTime() (time.Time, error) {
+	_, previousPrivate, _ := getPreviousKeyPaths()
+	info, err := os.Stat(previousPrivate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read previous SSH key: %s", err)
+	}
+	return info.ModTime(), nil
+}
+
+func removePreviousKey() error {
+	previousPublic, previousPrivate, _ := getPreviousKeyPaths()
+	for _, p := range []string{previousPublic, previousPrivate, getPreviousKeyNamespacesPath()} {
+		if p == "" {
+			continue
+		}
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove previous SSH key: %s", err)
+		}
+	}
+	oktetoLog.Infof("removed previous ssh keypair at %s and %s", previousPublic, previousPrivate)
+	return nil
+}
+
+func writePreviousKeyNamespaces(namespaces []string) error {
+	content := strings.Join(namespaces, "\n") + "\n"
+	if err := os.WriteFile(getPreviousKeyNamespacesPath(), []byte(content), 0600); err != nil {
+		return fmt.Errorf("failed to write the namespaces of the previous SSH key: %s", err)
+	}
+	return nil
+}
+
+// getPreviousKeyPaths returns the paths of the previous key pair, if it exists
+func getPreviousKeyPaths() (string, string, bool) {
+	for _, keyType := range []string{KeyTypeED25519, KeyTypeRSA} {
+		public, private := getKeyPathsByType(keyType)
+		public, private = public+previousKeySuffix, private+previousKeySuffix
+		if filesystem.FileExists(public) && filesystem.FileExists(private) {
+			return public, private, true
+		}
+	}
+	return "", "", false
+}
+
+func getPreviousKeyNamespacesPath() string {
+	return filepath.Join(config.GetOktetoHome(), previousKeyNamespacesFile)
+}
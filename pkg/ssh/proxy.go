@@ -0,0 +1,51 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/proxy"
+)
+
+// poolDialer opens the connections of the proxy from the development container
+type poolDialer struct {
+	pool *pool
+}
+
+func (d *poolDialer) DialContext(_ context.Context, network, address string) (net.Conn, error) {
+	return d.pool.get(network, address)
+}
+
+// AddProxy initializes a SOCKS5 and HTTP proxy that reaches the cluster network through the development container
+func (fm *ForwardManager) AddProxy(localPort int) error {
+	if err := fm.canAdd(localPort, true); err != nil {
+		return err
+	}
+
+	fm.proxyPort = localPort
+	return nil
+}
+
+func (fm *ForwardManager) startProxy() {
+	address := net.JoinHostPort(fm.localInterface, strconv.Itoa(fm.proxyPort))
+	p := proxy.New(address, &poolDialer{pool: fm.pool})
+	oktetoLog.Infof("ssh proxy listening on %s", address)
+	if err := p.ListenAndServe(fm.ctx); err != nil {
+		oktetoLog.Infof("ssh proxy %s -> failed to listen: %s", address, err)
+	}
+}
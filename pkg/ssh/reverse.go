@@ -27,10 +27,16 @@ import (
 
 type reverse struct {
 	forward
+
+	// listener is the remote listener opened before starting the reverse, if any
+	listener net.Listener
 }
 
 // AddReverse adds a reverse forward
 func (fm *ForwardManager) AddReverse(f model.Reverse) error {
+	if f.LocalSocket != "" {
+		return fm.addSocketReverse(f)
+	}
 
 	if err := fm.canAdd(f.Local, false); err != nil {
 		return err
@@ -39,7 +45,8 @@ func (fm *ForwardManager) AddReverse(f model.Reverse) error {
 	fm.reverses[f.Local] = &reverse{
 		forward: forward{
 			localAddress:  net.JoinHostPort(fm.localInterface, strconv.Itoa(f.Local)),
-			remoteAddress: net.JoinHostPort(fm.remoteInterface, strconv.Itoa(f.Remote)),
+			remoteAddress: fm.getRemoteAddress(f.Remote, f.RemoteSocket),
+			remoteNetwork: getNetwork(f.RemoteSocket),
 		},
 	}
 
@@ -47,10 +54,15 @@ func (fm *ForwardManager) AddReverse(f model.Reverse) error {
 }
 
 func (r *reverse) start(ctx context.Context) {
-	remoteListener, err := r.pool.getListener(r.remoteAddress)
-	if err != nil {
-		oktetoLog.Infof("%s -> failed to listen on remote address: %v", r.String(), err)
-		return
+	remoteListener := r.listener
+	r.listener = nil
+	if remoteListener == nil {
+		var err error
+		remoteListener, err = r.pool.getListener(r.getRemoteNetwork(), r.remoteAddress)
+		if err != nil {
+			oktetoLog.Infof("%s -> failed to listen on remote address: %v", r.String(), err)
+			return
+		}
 	}
 	defer func() {
 		if err := remoteListener.Close(); err != nil {
@@ -87,6 +99,17 @@ func (r *reverse) start(ctx context.Context) {
 	}
 }
 
+// closeListener closes the remote listener opened before starting the reverse
+func (r *reverse) closeListener() {
+	if r.listener == nil {
+		return
+	}
+	if err := r.listener.Close(); err != nil {
+		oktetoLog.Debugf("Error closing remote listener '%s': %s", r.String(), err)
+	}
+	r.listener = nil
+}
+
 func (r *reverse) handle(ctx context.Context, remote net.Conn) {
 	defer func() {
 		if err := remote.Close(); err != nil {
@@ -95,7 +118,7 @@ func (r *reverse) handle(ctx context.Context, remote net.Conn) {
 	}()
 
 	quit := make(chan struct{}, 1)
-	local, err := getConn(ctx, r.localAddress, defaultRetries)
+	local, err := getConn(ctx, r.getLocalNetwork(), r.localAddress, defaultRetries)
 	if err != nil {
 		oktetoLog.Infof("%s -> failed to listen on local address: %v", r.String(), err)
 		return
@@ -0,0 +1,95 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/alessio/shellescape"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"golang.org/x/crypto/ssh"
+)
+
+// Session runs non-interactive commands reusing a single connection to the SSH server of the development container
+type Session struct {
+	connection *ssh.Client
+}
+
+// NewSession connects to the SSH server of the development container
+func NewSession(ctx context.Context, iface string, remotePort int) (*Session, error) {
+	sshConfig, err := getSSHClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SSH configuration: %s", err)
+	}
+
+	var connection *ssh.Client
+	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
+	for i := 0; i < 100; i++ {
+		connection, err = dial(ctx, "tcp", net.JoinHostPort(iface, fmt.Sprintf("%d", remotePort)), sshConfig)
+		if err == nil {
+			break
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SSH server: %s", err)
+	}
+	return &Session{connection: connection}, nil
+}
+
+// Run executes the command in a new channel of the connection. The stdin of the command is closed after reading all the content of stdin
+func (s *Session) Run(stdin io.Reader, stdout io.Writer, command []string) error {
+	session, err := s.connection.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to create SSH session: %s", err)
+	}
+	defer func() {
+		if err := session.Close(); err != nil && err != io.EOF {
+			oktetoLog.Debugf("Error closing session: %s", err)
+		}
+	}()
+
+	var stderr bytes.Buffer
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = &stderr
+
+	cmd := shellescape.QuoteCommand(command)
+	oktetoLog.Debugf("running command over ssh: '%s'", cmd)
+	if err := session.Run(cmd); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection to the SSH server
+func (s *Session) Close() error {
+	return s.connection.Close()
+}
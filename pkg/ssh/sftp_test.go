@@ -0,0 +1,73 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gliderlabs/ssh"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listenAndServeSFTP starts a ssh server with the sftp subsystem
+func listenAndServeSFTP(address string) {
+	server := &ssh.Server{
+		Addr: address,
+		SubsystemHandlers: map[string]ssh.SubsystemHandler{
+			"sftp": func(s ssh.Session) {
+				server, err := sftp.NewServer(s)
+				if err != nil {
+					return
+				}
+				_ = server.Serve()
+			},
+		},
+	}
+
+	if err := server.ListenAndServe(); err != nil {
+		oktetoLog.Fatalf(err.Error())
+	}
+}
+
+func TestNewSFTPClient(t *testing.T) {
+	setUpClientKeys(t)
+
+	port, err := model.GetAvailablePort(model.Localhost)
+	require.NoError(t, err)
+	go listenAndServeSFTP(net.JoinHostPort(model.Localhost, strconv.Itoa(port)))
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0600))
+
+	var client *SFTPClient
+	require.Eventually(t, func() bool {
+		client, err = NewSFTPClient(context.Background(), model.Localhost, port)
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+
+	info, err := client.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), info.Size())
+	assert.NoError(t, client.Close())
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	forwardModel "github.com/okteto/okteto/pkg/model/forward"
+	"golang.org/x/crypto/ssh"
+)
+
+// errSocketsNotSupported is returned when the okteto remote server of the development container doesn't handle the channels to Unix sockets
+var errSocketsNotSupported = oktetoErrors.UserError{
+	E:    fmt.Errorf("the SSH server of your development container doesn't support forwards to Unix sockets"),
+	Hint: "Remove the forwards and reverses to remote Unix sockets from your okteto manifest",
+}
+
+// getNetwork returns the network of a side of a forward
+func getNetwork(socket string) string {
+	if socket != "" {
+		return unixNetwork
+	}
+	return tcpNetwork
+}
+
+// getRemoteAddress returns the address of the remote side of a forward, which is a port or an Unix socket
+func (fm *ForwardManager) getRemoteAddress(port int, socket string) string {
+	if socket != "" {
+		return socket
+	}
+	return net.JoinHostPort(fm.remoteInterface, strconv.Itoa(port))
+}
+
+// addSocketForward initializes a forward listening on a local Unix socket
+func (fm *ForwardManager) addSocketForward(f forwardModel.Forward) error {
+	if f.IsGlobal {
+		return fmt.Errorf("global forwards don't support Unix sockets")
+	}
+
+	if err := fm.canAddSocket(f.LocalSocket, true); err != nil {
+		return err
+	}
+
+	fm.socketForwards[f.LocalSocket] = &forward{
+		localAddress:  f.LocalSocket,
+		localNetwork:  unixNetwork,
+		remoteAddress: fm.getRemoteAddress(f.Remote, f.RemoteSocket),
+		remoteNetwork: getNetwork(f.RemoteSocket),
+	}
+	return nil
+}
+
+// addSocketReverse initializes a reverse forward connecting to a local Unix socket
+func (fm *ForwardManager) addSocketReverse(r model.Reverse) error {
+	if err := fm.canAddSocket(r.LocalSocket, false); err != nil {
+		return err
+	}
+
+	fm.socketReverses[r.LocalSocket] = &reverse{
+		forward: forward{
+			localAddress:  r.LocalSocket,
+			localNetwork:  unixNetwork,
+			remoteAddress: fm.getRemoteAddress(r.Remote, r.RemoteSocket),
+			remoteNetwork: getNetwork(r.RemoteSocket),
+		},
+	}
+	return nil
+}
+
+func (fm *ForwardManager) canAddSocket(path string, checkAvailable bool) error {
+	if _, ok := fm.socketReverses[path]; ok {
+		return fmt.Errorf("socket '%s' is listed multiple times, please check your reverse forwards configuration", path)
+	}
+
+	if _, ok := fm.socketForwards[path]; ok {
+		return fmt.Errorf("socket '%s' is listed multiple times, please check your forwards configuration", path)
+	}
+
+	if !checkAvailable {
+		return nil
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to check local socket '%s': %w", path, err)
+	}
+
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("local socket '%s' can't be created: the file already exists", path)
+	}
+
+	if c, err := net.DialTimeout(unixNetwork, path, time.Second); err == nil {
+		if err := c.Close(); err != nil {
+			oktetoLog.Debugf("Error closing connection to '%s': %s", path, err)
+		}
+		return fmt.Errorf("local socket '%s' is already in-use in your local machine: %w", path, oktetoErrors.ErrPortAlreadyAllocated)
+	}
+
+	return nil
+}
+
+// prepareSockets fails if the server of the development container doesn't support the forwards and reverses of remote Unix sockets.
+// It listens on the remote side of the reverses, closing the remote listeners already opened if any of them fails
+func (fm *ForwardManager) prepareSockets() error {
+	for _, f := range fm.getRemoteSocketForwards() {
+		if err := fm.pool.checkStreamLocal(f.remoteAddress); err != nil {
+			return err
+		}
+	}
+
+	listening := []*reverse{}
+	for _, r := range fm.getRemoteSocketReverses() {
+		l, err := fm.pool.getListener(unixNetwork, r.remoteAddress)
+		if err != nil {
+			for _, lr := range listening {
+				lr.closeListener()
+			}
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("%s -> failed to listen on remote socket: %w", r.String(), err),
+				Hint: "Check that the SSH server of your development container supports Unix sockets and that the remote socket is not in use",
+			}
+		}
+		r.listener = l
+		listening = append(listening, r)
+	}
+	return nil
+}
+
+func (fm *ForwardManager) getRemoteSocketForwards() []*forward {
+	result := []*forward{}
+	for _, f := range fm.forwards {
+		if f.getRemoteNetwork() == unixNetwork {
+			result = append(result, f)
+		}
+	}
+	for _, f := range fm.socketForwards {
+		if f.getRemoteNetwork() == unixNetwork {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func (fm *ForwardManager) getRemoteSocketReverses() []*reverse {
+	result := []*reverse{}
+	for _, r := range fm.reverses {
+		if r.getRemoteNetwork() == unixNetwork {
+			result = append(result, r)
+		}
+	}
+	for _, r := range fm.socketReverses {
+		if r.getRemoteNetwork() == unixNetwork {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// checkStreamLocal opens and closes a connection to a remote Unix socket, to fail before listening if the server doesn't support Unix sockets.
+// Other errors are ignored, the process of the development container might not have created the socket yet
+func (p *pool) checkStreamLocal(address string) error {
+	c, err := p.get(unixNetwork, address)
+	if err != nil {
+		var openErr *ssh.OpenChannelError
+		if errors.As(err, &openErr) && (openErr.Reason == ssh.UnknownChannelType || openErr.Reason == ssh.Prohibited) {
+			return errSocketsNotSupported
+		}
+		oktetoLog.Infof("failed to connect to remote socket '%s': %s", address, err)
+		return nil
+	}
+	if err := c.Close(); err != nil {
+		oktetoLog.Debugf("Error closing connection to remote socket '%s': %s", address, err)
+	}
+	return nil
+}
+
+// prepareLocalSocket creates the folder of the socket and removes the socket left by a previous execution
+func prepareLocalSocket(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("'%s' already exists and it is not a socket", path)
+	}
+
+	return os.Remove(path)
+}
+
+// removeLocalSockets deletes the sockets created by the forwards
+func (fm *ForwardManager) removeLocalSockets() {
+	for path := range fm.socketForwards {
+		info, err := os.Lstat(path)
+		if err != nil || info.Mode()&os.ModeSocket == 0 {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			oktetoLog.Infof("failed to remove local socket '%s': %s", path, err)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gliderlabs/ssh"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	forwardModel "github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// listenAndServeStreamLocal starts a ssh server supporting the direct-streamlocal channels and the streamlocal-forward requests
+func listenAndServeStreamLocal(address string) {
+	server := &ssh.Server{
+		Addr: address,
+		ChannelHandlers: map[string]ssh.ChannelHandler{
+			"direct-streamlocal@openssh.com": handleTestDirectStreamLocal,
+		},
+		RequestHandlers: map[string]ssh.RequestHandler{
+			"streamlocal-forward@openssh.com": handleTestStreamLocalForward,
+		},
+	}
+
+	if err := server.ListenAndServe(); err != nil {
+		oktetoLog.Fatalf(err.Error())
+	}
+}
+
+func handleTestDirectStreamLocal(_ *ssh.Server, _ *gossh.ServerConn, newChan gossh.NewChannel, _ ssh.Context) {
+	var msg struct {
+		SocketPath string
+		Reserved0  string
+		Reserved1  uint32
+	}
+	if err := gossh.Unmarshal(newChan.ExtraData(), &msg); err != nil {
+		_ = newChan.Reject(gossh.ConnectionFailed, err.Error())
+		return
+	}
+	conn, err := net.Dial(unixNetwork, msg.SocketPath)
+	if err != nil {
+		_ = newChan.Reject(gossh.ConnectionFailed, err.Error())
+		return
+	}
+	ch, reqs, err := newChan.Accept()
+	if err != nil {
+		return
+	}
+	go gossh.DiscardRequests(reqs)
+	go func() {
+		_, _ = io.Copy(ch, conn)
+		_ = ch.Close()
+	}()
+	_, _ = io.Copy(conn, ch)
+	_ = conn.Close()
+}
+
+func handleTestStreamLocalForward(ctx ssh.Context, _ *ssh.Server, req *gossh.Request) (bool, []byte) {
+	var msg struct {
+		SocketPath string
+	}
+	if err := gossh.Unmarshal(req.Payload, &msg); err != nil {
+		return false, nil
+	}
+	l, err := net.Listen(unixNetwork, msg.SocketPath)
+	if err != nil {
+		return false, nil
+	}
+	conn := ctx.Value(ssh.ContextKeyConn).(*gossh.ServerConn)
+	go func() {
+		<-ctx.Done()
+		_ = l.Close()
+	}()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			payload := gossh.Marshal(&struct {
+				SocketPath string
+				Reserved   string
+			}{SocketPath: msg.SocketPath})
+			ch, reqs, err := conn.OpenChannel("forwarded-streamlocal@openssh.com", payload)
+			if err != nil {
+				_ = c.Close()
+				continue
+			}
+			go gossh.DiscardRequests(reqs)
+			go func() {
+				_, _ = io.Copy(ch, c)
+				_ = ch.Close()
+			}()
+			go func() {
+				_, _ = io.Copy(c, ch)
+				_ = c.Close()
+			}()
+		}
+	}()
+	return true, nil
+}
+
+// startUnixEchoServer starts a server on an Unix socket sending back every line
+func startUnixEchoServer(t *testing.T, path string) {
+	l, err := net.Listen(unixNetwork, path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				_, _ = io.Copy(c, c)
+			}()
+		}
+	}()
+}
+
+func TestSocketForward(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	setUpClientKeys(t)
+	dir := t.TempDir()
+	port, err := model.GetAvailablePort(model.Localhost)
+	require.NoError(t, err)
+	sshAddr := net.JoinHostPort(model.Localhost, strconv.Itoa(port))
+	go listenAndServeStreamLocal(sshAddr)
+
+	remote := filepath.Join(dir, "remote.sock")
+	local := filepath.Join(dir, "run", "local.sock")
+	startUnixEchoServer(t, remote)
+
+	fm := NewForwardManager(ctx, sshAddr, model.Localhost, model.Localhost, nil, "")
+	require.NoError(t, fm.Add(forwardModel.Forward{LocalSocket: local, RemoteSocket: remote}))
+	assert.Error(t, fm.Add(forwardModel.Forward{LocalSocket: local, RemoteSocket: remote}))
+	require.NoError(t, fm.Start("", ""))
+
+	var conn net.Conn
+	require.Eventually(t, func() bool {
+		conn, err = net.Dial(unixNetwork, local)
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	_, err = conn.Write([]byte("ping\n"))
+	require.NoError(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "ping\n", line)
+	require.NoError(t, conn.Close())
+
+	cancel()
+	fm.Stop()
+	assert.Eventually(t, func() bool {
+		_, err := os.Lstat(local)
+		return os.IsNotExist(err)
+	}, 2*time.Second, 50*time.Millisecond, "local socket was not removed")
+}
+
+func TestSocketReverse(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	setUpClientKeys(t)
+	dir := t.TempDir()
+	port, err := model.GetAvailablePort(model.Localhost)
+	require.NoError(t, err)
+	sshAddr := net.JoinHostPort(model.Localhost, strconv.Itoa(port))
+	go listenAndServeStreamLocal(sshAddr)
+
+	local := filepath.Join(dir, "local.sock")
+	remote := filepath.Join(dir, "remote.sock")
+	startUnixEchoServer(t, local)
+
+	fm := NewForwardManager(ctx, sshAddr, model.Localhost, model.Localhost, nil, "")
+	require.NoError(t, fm.AddReverse(model.Reverse{LocalSocket: local, RemoteSocket: remote}))
+	require.NoError(t, fm.Start("", ""))
+	defer fm.Stop()
+
+	var conn net.Conn
+	require.Eventually(t, func() bool {
+		conn, err = net.Dial(unixNetwork, remote)
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	_, err = conn.Write([]byte("ping\n"))
+	require.NoError(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "ping\n", line)
+	require.NoError(t, conn.Close())
+}
+
+func TestSocketsNotSupported(t *testing.T) {
+	setUpClientKeys(t)
+	dir := t.TempDir()
+	port, err := model.GetAvailablePort(model.Localhost)
+	require.NoError(t, err)
+	sshAddr := net.JoinHostPort(model.Localhost, strconv.Itoa(port))
+	// a ssh server without the streamlocal channels and requests
+	go func() {
+		server := &ssh.Server{Addr: sshAddr}
+		if err := server.ListenAndServe(); err != nil {
+			oktetoLog.Fatalf(err.Error())
+		}
+	}()
+
+	t.Run("forward", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		fm := NewForwardManager(ctx, sshAddr, model.Localhost, model.Localhost, nil, "")
+		local := filepath.Join(dir, "local.sock")
+		require.NoError(t, fm.Add(forwardModel.Forward{LocalSocket: local, RemoteSocket: "/var/run/docker.sock"}))
+		assert.ErrorIs(t, fm.Start("", ""), errSocketsNotSupported)
+		fm.Stop()
+
+		// the forward was never started
+		_, err := os.Lstat(local)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		fm := NewForwardManager(ctx, sshAddr, model.Localhost, model.Localhost, nil, "")
+		require.NoError(t, fm.AddReverse(model.Reverse{Local: 8080, RemoteSocket: "/tmp/app.sock"}))
+		err := fm.Start("", "")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+		fm.Stop()
+	})
+}
+
+func TestAddSocket(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, []byte("content"), 0600))
+	inUse := filepath.Join(dir, "in-use.sock")
+	startUnixEchoServer(t, inUse)
+
+	fm := NewForwardManager(context.Background(), "localhost:22", model.Localhost, "0.0.0.0", nil, "")
+	assert.Error(t, fm.Add(forwardModel.Forward{LocalSocket: file, Remote: 5432}))
+	assert.Error(t, fm.Add(forwardModel.Forward{LocalSocket: inUse, Remote: 5432}))
+	assert.Error(t, fm.Add(forwardModel.Forward{LocalSocket: filepath.Join(dir, "global.sock"), Remote: 5432, IsGlobal: true}))
+
+	require.NoError(t, fm.Add(forwardModel.Forward{Local: 2375, RemoteSocket: "/var/run/docker.sock"}))
+	assert.Equal(t, "/var/run/docker.sock", fm.forwards[2375].remoteAddress)
+	assert.Equal(t, unixNetwork, fm.forwards[2375].getRemoteNetwork())
+
+	require.NoError(t, fm.AddReverse(model.Reverse{RemoteSocket: "/tmp/agent.sock", LocalSocket: inUse}))
+	assert.Equal(t, inUse, fm.socketReverses[inUse].localAddress)
+	assert.Equal(t, unixNetwork, fm.socketReverses[inUse].getLocalNetwork())
+	assert.Equal(t, "/tmp/agent.sock", fm.socketReverses[inUse].remoteAddress)
+	assert.Error(t, fm.AddReverse(model.Reverse{Remote: 8080, LocalSocket: inUse}))
+
+	require.NoError(t, fm.AddReverse(model.Reverse{RemoteSocket: "/tmp/app.sock", Local: 8080}))
+	assert.Equal(t, "localhost:8080", fm.reverses[8080].localAddress)
+	assert.Equal(t, unixNetwork, fm.reverses[8080].getRemoteNetwork())
+}
@@ -18,6 +18,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -59,6 +60,29 @@ func add(path, name, iface string, port int) error {
 	return save(cfg, path)
 }
 
+// updateIdentityFile replaces the private key of the entries generated by okteto
+func updateIdentityFile(path, previous, current string) error {
+	cfg, err := getConfig(path)
+	if err != nil {
+		return err
+	}
+
+	updated := false
+	for _, h := range cfg.hosts {
+		p := h.getParam(identityFile)
+		if p == nil || strings.Trim(p.value(), "\"") != previous {
+			continue
+		}
+		p.args = []string{"\"" + current + "\""}
+		updated = true
+	}
+
+	if !updated {
+		return nil
+	}
+	return save(cfg, path)
+}
+
 // RemoveEntry removes the entry to the user's sshconfig if found
 func RemoveEntry(name string) error {
 	return remove(getSSHConfigPath(), buildHostname(name))
@@ -0,0 +1,277 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshsync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/spf13/afero"
+)
+
+// uploadBatchSize is the number of files sent on each tar stream during the initial synchronization
+const uploadBatchSize = 100
+
+// runner runs commands in the development container
+type runner interface {
+	Run(stdin io.Reader, stdout io.Writer, command []string) error
+	Close() error
+}
+
+// Engine synchronizes the local folders with the development container over its SSH server.
+// Only the local changes are sent: files are compared by their sha256 hash during the initial synchronization,
+// and then the local folders are watched for changes. Files deleted in the remote folders are not restored
+type Engine struct {
+	iface      string
+	remotePort int
+	fs         afero.Fs
+	folders    []*folder
+	runner     runner
+
+	mu                    sync.Mutex
+	inSynchronizationFile string
+	subscribers           []chan<- syncthing.SyncedItems
+}
+
+type folder struct {
+	localPath  string
+	remotePath string
+	ignore     *ignoreList
+}
+
+// New returns a ssh sync engine for the development container
+func New(dev *model.Dev, fs afero.Fs) *Engine {
+	e := &Engine{
+		iface:      dev.Interface,
+		remotePort: dev.RemotePort,
+		fs:         fs,
+	}
+	for _, f := range dev.Sync.Folders {
+		e.folders = append(e.folders, &folder{localPath: f.LocalPath, remotePath: f.RemotePath})
+	}
+	return e
+}
+
+// Start connects to the SSH server of the development container and loads the '.stignore' files of the folders
+func (e *Engine) Start(ctx context.Context) error {
+	oktetoLog.Spinner("Starting the file synchronization service...")
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	for _, f := range e.folders {
+		ignore, err := loadIgnoreList(e.fs, f.localPath)
+		if err != nil {
+			return fmt.Errorf("failed to read the '.stignore' file of '%s': %w", f.localPath, err)
+		}
+		f.ignore = ignore
+	}
+
+	if e.runner != nil {
+		return nil
+	}
+	session, err := ssh.NewSession(ctx, e.iface, e.remotePort)
+	if err != nil {
+		return err
+	}
+	e.runner = session
+	return nil
+}
+
+// GetInSynchronizationFile returns the file being sent to the development container
+func (e *Engine) GetInSynchronizationFile(_ context.Context) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.inSynchronizationFile
+}
+
+// WaitForCompletion sends the local files missing or different in the development container, reporting the progress from 0 to 100
+func (e *Engine) WaitForCompletion(ctx context.Context, reporter chan float64) error {
+	defer close(reporter)
+
+	changes := map[*folder][]string{}
+	total := 0
+	for _, f := range e.folders {
+		local, err := buildLocalIndex(e.fs, f.localPath, f.ignore)
+		if err != nil {
+			return fmt.Errorf("failed to scan '%s': %w", f.localPath, err)
+		}
+		var out bytes.Buffer
+		if err := e.runner.Run(nil, &out, remoteIndexCommand(f.remotePath)); err != nil {
+			return fmt.Errorf("failed to scan '%s' in the development container: %w", f.remotePath, err)
+		}
+		remote, err := parseRemoteIndex(&out)
+		if err != nil {
+			return err
+		}
+		changes[f] = changedFiles(local, remote)
+		total += len(changes[f])
+	}
+	oktetoLog.Infof("%d files need to be synchronized", total)
+
+	sent := 0
+	for _, f := range e.folders {
+		files := changes[f]
+		for len(files) > 0 {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			batch := files
+			if len(batch) > uploadBatchSize {
+				batch = files[:uploadBatchSize]
+			}
+			files = files[len(batch):]
+			if err := e.upload(f, batch); err != nil {
+				return err
+			}
+			sent += len(batch)
+			reporter <- float64(sent) * 100 / float64(total)
+		}
+	}
+	e.setInSynchronizationFile("")
+	return nil
+}
+
+// Watch keeps the development container updated with the local changes until the context is cancelled.
+// A lost connection is reported on disconnect
+func (e *Engine) Watch(ctx context.Context, disconnect chan error) error {
+	w, err := newWatcher(e.fs, e.folders)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer w.close()
+		for {
+			select {
+			case changes := <-w.changes:
+				if err := e.apply(ctx, changes); err != nil {
+					oktetoLog.Infof("failed to synchronize changes: %s", err)
+					select {
+					case disconnect <- oktetoErrors.ErrLostSyncthing:
+					case <-ctx.Done():
+					}
+					return
+				}
+			case err := <-w.errors:
+				oktetoLog.Infof("error watching local folders: %s", err)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go w.run(ctx)
+	return nil
+}
+
+// WatchSyncedItems sends to synced the files applied in the development container until the context is cancelled
+func (e *Engine) WatchSyncedItems(ctx context.Context, synced chan<- syncthing.SyncedItems) {
+	e.mu.Lock()
+	e.subscribers = append(e.subscribers, synced)
+	e.mu.Unlock()
+	<-ctx.Done()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, s := range e.subscribers {
+		if s == synced {
+			e.subscribers = append(e.subscribers[:i], e.subscribers[i+1:]...)
+			break
+		}
+	}
+}
+
+// Ping returns if the SSH server of the development container still runs commands
+func (e *Engine) Ping(_ context.Context) bool {
+	if e.runner == nil {
+		return false
+	}
+	return e.runner.Run(nil, io.Discard, []string{"true"}) == nil
+}
+
+// Stop closes the connection with the development container
+func (e *Engine) Stop() error {
+	if e.runner == nil {
+		return nil
+	}
+	return e.runner.Close()
+}
+
+// apply sends the changed files to the development container and deletes the ones removed locally
+func (e *Engine) apply(ctx context.Context, changes map[*folder]*changeSet) error {
+	for f, c := range changes {
+		if len(c.deleted) > 0 {
+			oktetoLog.Infof("deleting %d files from '%s'", len(c.deleted), f.remotePath)
+			if err := e.runner.Run(nil, io.Discard, deleteCommand(f.remotePath, c.deleted)); err != nil {
+				return err
+			}
+		}
+		if len(c.updated) > 0 {
+			oktetoLog.Infof("sending %d files to '%s'", len(c.updated), f.remotePath)
+			if err := e.upload(f, c.updated); err != nil {
+				return err
+			}
+		}
+		e.notify(ctx, f, append(c.updated, c.deleted...))
+	}
+	return nil
+}
+
+func (e *Engine) upload(f *folder, files []string) error {
+	e.setInSynchronizationFile(files[len(files)-1])
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(e.fs, f.localPath, files, pw))
+	}()
+	err := e.runner.Run(pr, io.Discard, uploadCommand(f.remotePath))
+	if closeErr := pr.Close(); closeErr != nil {
+		oktetoLog.Debugf("Error closing tar stream: %s", closeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to send files to '%s': %w", f.remotePath, err)
+	}
+	return nil
+}
+
+func (e *Engine) notify(ctx context.Context, f *folder, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	// the items are sent without holding the lock, so a slow subscriber doesn't block the engine
+	e.mu.Lock()
+	subscribers := make([]chan<- syncthing.SyncedItems, len(e.subscribers))
+	copy(subscribers, e.subscribers)
+	e.mu.Unlock()
+	for _, s := range subscribers {
+		select {
+		case s <- syncthing.SyncedItems{
+			Folder: &syncthing.Folder{LocalPath: f.localPath, RemotePath: f.remotePath},
+			Items:  items,
+		}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (e *Engine) setInSynchronizationFile(name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.inSynchronizationFile = name
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshsync
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRunner struct {
+	remoteIndex string
+	err         error
+	commands    [][]string
+	uploaded    map[string]string
+}
+
+func (r *fakeRunner) Run(stdin io.Reader, stdout io.Writer, command []string) error {
+	r.commands = append(r.commands, command)
+	if r.err != nil {
+		return r.err
+	}
+	switch {
+	case strings.Contains(strings.Join(command, " "), "sha256sum"):
+		_, err := stdout.Write([]byte(r.remoteIndex))
+		return err
+	case stdin != nil:
+		tr := tar.NewReader(stdin)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			content, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			r.uploaded[header.Name] = string(content)
+		}
+	}
+	return nil
+}
+
+func (*fakeRunner) Close() error {
+	return nil
+}
+
+func newTestEngine(t *testing.T, runner *fakeRunner) *Engine {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/app/main.go", []byte("package main"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/app/README.md", []byte("readme"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/app/.git/HEAD", []byte("ref"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/app/.stignore", []byte(".git\n"), 0600))
+
+	dev := &model.Dev{
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{{LocalPath: "/app", RemotePath: "/okteto"}},
+		},
+	}
+	e := New(dev, fs)
+	e.runner = runner
+	require.NoError(t, e.Start(context.Background()))
+	return e
+}
+
+func TestWaitForCompletion(t *testing.T) {
+	runner := &fakeRunner{
+		// README.md is up to date and main.go has a different content
+		remoteIndex: "c0c2b8be1b4ad6c8e2a79d3086c5c6dcd0d9ad2bd7a0b3b4d3a9c1de42ebea1d  ./main.go\n" +
+			"a5f4aa8ba5b5be1b8bc0f1b4a5e6b5d5b6d6c53c5b1a6c0bca1e0a9b0a3e4d51  ./old.go\n",
+		uploaded: map[string]string{},
+	}
+	e := newTestEngine(t, runner)
+	readme, err := hashFile(e.fs, "/app/README.md")
+	require.NoError(t, err)
+	runner.remoteIndex += readme + "  ./README.md\n"
+
+	reporter := make(chan float64, 10)
+	require.NoError(t, e.WaitForCompletion(context.Background(), reporter))
+
+	progress := []float64{}
+	for p := range reporter {
+		progress = append(progress, p)
+	}
+	assert.Equal(t, []float64{100}, progress)
+	assert.Equal(t, map[string]string{".stignore": ".git\n", "main.go": "package main"}, runner.uploaded)
+	assert.Equal(t, uploadCommand("/okteto"), runner.commands[len(runner.commands)-1])
+}
+
+func TestWaitForCompletionError(t *testing.T) {
+	runner := &fakeRunner{uploaded: map[string]string{}}
+	e := newTestEngine(t, runner)
+	runner.err = errors.New("connection lost")
+
+	err := e.WaitForCompletion(context.Background(), make(chan float64, 10))
+	assert.ErrorContains(t, err, "connection lost")
+}
+
+func TestApply(t *testing.T) {
+	runner := &fakeRunner{uploaded: map[string]string{}}
+	e := newTestEngine(t, runner)
+	f := e.folders[0]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	synced := make(chan syncthing.SyncedItems, 1)
+	e.subscribers = append(e.subscribers, synced)
+
+	changes := map[*folder]*changeSet{
+		f: {updated: []string{"main.go"}, deleted: []string{"old.go", "tmp"}},
+	}
+	require.NoError(t, e.apply(ctx, changes))
+
+	assert.Equal(t, []string{"rm", "-rf", "--", "/okteto/old.go", "/okteto/tmp"}, runner.commands[0])
+	assert.Equal(t, map[string]string{"main.go": "package main"}, runner.uploaded)
+	items := <-synced
+	assert.Equal(t, "/app", items.Folder.LocalPath)
+	assert.Equal(t, []string{"main.go", "old.go", "tmp"}, items.Items)
+}
+
+func TestNotifyDoesNotHoldTheLock(t *testing.T) {
+	runner := &fakeRunner{uploaded: map[string]string{}}
+	e := newTestEngine(t, runner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// nobody reads from this subscriber until the engine lock is taken again
+	synced := make(chan syncthing.SyncedItems)
+	e.subscribers = append(e.subscribers, synced)
+
+	go e.notify(ctx, e.folders[0], []string{"main.go"})
+	time.Sleep(50 * time.Millisecond)
+	e.setInSynchronizationFile("main.go")
+	assert.Equal(t, "main.go", e.GetInSynchronizationFile(ctx))
+
+	items := <-synced
+	assert.Equal(t, []string{"main.go"}, items.Items)
+}
+
+func TestPing(t *testing.T) {
+	runner := &fakeRunner{uploaded: map[string]string{}}
+	e := newTestEngine(t, runner)
+	assert.True(t, e.Ping(context.Background()))
+
+	runner.err = errors.New("connection lost")
+	assert.False(t, e.Ping(context.Background()))
+}
+
+func Test_parseRemoteIndex(t *testing.T) {
+	out := "abc  ./main.go\n\ndef  ./src/file with spaces.go\n"
+	index, err := parseRemoteIndex(strings.NewReader(out))
+	require.NoError(t, err)
+	assert.Equal(t, fileIndex{"main.go": "abc", "src/file with spaces.go": "def"}, index)
+
+	_, err = parseRemoteIndex(strings.NewReader("invalid"))
+	assert.Error(t, err)
+}
+
+func Test_changedFiles(t *testing.T) {
+	local := fileIndex{"a": "1", "b": "2", "c": "3"}
+	remote := fileIndex{"a": "1", "b": "0", "d": "4"}
+	assert.Equal(t, []string{"b", "c"}, changedFiles(local, remote))
+}
+
+func Test_resolve(t *testing.T) {
+	w := &watcher{
+		folders: []*folder{
+			{localPath: "/app", remotePath: "/okteto"},
+			{localPath: "/app/web", remotePath: "/web"},
+		},
+	}
+	tests := []struct {
+		name       string
+		file       string
+		expected   string
+		expectedFn string
+	}{
+		{name: "root folder", file: "/app/main.go", expected: "/app", expectedFn: "main.go"},
+		{name: "nested folder", file: "/app/web/src/index.js", expected: "/app/web", expectedFn: "src/index.js"},
+		{name: "outside", file: "/application/main.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, rel := w.resolve(tt.file)
+			if tt.expected == "" {
+				assert.Nil(t, f)
+				return
+			}
+			require.NotNil(t, f)
+			assert.Equal(t, tt.expected, f.localPath)
+			assert.Equal(t, tt.expectedFn, rel)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshsync
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+const stignoreFile = ".stignore"
+
+type ignorePattern struct {
+	pattern  string
+	negated  bool
+	anchored bool
+}
+
+// ignoreList is the subset of the '.stignore' syntax supported by the ssh sync engine.
+// As in syncthing, the first pattern matching a path decides if the path is ignored
+type ignoreList struct {
+	patterns []ignorePattern
+}
+
+func loadIgnoreList(fs afero.Fs, folder string) (*ignoreList, error) {
+	stignorePath := filepath.Join(folder, stignoreFile)
+	if ok, _ := afero.Exists(fs, stignorePath); !ok {
+		return &ignoreList{}, nil
+	}
+	f, err := fs.Open(stignorePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", stignoreFile, err)
+		}
+	}()
+	return parseIgnoreList(f)
+}
+
+func parseIgnoreList(r io.Reader) (*ignoreList, error) {
+	result := &ignoreList{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if strings.HasPrefix(line, "#include") {
+			oktetoLog.Infof("'#include' is not supported by the ssh sync engine, ignoring '%s'", line)
+			continue
+		}
+		p := ignorePattern{}
+		for {
+			switch {
+			case strings.HasPrefix(line, "!"):
+				p.negated = true
+				line = line[1:]
+				continue
+			case strings.HasPrefix(line, "(?d)"), strings.HasPrefix(line, "(?i)"):
+				line = line[4:]
+				continue
+			}
+			break
+		}
+		if strings.HasPrefix(line, "/") {
+			p.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		line = strings.TrimSuffix(line, "/")
+		if line == "" {
+			continue
+		}
+		p.pattern = line
+		result.patterns = append(result.patterns, p)
+	}
+	return result, scanner.Err()
+}
+
+// isIgnored returns true if the path, relative to the synchronized folder and separated by slashes, is ignored.
+// A path is also ignored when one of its parent directories is ignored
+func (l *ignoreList) isIgnored(rel string) bool {
+	if l == nil || rel == stignoreFile {
+		return false
+	}
+	parts := strings.Split(rel, "/")
+	for i := 1; i <= len(parts); i++ {
+		if ignored, ok := l.match(parts[:i]); ok && ignored {
+			return true
+		}
+	}
+	return false
+}
+
+// match returns if the path is ignored by the first pattern matching it, and if any pattern matched it
+func (l *ignoreList) match(parts []string) (bool, bool) {
+	for _, p := range l.patterns {
+		patternParts := strings.Split(p.pattern, "/")
+		if !p.anchored {
+			patternParts = append([]string{"**"}, patternParts...)
+		}
+		if matchParts(patternParts, parts) {
+			return !p.negated, true
+		}
+	}
+	return false, false
+}
+
+// matchParts matches the path elements against the pattern elements, where '**' matches any number of elements
+func matchParts(pattern, parts []string) bool {
+	if len(pattern) == 0 {
+		return len(parts) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchParts(pattern[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(parts) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+		return false
+	}
+	return matchParts(pattern[1:], parts[1:])
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshsync
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ignoreList(t *testing.T) {
+	stignore := `// comment
+#include .stglobalignore
+.git
+(?d)*.pyc
+/build
+node_modules/
+!keep.log
+*.log
+docs/**/*.tmp
+`
+	l, err := parseIgnoreList(strings.NewReader(stignore))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: ".git", expected: true},
+		{path: ".git/config", expected: true},
+		{path: "src/.git/HEAD", expected: true},
+		{path: "app.pyc", expected: true},
+		{path: "src/app.pyc", expected: true},
+		{path: "build/out", expected: true},
+		{path: "src/build/out", expected: false},
+		{path: "web/node_modules/react/index.js", expected: true},
+		{path: "keep.log", expected: false},
+		{path: "server.log", expected: true},
+		{path: "docs/a/b/file.tmp", expected: true},
+		{path: "docs/file.tmp", expected: true},
+		{path: "src/file.tmp", expected: false},
+		{path: "main.go", expected: false},
+		{path: ".stignore", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, l.isIgnored(tt.path))
+		})
+	}
+}
+
+func Test_ignoreListNil(t *testing.T) {
+	var l *ignoreList
+	assert.False(t, l.isIgnored("main.go"))
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshsync
+
+import (
+	"archive/tar"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+// fileIndex maps the path of each file, relative to the synchronized folder and separated by slashes, to its sha256 hash
+type fileIndex map[string]string
+
+// buildLocalIndex hashes the regular files of the folder that are not ignored
+func buildLocalIndex(fs afero.Fs, folder string, ignore *ignoreList) (fileIndex, error) {
+	result := fileIndex{}
+	err := afero.Walk(fs, folder, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(folder, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if ignore.isIgnored(rel) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		hash, err := hashFile(fs, p)
+		if err != nil {
+			return err
+		}
+		result[rel] = hash
+		return nil
+	})
+	return result, err
+}
+
+func hashFile(fs afero.Fs, p string) (string, error) {
+	f, err := fs.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", p, err)
+		}
+	}()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// remoteIndexCommand returns the command that prints the sha256 hash of every file of the remote folder
+func remoteIndexCommand(remotePath string) []string {
+	return []string{"sh", "-c", `mkdir -p "$1" && cd "$1" && find . -type f -exec sha256sum {} +`, "sh", remotePath}
+}
+
+// parseRemoteIndex parses the output of the sha256sum command
+func parseRemoteIndex(r io.Reader) (fileIndex, error) {
+	result := fileIndex{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		hash, name, found := strings.Cut(line, "  ")
+		if !found {
+			return nil, fmt.Errorf("unexpected output of sha256sum: '%s'", line)
+		}
+		result[strings.TrimPrefix(name, "./")] = hash
+	}
+	return result, scanner.Err()
+}
+
+// changedFiles returns the sorted list of files that are missing or different in the remote index
+func changedFiles(local, remote fileIndex) []string {
+	result := []string{}
+	for name, hash := range local {
+		if remote[name] != hash {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// uploadCommand returns the command that extracts a tar stream in the remote folder
+func uploadCommand(remotePath string) []string {
+	return []string{"sh", "-c", `mkdir -p "$1" && tar -xf - -C "$1"`, "sh", remotePath}
+}
+
+// deleteCommand returns the command that deletes the files of the remote folder
+func deleteCommand(remotePath string, files []string) []string {
+	result := []string{"rm", "-rf", "--"}
+	for _, f := range files {
+		result = append(result, remotePath+"/"+f)
+	}
+	return result
+}
+
+// writeTar writes the files of the folder to w as a tar stream. Directories are written without their content
+func writeTar(fs afero.Fs, folder string, files []string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	for _, name := range files {
+		p := filepath.Join(folder, filepath.FromSlash(name))
+		info, err := fs.Stat(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				oktetoLog.Infof("file '%s' was deleted before being synchronized", p)
+				continue
+			}
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			continue
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			continue
+		}
+		if err := copyFile(fs, p, header.Size, tw); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// copyFile copies exactly size bytes, as declared in the tar header, even if the file changed after it was stat'ed
+func copyFile(fs afero.Fs, p string, size int64, w io.Writer) error {
+	f, err := fs.Open(p)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", p, err)
+		}
+	}()
+	_, err = io.CopyN(w, f, size)
+	return err
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshsync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+// watchDebounce is the time to wait for more local changes before sending them to the development container
+const watchDebounce = 500 * time.Millisecond
+
+// changeSet is the list of files of a folder to send to or delete from the development container
+type changeSet struct {
+	updated []string
+	deleted []string
+}
+
+// watcher watches the local folders recursively, since fsnotify only watches the directories explicitly added
+type watcher struct {
+	fs      afero.Fs
+	folders []*folder
+	notify  *fsnotify.Watcher
+	changes chan map[*folder]*changeSet
+	errors  chan error
+}
+
+func newWatcher(fs afero.Fs, folders []*folder) (*watcher, error) {
+	notify, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &watcher{
+		fs:      fs,
+		folders: folders,
+		notify:  notify,
+		changes: make(chan map[*folder]*changeSet),
+		errors:  make(chan error, 1),
+	}
+	for _, f := range folders {
+		if _, err := w.walk(f, f.localPath); err != nil {
+			w.close()
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+func (w *watcher) run(ctx context.Context) {
+	pending := map[*folder]map[string]bool{}
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+	for {
+		select {
+		case event, ok := <-w.notify.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			f, rel := w.resolve(event.Name)
+			if f == nil || f.ignore.isIgnored(rel) {
+				continue
+			}
+			if pending[f] == nil {
+				pending[f] = map[string]bool{}
+			}
+			pending[f][rel] = true
+			timer.Reset(watchDebounce)
+		case err, ok := <-w.notify.Errors:
+			if !ok {
+				return
+			}
+			select {
+			case w.errors <- err:
+			default:
+			}
+		case <-timer.C:
+			changes := w.collect(pending)
+			pending = map[*folder]map[string]bool{}
+			if len(changes) == 0 {
+				continue
+			}
+			select {
+			case w.changes <- changes:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// resolve returns the folder of the file and its path relative to the folder, separated by slashes
+func (w *watcher) resolve(name string) (*folder, string) {
+	var result *folder
+	rel := ""
+	for _, f := range w.folders {
+		r, err := filepath.Rel(f.localPath, name)
+		if err != nil || r == "." || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if result == nil || len(f.localPath) > len(result.localPath) {
+			result = f
+			rel = filepath.ToSlash(r)
+		}
+	}
+	return result, rel
+}
+
+// collect classifies the pending files as updated or deleted depending on their current state.
+// New directories are watched and all their files are sent
+func (w *watcher) collect(pending map[*folder]map[string]bool) map[*folder]*changeSet {
+	result := map[*folder]*changeSet{}
+	for f, files := range pending {
+		c := &changeSet{}
+		updated := map[string]bool{}
+		for rel := range files {
+			p := filepath.Join(f.localPath, filepath.FromSlash(rel))
+			info, err := w.fs.Stat(p)
+			if err != nil {
+				if os.IsNotExist(err) {
+					c.deleted = append(c.deleted, rel)
+				} else {
+					oktetoLog.Infof("failed to get the state of '%s': %s", p, err)
+				}
+				continue
+			}
+			if !info.IsDir() {
+				if info.Mode().IsRegular() {
+					updated[rel] = true
+				}
+				continue
+			}
+			entries, err := w.walk(f, p)
+			if err != nil {
+				oktetoLog.Infof("failed to watch '%s': %s", p, err)
+			}
+			for _, e := range entries {
+				updated[e] = true
+			}
+		}
+		for rel := range updated {
+			c.updated = append(c.updated, rel)
+		}
+		if len(c.updated) == 0 && len(c.deleted) == 0 {
+			continue
+		}
+		// parent directories are sorted before their files in the tar stream
+		sort.Strings(c.updated)
+		sort.Strings(c.deleted)
+		result[f] = c
+	}
+	return result
+}
+
+// walk watches the directory and its subdirectories, returning the relative paths of the entries that are not ignored
+func (w *watcher) walk(f *folder, dir string) ([]string, error) {
+	result := []string{}
+	err := afero.Walk(w.fs, dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(f.localPath, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != "." && f.ignore.isIgnored(rel) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			if err := w.notify.Add(p); err != nil {
+				return err
+			}
+		}
+		if rel != "." {
+			result = append(result, rel)
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (w *watcher) close() {
+	if err := w.notify.Close(); err != nil {
+		oktetoLog.Infof("failed to close the file watcher: %s", err)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+const (
+	directoryType = "FILE_INFO_TYPE_DIRECTORY"
+
+	// shortDeviceIDLength is the length of the device ID included in the name of the conflict copies
+	shortDeviceIDLength = 7
+)
+
+// conflictRegex matches the name of the copies created by syncthing: <name>.sync-conflict-<date>-<time>-<device>[.<ext>]
+var conflictRegex = regexp.MustCompile(`^(.+)\.sync-conflict-\d{8}-\d{6}-([A-Z0-9]{7})(\.[^.]*)?$`)
+
+// Conflict represents the copy created by syncthing when a file was modified in both sides.
+// The copy keeps the version of the device that lost the conflict, and the original file keeps the other version
+type Conflict struct {
+	Folder   *Folder
+	Path     string
+	Original string
+	DeviceID string
+}
+
+// browseEntry represents an entry of the response of the syncthing browse API
+type browseEntry struct {
+	Name     string        `json:"name"`
+	Type     string        `json:"type"`
+	Children []browseEntry `json:"children"`
+}
+
+// GetConflicts returns the conflict copies of the synchronized folders known by the local syncthing
+func (s *Syncthing) GetConflicts(ctx context.Context) ([]Conflict, error) {
+	result := []Conflict{}
+	for _, folder := range s.Folders {
+		params := map[string]string{"folder": GetFolderName(folder)}
+		body, err := s.APICall(ctx, "rest/db/browse", "GET", 200, params, true, nil, true, 3)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the files of folder '%s': %w", folder.LocalPath, err)
+		}
+		var entries []browseEntry
+		if err := json.Unmarshal(body, &entries); err != nil {
+			return nil, fmt.Errorf("error unmarshalling the files of folder '%s': %w", folder.LocalPath, err)
+		}
+		result = append(result, findConflicts(folder, "", entries)...)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Folder.LocalPath+result[i].Path < result[j].Folder.LocalPath+result[j].Path
+	})
+	return result, nil
+}
+
+func findConflicts(folder *Folder, dir string, entries []browseEntry) []Conflict {
+	result := []Conflict{}
+	for _, e := range entries {
+		p := path.Join(dir, e.Name)
+		if e.Type == directoryType {
+			result = append(result, findConflicts(folder, p, e.Children)...)
+			continue
+		}
+		if c, ok := parseConflict(folder, p); ok {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// parseConflict returns the conflict represented by the path, relative to the folder, if it is a conflict copy
+func parseConflict(folder *Folder, p string) (Conflict, bool) {
+	matches := conflictRegex.FindStringSubmatch(path.Base(p))
+	if matches == nil {
+		return Conflict{}, false
+	}
+	return Conflict{
+		Folder:   folder,
+		Path:     p,
+		Original: path.Join(path.Dir(p), matches[1]+matches[3]),
+		DeviceID: matches[2],
+	}, true
+}
+
+// IsLocalCopy returns true if the conflict copy keeps the local version of the file
+func (c *Conflict) IsLocalCopy() bool {
+	return c.DeviceID == LocalDeviceID[:shortDeviceIDLength]
+}
+
+// GetLocalVersionPath returns the path of the file with the local version
+func (c *Conflict) GetLocalVersionPath() string {
+	if c.IsLocalCopy() {
+		return c.getPath(c.Path)
+	}
+	return c.getPath(c.Original)
+}
+
+// GetRemoteVersionPath returns the path of the file with the remote version
+func (c *Conflict) GetRemoteVersionPath() string {
+	if c.IsLocalCopy() {
+		return c.getPath(c.Original)
+	}
+	return c.getPath(c.Path)
+}
+
+// Resolve keeps the local or the remote version in the original file and deletes the conflict copy.
+// Syncthing synchronizes the result to the other side
+func (c *Conflict) Resolve(keepLocal bool) error {
+	if keepLocal == c.IsLocalCopy() {
+		return os.Rename(c.getPath(c.Path), c.getPath(c.Original))
+	}
+	return os.Remove(c.getPath(c.Path))
+}
+
+func (c *Conflict) getPath(p string) string {
+	return filepath.Join(c.Folder.LocalPath, filepath.FromSlash(p))
+}
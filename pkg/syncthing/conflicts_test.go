@@ -0,0 +1,114 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseConflict(t *testing.T) {
+	folder := &Folder{Name: "1", LocalPath: "/src"}
+	tests := []struct {
+		name     string
+		path     string
+		expected *Conflict
+	}{
+		{
+			name:     "with extension",
+			path:     "pkg/main.sync-conflict-20230102-150405-ABKAVQF.go",
+			expected: &Conflict{Folder: folder, Path: "pkg/main.sync-conflict-20230102-150405-ABKAVQF.go", Original: "pkg/main.go", DeviceID: "ABKAVQF"},
+		},
+		{
+			name:     "with several extensions",
+			path:     "archive.tar.sync-conflict-20230102-150405-ATOPHFJ.gz",
+			expected: &Conflict{Folder: folder, Path: "archive.tar.sync-conflict-20230102-150405-ATOPHFJ.gz", Original: "archive.tar.gz", DeviceID: "ATOPHFJ"},
+		},
+		{
+			name:     "without extension",
+			path:     "Makefile.sync-conflict-20230102-150405-ATOPHFJ",
+			expected: &Conflict{Folder: folder, Path: "Makefile.sync-conflict-20230102-150405-ATOPHFJ", Original: "Makefile", DeviceID: "ATOPHFJ"},
+		},
+		{
+			name: "regular file",
+			path: "pkg/main.go",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := parseConflict(folder, tt.path)
+			if tt.expected == nil {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, *tt.expected, c)
+		})
+	}
+}
+
+func Test_findConflicts(t *testing.T) {
+	folder := &Folder{Name: "1", LocalPath: "/src"}
+	body := `[
+  {"name": "main.go", "type": "FILE_INFO_TYPE_FILE"},
+  {"name": "pkg", "type": "FILE_INFO_TYPE_DIRECTORY", "children": [
+    {"name": "api.go", "type": "FILE_INFO_TYPE_FILE"},
+    {"name": "api.sync-conflict-20230102-150405-ATOPHFJ.go", "type": "FILE_INFO_TYPE_FILE"}
+  ]}
+]`
+	var entries []browseEntry
+	require.NoError(t, json.Unmarshal([]byte(body), &entries))
+
+	result := findConflicts(folder, "", entries)
+	require.Len(t, result, 1)
+	assert.Equal(t, "pkg/api.go", result[0].Original)
+	assert.False(t, result[0].IsLocalCopy())
+}
+
+func TestConflictResolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		deviceID  string
+		keepLocal bool
+		expected  string
+	}{
+		{name: "keep local with local copy", deviceID: LocalDeviceID[:7], keepLocal: true, expected: "copy"},
+		{name: "keep remote with local copy", deviceID: LocalDeviceID[:7], keepLocal: false, expected: "original"},
+		{name: "keep local with remote copy", deviceID: DefaultRemoteDeviceID[:7], keepLocal: true, expected: "original"},
+		{name: "keep remote with remote copy", deviceID: DefaultRemoteDeviceID[:7], keepLocal: false, expected: "copy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			copyName := "main.sync-conflict-20230102-150405-" + tt.deviceID + ".go"
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("original"), 0600))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, copyName), []byte("copy"), 0600))
+
+			c, ok := parseConflict(&Folder{LocalPath: dir}, copyName)
+			require.True(t, ok)
+			require.NoError(t, c.Resolve(tt.keepLocal))
+
+			content, err := os.ReadFile(filepath.Join(dir, "main.go"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+			_, err = os.Stat(filepath.Join(dir, copyName))
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	itemFinishedEvent = "ItemFinished"
+	stateChangedEvent = "StateChanged"
+	idleState         = "idle"
+)
+
+// FolderEvent represents an event of the syncthing events API related to a folder
+type FolderEvent struct {
+	ID   int             `json:"id"`
+	Type string          `json:"type"`
+	Data FolderEventData `json:"data"`
+}
+
+// FolderEventData represents the data of ItemFinished and StateChanged events
+type FolderEventData struct {
+	Folder string  `json:"folder"`
+	Item   string  `json:"item"`
+	Error  *string `json:"error"`
+	To     string  `json:"to"`
+}
+
+// SyncedItems represents the files of a folder applied by the remote syncthing
+type SyncedItems struct {
+	Folder *Folder
+	Items  []string
+}
+
+// WatchSyncedItems sends to synced the files applied by the remote syncthing every time a folder goes back to idle
+func (s *Syncthing) WatchSyncedItems(ctx context.Context, synced chan<- SyncedItems) {
+	since := s.getLastFolderEventID(ctx)
+	pending := map[string][]string{}
+	for {
+		events, err := s.getFolderEvents(ctx, since, 0)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			oktetoLog.Infof("error getting syncthing events: %s", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+				continue
+			}
+		}
+		for _, e := range events {
+			since = e.ID
+			for _, items := range s.processFolderEvent(e, pending) {
+				select {
+				case synced <- items:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+}
+
+// getLastFolderEventID returns the id of the last event so changes synchronized before calling it are ignored
+func (s *Syncthing) getLastFolderEventID(ctx context.Context) int {
+	events, err := s.getFolderEvents(ctx, 0, 1)
+	if err != nil {
+		oktetoLog.Infof("error getting last syncthing event: %s", err)
+		return 0
+	}
+	if len(events) == 0 {
+		return 0
+	}
+	return events[len(events)-1].ID
+}
+
+func (s *Syncthing) getFolderEvents(ctx context.Context, since, limit int) ([]FolderEvent, error) {
+	params := map[string]string{
+		"since":   strconv.Itoa(since),
+		"timeout": "2",
+		"events":  itemFinishedEvent + "," + stateChangedEvent,
+	}
+	if limit > 0 {
+		params["limit"] = strconv.Itoa(limit)
+		params["timeout"] = "0"
+	}
+	body, err := s.APICall(ctx, "rest/events", "GET", 200, params, false, nil, true, 3)
+	if err != nil {
+		return nil, err
+	}
+	events := []FolderEvent{}
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// processFolderEvent accumulates the finished items of every folder and returns them once the folder is idle
+func (s *Syncthing) processFolderEvent(e FolderEvent, pending map[string][]string) []SyncedItems {
+	switch e.Type {
+	case itemFinishedEvent:
+		if e.Data.Error != nil {
+			return nil
+		}
+		pending[e.Data.Folder] = append(pending[e.Data.Folder], e.Data.Item)
+	case stateChangedEvent:
+		if e.Data.To != idleState || len(pending[e.Data.Folder]) == 0 {
+			return nil
+		}
+		items := pending[e.Data.Folder]
+		delete(pending, e.Data.Folder)
+		for _, folder := range s.Folders {
+			if GetFolderName(folder) == e.Data.Folder {
+				return []SyncedItems{{Folder: folder, Items: items}}
+			}
+		}
+	}
+	return nil
+}
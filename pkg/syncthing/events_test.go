@@ -0,0 +1,44 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_processFolderEvent(t *testing.T) {
+	folder := &Folder{Name: "1", LocalPath: "/src", RemotePath: "/app"}
+	s := &Syncthing{Folders: []*Folder{folder}}
+	pending := map[string][]string{}
+	failed := "permission denied"
+
+	events := []FolderEvent{
+		{ID: 1, Type: stateChangedEvent, Data: FolderEventData{Folder: "okteto-1", To: "syncing"}},
+		{ID: 2, Type: itemFinishedEvent, Data: FolderEventData{Folder: "okteto-1", Item: "main.go"}},
+		{ID: 3, Type: itemFinishedEvent, Data: FolderEventData{Folder: "okteto-1", Item: "pkg/api.go"}},
+		{ID: 4, Type: itemFinishedEvent, Data: FolderEventData{Folder: "okteto-1", Item: "secret", Error: &failed}},
+	}
+	for _, e := range events {
+		assert.Empty(t, s.processFolderEvent(e, pending))
+	}
+
+	result := s.processFolderEvent(FolderEvent{ID: 5, Type: stateChangedEvent, Data: FolderEventData{Folder: "okteto-1", To: "idle"}}, pending)
+	assert.Equal(t, []SyncedItems{{Folder: folder, Items: []string{"main.go", "pkg/api.go"}}}, result)
+	assert.Empty(t, pending)
+
+	// going back to idle without changes doesn't notify
+	assert.Empty(t, s.processFolderEvent(FolderEvent{ID: 6, Type: stateChangedEvent, Data: FolderEventData{Folder: "okteto-1", To: "idle"}}, pending))
+}
@@ -54,6 +54,10 @@ const (
 	keyFile    = "key.pem"
 	configFile = "config.xml"
 
+	sendOnlyFolderType    = "sendonly"
+	receiveOnlyFolderType = "receiveonly"
+	sendReceiveFolderType = "sendreceive"
+
 	// DefaultRemoteDeviceID remote syncthing device ID
 	DefaultRemoteDeviceID = "ATOPHFJ-VPVLDFY-QVZDCF2-OQQ7IOW-OG4DIXF-OA7RWU3-ZYA4S22-SI4XVAU"
 	// LocalDeviceID local syncthing device ID
@@ -106,15 +110,49 @@ type Folder struct {
 	Name        string `yaml:"name"`
 	LocalPath   string `yaml:"localPath"`
 	RemotePath  string `yaml:"remotePath"`
+	Direction   string `yaml:"direction,omitempty"`
 	Overwritten bool   `yaml:"-"`
 }
 
+// GetLocalType returns the type of the folder in the local syncthing. Bidirectional folders use the type of the syncthing instance
+func (f *Folder) GetLocalType(defaultType string) string {
+	switch f.Direction {
+	case model.SyncDirectionUp:
+		return sendOnlyFolderType
+	case model.SyncDirectionDown:
+		return receiveOnlyFolderType
+	default:
+		return defaultType
+	}
+}
+
+// GetRemoteType returns the type of the folder in the remote syncthing
+func (f *Folder) GetRemoteType() string {
+	switch f.Direction {
+	case model.SyncDirectionUp:
+		return receiveOnlyFolderType
+	case model.SyncDirectionDown:
+		return sendOnlyFolderType
+	default:
+		return sendReceiveFolderType
+	}
+}
+
 // Status represents the status of a syncthing folder.
 type Status struct {
 	State      string `json:"state"`
 	PullErrors int64  `json:"pullErrors"`
 }
 
+// FolderStatus represents the synchronization status of a folder returned by the db status API
+type FolderStatus struct {
+	State                   string `json:"state"`
+	GlobalBytes             int64  `json:"globalBytes"`
+	NeedBytes               int64  `json:"needBytes"`
+	PullErrors              int64  `json:"pullErrors"`
+	ReceiveOnlyChangedFiles int64  `json:"receiveOnlyChangedFiles"`
+}
+
 // StateChangedEvent represents state changed in syncthing.
 type StateChangedEvent struct {
 	Type string                `json:"type"`
@@ -229,7 +267,7 @@ func New(dev *model.Dev) (*Syncthing, error) {
 		LocalPort:        listenPort,
 		RemoteGUIPort:    remoteGUIPort,
 		RemotePort:       remotePort,
-		Type:             "sendonly",
+		Type:             sendOnlyFolderType,
 		IgnoreDelete:     true,
 		Verbose:          dev.Sync.Verbose,
 		Folders:          []*Folder{},
@@ -250,6 +288,7 @@ func New(dev *model.Dev) (*Syncthing, error) {
 					Name:       strconv.Itoa(index),
 					LocalPath:  sync.LocalPath,
 					RemotePath: sync.RemotePath,
+					Direction:  sync.Direction,
 				},
 			)
 			index++
@@ -376,6 +415,11 @@ func (s *Syncthing) Ping(ctx context.Context, local bool) bool {
 // Overwrite overwrites local changes to the remote syncthing
 func (s *Syncthing) Overwrite(ctx context.Context) error {
 	for _, folder := range s.Folders {
+		if folder.GetLocalType(s.Type) != sendOnlyFolderType {
+			// only send-only folders can override the changes of the remote syncthing
+			folder.Overwritten = true
+			continue
+		}
 		oktetoLog.Infof("overriding local changes to the remote syncthing path=%s", folder.LocalPath)
 		params := getFolderParameter(folder)
 		_, err := s.APICall(ctx, "rest/db/override", "POST", 200, params, true, nil, false, 3)
@@ -510,6 +554,25 @@ func (s *Syncthing) GetCompletion(ctx context.Context, local bool, device string
 	return completion, nil
 }
 
+// GetFolderStatus returns the status of the folder in the local or remote syncthing
+func (s *Syncthing) GetFolderStatus(ctx context.Context, folder *Folder, local bool) (*FolderStatus, error) {
+	params := map[string]string{"folder": GetFolderName(folder)}
+	body, err := s.APICall(ctx, "rest/db/status", "GET", 200, params, local, nil, true, 3)
+	if err != nil {
+		oktetoLog.Infof("error calling 'rest/db/status' local=%t syncthing API: %s", local, err)
+		if strings.Contains(err.Error(), "Client.Timeout") {
+			return nil, oktetoErrors.ErrBusySyncthing
+		}
+		return nil, oktetoErrors.ErrLostSyncthing
+	}
+	status := &FolderStatus{}
+	if err := json.Unmarshal(body, status); err != nil {
+		oktetoLog.Infof("error unmarshalling 'rest/db/status' local=%t syncthing API: %s", local, err)
+		return nil, oktetoErrors.ErrLostSyncthing
+	}
+	return status, nil
+}
+
 // IsHealthy returns the syncthing error or nil
 func (s *Syncthing) IsHealthy(ctx context.Context, local bool, max int) error {
 	pullErrors, err := s.GetPullErrors(ctx, local)
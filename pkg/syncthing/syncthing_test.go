@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
 )
 
 func TestGetFiles(t *testing.T) {
@@ -41,3 +42,31 @@ func TestGetFiles(t *testing.T) {
 		t.Errorf("got %s, expected %s", info, expected)
 	}
 }
+
+func TestFolderTypes(t *testing.T) {
+	tests := []struct {
+		direction          string
+		expectedLocalType  string
+		expectedRemoteType string
+	}{
+		{direction: "", expectedLocalType: "sendonly", expectedRemoteType: "sendreceive"},
+		{direction: model.SyncDirectionBoth, expectedLocalType: "sendonly", expectedRemoteType: "sendreceive"},
+		{direction: model.SyncDirectionUp, expectedLocalType: "sendonly", expectedRemoteType: "receiveonly"},
+		{direction: model.SyncDirectionDown, expectedLocalType: "receiveonly", expectedRemoteType: "sendonly"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.direction, func(t *testing.T) {
+			f := &Folder{Direction: tt.direction}
+			if got := f.GetLocalType("sendonly"); got != tt.expectedLocalType {
+				t.Errorf("expected local type '%s', got '%s'", tt.expectedLocalType, got)
+			}
+			if got := f.GetRemoteType(); got != tt.expectedRemoteType {
+				t.Errorf("expected remote type '%s', got '%s'", tt.expectedRemoteType, got)
+			}
+		})
+	}
+	f := &Folder{Direction: model.SyncDirectionUp}
+	if got := f.GetLocalType("sendreceive"); got != "sendonly" {
+		t.Errorf("expected up folders to stay sendonly, got '%s'", got)
+	}
+}